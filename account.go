@@ -0,0 +1,40 @@
+package supadata
+
+import (
+	"sync"
+	"time"
+)
+
+// WithAccountCacheTTL caches the account information returned by Me for ttl, so that frequent plan and credit
+// checks do not each cost a round trip. Pass WithForceRefresh to Me to bypass the cached value.
+func WithAccountCacheTTL(ttl time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.accountCacheTTL = ttl
+	}
+}
+
+// accountCache holds the last account information returned by Me
+type accountCache struct {
+	mu      sync.Mutex
+	info    *AccountInfo
+	expires time.Time
+}
+
+// get returns a copy of the cached account information when it has not expired
+func (c *accountCache) get() (*AccountInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.info == nil || time.Now().After(c.expires) {
+		return nil, false
+	}
+	info := *c.info
+	return &info, true
+}
+
+func (c *accountCache) set(info *AccountInfo, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached := *info
+	c.info = &cached
+	c.expires = time.Now().Add(ttl)
+}
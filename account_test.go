@@ -0,0 +1,65 @@
+package supadata
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithAccountCacheTTL(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		jsonResponse(w, http.StatusOK, map[string]any{"plan": "Free", "usedCredits": requests})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithAccountCacheTTL(time.Minute))
+
+	first, err := client.Me()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first.Plan = "mutated"
+
+	second, err := client.Me()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 1 || second.UsedCredits != 1 || second.Plan != "Free" {
+		t.Errorf("expected an unaltered cached result after 1 request, got %+v after %d", second, requests)
+	}
+
+	refreshed, err := client.Me(WithForceRefresh())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 2 || refreshed.UsedCredits != 2 {
+		t.Errorf("expected a fresh result, got %+v after %d requests", refreshed, requests)
+	}
+
+	cached, _ := client.Me()
+	if requests != 2 || cached.UsedCredits != 2 {
+		t.Errorf("expected the refreshed result to be cached, got %+v after %d requests", cached, requests)
+	}
+}
+
+func TestMe_NotCachedByDefault(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		jsonResponse(w, http.StatusOK, map[string]any{"plan": "Free"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	for i := 0; i < 2; i++ {
+		if _, err := client.Me(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
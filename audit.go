@@ -0,0 +1,209 @@
+package supadata
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAuditMaxPages is the number of pages scanned by AuditLinks when LinkAuditOptions.MaxPages is not set
+const DefaultAuditMaxPages = 100
+
+// defaultLinkCheckTimeout bounds a single link check when no checker client is configured
+const defaultLinkCheckTimeout = 10 * time.Second
+
+// LinkAuditOptions customizes AuditLinks
+type LinkAuditOptions struct {
+	// MaxPages is the maximum number of pages of the site scanned for links, DefaultAuditMaxPages when zero
+	MaxPages int
+	// Concurrency is the number of pages scraped and links checked at the same time, DefaultFetchConcurrency when zero
+	Concurrency int
+	// SkipExternal only checks the links pointing to the host of the site
+	SkipExternal bool
+	// Checker is the HTTP client sending the link checks, a client with a 10s timeout when nil
+	Checker *http.Client
+}
+
+// BrokenLink is a link that could not be reached or answered with an error status
+type BrokenLink struct {
+	Url      string
+	External bool
+	// StatusCode is zero when the link could not be reached, see Err
+	StatusCode int
+	Err        error
+	// Sources are the pages containing the link, sorted
+	Sources []string
+}
+
+// LinkAudit is the report of AuditLinks
+type LinkAudit struct {
+	PagesScanned int
+	LinksChecked int
+	Broken       []BrokenLink
+	// Unscanned are the pages of the site that could not be scraped
+	Unscanned []string
+}
+
+// AuditLinks finds the dead links of a site. The pages of the site are discovered with Map and scraped for their
+// links, then every link is checked with a HEAD request, falling back to GET when the server does not support HEAD.
+// Only the link checks are sent outside of the API.
+func (s *Supadata) AuditLinks(ctx context.Context, siteUrl string, opts *LinkAuditOptions) (*LinkAudit, error) {
+	ctx = ensureLineage(ctx)
+	if opts == nil {
+		opts = &LinkAuditOptions{}
+	}
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = DefaultAuditMaxPages
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultFetchConcurrency
+	}
+	checker := opts.Checker
+	if checker == nil {
+		checker = &http.Client{Timeout: defaultLinkCheckTimeout}
+	}
+
+	site, err := url.Parse(siteUrl)
+	if err != nil {
+		return nil, err
+	}
+	mapped, err := s.Map(&MapParams{Url: siteUrl}, WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	pages := mapped.Urls
+	if len(pages) > maxPages {
+		pages = pages[:maxPages]
+	}
+
+	audit := &LinkAudit{}
+	sources := make(map[string][]string)
+	var mu sync.Mutex
+	forEachConcurrent(ctx, concurrency, pages, func(page string) {
+		result, err := s.Scrape(&ScrapeParams{Url: page}, WithContext(ctx))
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			audit.Unscanned = append(audit.Unscanned, page)
+			return
+		}
+		audit.PagesScanned++
+		base, _ := url.Parse(page)
+		for _, link := range result.Urls {
+			target := resolveLink(base, link)
+			if target == "" || (opts.SkipExternal && !sameHost(site, target)) {
+				continue
+			}
+			if !slices.Contains(sources[target], page) {
+				sources[target] = append(sources[target], page)
+			}
+		}
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	links := make([]string, 0, len(sources))
+	for link := range sources {
+		links = append(links, link)
+	}
+	slices.Sort(links)
+	audit.LinksChecked = len(links)
+
+	forEachConcurrent(ctx, concurrency, links, func(link string) {
+		status, err := checkLink(ctx, checker, link)
+		if err == nil && status < 400 {
+			return
+		}
+		linkSources := sources[link]
+		slices.Sort(linkSources)
+		mu.Lock()
+		defer mu.Unlock()
+		audit.Broken = append(audit.Broken, BrokenLink{
+			Url:        link,
+			External:   !sameHost(site, link),
+			StatusCode: status,
+			Err:        err,
+			Sources:    linkSources,
+		})
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(audit.Broken, func(a, b BrokenLink) int { return strings.Compare(a.Url, b.Url) })
+	slices.Sort(audit.Unscanned)
+	return audit, nil
+}
+
+// forEachConcurrent calls fn for every item with at most n calls at the same time, stopping early when ctx is done
+func forEachConcurrent(ctx context.Context, n int, items []string, fn func(string)) {
+	sem := make(chan struct{}, n)
+	var wg sync.WaitGroup
+	for _, item := range items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(item)
+		}()
+	}
+	wg.Wait()
+}
+
+// checkLink returns the status code of link, retrying with GET when HEAD is not supported
+func checkLink(ctx context.Context, client *http.Client, link string) (int, error) {
+	status, err := requestStatus(ctx, client, http.MethodHead, link)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		return requestStatus(ctx, client, http.MethodGet, link)
+	}
+	return status, err
+}
+
+func requestStatus(ctx context.Context, client *http.Client, method, link string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, link, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	closeBody(resp.Body)
+	return resp.StatusCode, nil
+}
+
+// resolveLink resolves link against base and drops its fragment, returning an empty string unless it is http(s)
+func resolveLink(base *url.URL, link string) string {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
+// sameHost reports whether link points to the host of site
+func sameHost(site *url.URL, link string) bool {
+	u, err := url.Parse(link)
+	return err == nil && strings.EqualFold(u.Host, site.Host)
+}
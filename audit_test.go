@@ -0,0 +1,77 @@
+package supadata
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestAuditLinks(t *testing.T) {
+	var site *httptest.Server
+	site = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/", "/about", "/get-only":
+			if r.URL.Path == "/get-only" && r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/gone":
+			w.WriteHeader(http.StatusGone)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer site.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/web/map":
+			jsonResponse(w, http.StatusOK, map[string]any{
+				"urls": []string{site.URL + "/", site.URL + "/about", site.URL + "/private"},
+			})
+		case "/web/scrape":
+			page := r.URL.Query().Get("url")
+			switch page {
+			case site.URL + "/":
+				jsonResponse(w, http.StatusOK, map[string]any{"url": page, "urls": []string{"/about", "/missing#section", "/get-only", "mailto:hi@example.com"}})
+			case site.URL + "/about":
+				jsonResponse(w, http.StatusOK, map[string]any{"url": page, "urls": []string{"/", "/missing", "gone", "http://127.0.0.1:1/unreachable"}})
+			default:
+				errorResponse(w, http.StatusForbidden, Forbidden, "Forbidden", "")
+			}
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer api.Close()
+
+	client := newTestClient(api)
+	audit, err := client.AuditLinks(context.Background(), site.URL, &LinkAuditOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if audit.PagesScanned != 2 || !slices.Equal(audit.Unscanned, []string{site.URL + "/private"}) {
+		t.Errorf("unexpected scan %d/%v", audit.PagesScanned, audit.Unscanned)
+	}
+	if audit.LinksChecked != 6 {
+		t.Errorf("expected 6 links checked, got %d", audit.LinksChecked)
+	}
+
+	if len(audit.Broken) != 3 {
+		t.Fatalf("expected 3 broken links, got %+v", audit.Broken)
+	}
+	unreachable, gone, missing := audit.Broken[0], audit.Broken[1], audit.Broken[2]
+	if gone.Url != site.URL+"/gone" || gone.StatusCode != http.StatusGone || gone.External {
+		t.Errorf("unexpected broken link %+v", gone)
+	}
+	if missing.Url != site.URL+"/missing" || !slices.Equal(missing.Sources, []string{site.URL + "/", site.URL + "/about"}) {
+		t.Errorf("unexpected broken link %+v", missing)
+	}
+	if unreachable.StatusCode != 0 || unreachable.Err == nil || !unreachable.External {
+		t.Errorf("unexpected broken link %+v", unreachable)
+	}
+}
@@ -0,0 +1,406 @@
+package supadata
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"time"
+)
+
+// MaxBatchVideoIds is the maximum number of video IDs accepted by a single batch job
+const MaxBatchVideoIds = 100
+
+// maxLineLength bounds the length of a single line read by ScanLines
+const maxLineLength = 1024 * 1024
+
+// ScanLines iterates over the lines of r without loading it into memory, trimming surrounding whitespace and
+// skipping blank lines and lines starting with '#'
+func ScanLines(r io.Reader) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if !yield(line, nil) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield("", err)
+		}
+	}
+}
+
+// chunk groups the values of seq into slices of at most size elements
+func chunk(seq iter.Seq2[string, error], size int) iter.Seq2[[]string, error] {
+	return func(yield func([]string, error) bool) {
+		batch := make([]string, 0, size)
+		for value, err := range seq {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			batch = append(batch, value)
+			if len(batch) == size {
+				if !yield(batch, nil) {
+					return
+				}
+				batch = make([]string, 0, size)
+			}
+		}
+		if len(batch) > 0 {
+			yield(batch, nil)
+		}
+	}
+}
+
+// submitBatchChunks submits one job per chunk of at most MaxBatchVideoIds video IDs and returns a composite job.
+// When a submission fails, the jobs submitted so far are returned along with the error.
+func submitBatchChunks(ids []string, submit func(ids []string) (*YouTubeBatchJob, error)) (*YouTubeBatchJob, error) {
+	composite := &YouTubeBatchJob{}
+	for start := 0; start < len(ids); start += MaxBatchVideoIds {
+		end := min(start+MaxBatchVideoIds, len(ids))
+		job, err := submit(ids[start:end])
+		if err != nil {
+			if len(composite.JobIds) == 0 {
+				return nil, err
+			}
+			return composite, err
+		}
+		if composite.JobId == "" {
+			composite.JobId = job.JobId
+		}
+		composite.JobIds = append(composite.JobIds, job.JobId)
+	}
+	return composite, nil
+}
+
+// YouTubeBatchJobResult retrieves the results of a batch job, aggregating the results of every job when the
+// request was split into several jobs. The aggregated status is failed when any job failed, and completed only
+// once every job completed.
+func (s *Supadata) YouTubeBatchJobResult(job *YouTubeBatchJob, opts ...RequestOption) (*YouTubeBatchResult, error) {
+	if len(job.JobIds) == 0 {
+		return s.YouTubeBatchResult(job.JobId, opts...)
+	}
+
+	results := make([]*YouTubeBatchResult, 0, len(job.JobIds))
+	for _, jobId := range job.JobIds {
+		result, err := s.YouTubeBatchResult(jobId, opts...)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return mergeBatchResults(results), nil
+}
+
+// YouTubeBatchItems iterates over the results of a batch job, decoding them one at a time from the response instead
+// of loading them into a slice. The results available so far are yielded when the job is still running.
+// Iteration stops after the first error.
+func (s *Supadata) YouTubeBatchItems(ctx context.Context, jobId string) iter.Seq2[YouTubeBatchResultItem, error] {
+	return func(yield func(YouTubeBatchResultItem, error) bool) {
+		ctx := ensureLineage(ctx)
+		endpoint, err := jobPath("/youtube/batch", jobId)
+		if err != nil {
+			yield(YouTubeBatchResultItem{}, err)
+			return
+		}
+		req, err := s.prepareRequest("GET", endpoint, nil)
+		if err != nil {
+			yield(YouTubeBatchResultItem{}, err)
+			return
+		}
+
+		resp, err := s.do(req, []RequestOption{WithContext(ctx)})
+		if err != nil {
+			yield(YouTubeBatchResultItem{}, err)
+			return
+		}
+		defer closeBody(resp.Body)
+
+		if resp.StatusCode >= 400 {
+			_, err := s.handleRawResponse(resp)
+			yield(YouTubeBatchResultItem{}, err)
+			return
+		}
+
+		body := bufio.NewReaderSize(resp.Body, maxSnippetLength)
+		start, _ := body.Peek(maxSnippetLength)
+		if err := s.checkContentType(resp, start); err != nil {
+			yield(YouTubeBatchResultItem{}, s.requestError(req, err))
+			return
+		}
+		if err := decodeBatchItems(json.NewDecoder(body), yield); err != nil {
+			yield(YouTubeBatchResultItem{}, s.requestError(req, err))
+		}
+	}
+}
+
+// decodeBatchItems streams the elements of the results array of a batch result object to yield, skipping the other
+// fields. It returns nil when yield stops the iteration.
+func decodeBatchItems(dec *json.Decoder, yield func(YouTubeBatchResultItem, error) bool) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key, _ := token.(string); key != "results" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+
+		token, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			continue
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return fmt.Errorf("unexpected token %v in batch results", token)
+		}
+		for dec.More() {
+			var item YouTubeBatchResultItem
+			if err := dec.Decode(&item); err != nil {
+				return err
+			}
+			if !yield(item, nil) {
+				return nil
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expectDelim consumes the next token of dec, failing unless it is the delimiter want
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expected %v in batch result, got %v", want, token)
+	}
+	return nil
+}
+
+// ByVideoID indexes the items of the result by video ID, the last item winning when a video appears several times
+func (r *YouTubeBatchResult) ByVideoID() map[string]YouTubeBatchResultItem {
+	items := make(map[string]YouTubeBatchResultItem, len(r.Results))
+	for _, item := range r.Results {
+		items[item.VideoId] = item
+	}
+	return items
+}
+
+// Succeeded returns the items of the result completed without an error code, in order
+func (r *YouTubeBatchResult) Succeeded() []YouTubeBatchResultItem {
+	return r.filterItems(false)
+}
+
+// FailedItems returns the items of the result that failed with an error code, in order
+func (r *YouTubeBatchResult) FailedItems() []YouTubeBatchResultItem {
+	return r.filterItems(true)
+}
+
+// filterItems returns the items of the result that failed, or succeeded when failed is false
+func (r *YouTubeBatchResult) filterItems(failed bool) []YouTubeBatchResultItem {
+	var items []YouTubeBatchResultItem
+	for _, item := range r.Results {
+		if (item.ErrorCode != "") == failed {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// SuccessRate returns the share of the videos of the batch that succeeded, from 0 to 1, the videos still pending
+// counting as not succeeded. It is 0 for an empty batch.
+func (st YouTubeBatchStats) SuccessRate() float64 {
+	if st.Total <= 0 {
+		return 0
+	}
+	return float64(st.Succeeded) / float64(st.Total)
+}
+
+// ErrorCodeCounts counts the failed items of the result by error code
+func (r *YouTubeBatchResult) ErrorCodeCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, item := range r.FailedItems() {
+		counts[item.ErrorCode]++
+	}
+	return counts
+}
+
+// Elapsed returns how long the job took from its submission to the completion reported by result, or how long it has
+// been running when result is nil or has not completed. It is zero for jobs not submitted by this client.
+func (j *YouTubeBatchJob) Elapsed(result *YouTubeBatchResult) time.Duration {
+	if j.SubmittedAt.IsZero() {
+		return 0
+	}
+	end := time.Now()
+	if result != nil && result.CompletedAt != nil {
+		end = *result.CompletedAt
+	}
+	return max(end.Sub(j.SubmittedAt), 0)
+}
+
+// ErrBatchRetryFailed is returned when the batch resubmitting failed items does not complete
+var ErrBatchRetryFailed = errors.New("batch retry failed")
+
+// RetryFailedBatchItems resubmits the items of result that failed with an error code as a new batch, waits for it to
+// finish and returns a copy of result in which the retried items replace the failed ones. The items are resubmitted
+// as a transcript batch with the Lang, Text and Translate of params, or as a video metadata batch when params is nil.
+func (s *Supadata) RetryFailedBatchItems(ctx context.Context, result *YouTubeBatchResult, params *YouTubeTranscriptBatchParams, opts ...WaitOption) (*YouTubeBatchResult, error) {
+	ctx = ensureLineage(ctx)
+	wc := newWaitConfig(opts)
+	if err := checkProgress[YouTubeBatchStats](wc); err != nil {
+		return nil, err
+	}
+	var failed []string
+	for _, item := range result.FailedItems() {
+		failed = append(failed, item.VideoId)
+	}
+	if len(failed) == 0 {
+		return result, nil
+	}
+
+	var job *YouTubeBatchJob
+	var err error
+	if params != nil {
+		job, err = s.YouTubeTranscriptBatch(&YouTubeTranscriptBatchParams{
+			VideoIds:  failed,
+			Lang:      params.Lang,
+			Text:      params.Text,
+			Translate: params.Translate,
+		}, WithContext(ctx))
+	} else {
+		job, err = s.YouTubeVideoBatch(&YouTubeVideoBatchParams{VideoIds: failed}, WithContext(ctx))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	retried, err := s.waitForBatchJob(ctx, job, wc)
+	if err != nil {
+		return nil, err
+	}
+	if retried.Status != BatchCompleted {
+		return nil, fmt.Errorf("%w: job %s is %s", ErrBatchRetryFailed, job.JobId, retried.Status)
+	}
+	return mergeRetriedItems(result, retried), nil
+}
+
+// mergeRetriedItems returns a copy of result in which the failed items are replaced by their retried counterpart
+func mergeRetriedItems(result, retried *YouTubeBatchResult) *YouTubeBatchResult {
+	byVideoId := retried.ByVideoID()
+	merged := *result
+	merged.Results = make([]YouTubeBatchResultItem, len(result.Results))
+	for i, item := range result.Results {
+		if retry, ok := byVideoId[item.VideoId]; ok && item.ErrorCode != "" {
+			if retry.ErrorCode == "" {
+				merged.Stats.Succeeded++
+				merged.Stats.Failed--
+			}
+			item = retry
+		}
+		merged.Results[i] = item
+	}
+	return &merged
+}
+
+// batchStatusRank orders batch statuses from the least to the most advanced
+var batchStatusRank = map[YouTubeBatchStatus]int{
+	BatchFailed:    0,
+	BatchQueued:    1,
+	BatchActive:    2,
+	BatchCompleted: 3,
+}
+
+// mergeBatchResults combines the results of several batch jobs into one, keeping the least advanced status
+func mergeBatchResults(results []*YouTubeBatchResult) *YouTubeBatchResult {
+	merged := &YouTubeBatchResult{Status: BatchCompleted}
+	for _, result := range results {
+		if batchStatusRank[result.Status] < batchStatusRank[merged.Status] {
+			merged.Status = result.Status
+		}
+		merged.Results = append(merged.Results, result.Results...)
+		merged.Stats.Total += result.Stats.Total
+		merged.Stats.Succeeded += result.Stats.Succeeded
+		merged.Stats.Failed += result.Stats.Failed
+		if result.CompletedAt != nil && (merged.CompletedAt == nil || result.CompletedAt.After(*merged.CompletedAt)) {
+			merged.CompletedAt = result.CompletedAt
+		}
+	}
+	if merged.Status != BatchCompleted {
+		merged.CompletedAt = nil
+	}
+	return merged
+}
+
+// YouTubeVideoBatchFromReader submits video metadata batch jobs for the video IDs or URLs read from r, one per line,
+// in chunks of at most MaxBatchVideoIds. The WebhookUrl of params, when set, is applied to every job.
+// Iteration stops after the first error.
+func (s *Supadata) YouTubeVideoBatchFromReader(ctx context.Context, r io.Reader, params *YouTubeVideoBatchParams) iter.Seq2[*YouTubeBatchJob, error] {
+	return func(yield func(*YouTubeBatchJob, error) bool) {
+		ctx := ensureLineage(ctx)
+		for ids, err := range chunk(ScanLines(r), MaxBatchVideoIds) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			jobParams := YouTubeVideoBatchParams{VideoIds: ids}
+			if params != nil {
+				jobParams.WebhookUrl = params.WebhookUrl
+			}
+			job, err := s.YouTubeVideoBatch(&jobParams, WithContext(ctx))
+			if !yield(job, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// YouTubeTranscriptBatchFromReader submits transcript batch jobs for the video IDs or URLs read from r, one per line,
+// in chunks of at most MaxBatchVideoIds. The Lang, Text, Translate and WebhookUrl of params are applied
+// to every job.
+// Iteration stops after the first error.
+func (s *Supadata) YouTubeTranscriptBatchFromReader(ctx context.Context, r io.Reader, params *YouTubeTranscriptBatchParams) iter.Seq2[*YouTubeBatchJob, error] {
+	return func(yield func(*YouTubeBatchJob, error) bool) {
+		ctx := ensureLineage(ctx)
+		for ids, err := range chunk(ScanLines(r), MaxBatchVideoIds) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			jobParams := YouTubeTranscriptBatchParams{VideoIds: ids}
+			if params != nil {
+				jobParams.Lang = params.Lang
+				jobParams.Text = params.Text
+				jobParams.Translate = params.Translate
+				jobParams.WebhookUrl = params.WebhookUrl
+			}
+			job, err := s.YouTubeTranscriptBatch(&jobParams, WithContext(ctx))
+			if !yield(job, err) || err != nil {
+				return
+			}
+		}
+	}
+}
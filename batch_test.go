@@ -0,0 +1,480 @@
+package supadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScanLines(t *testing.T) {
+	input := "abc\n\n  def  \n# comment\r\nghi"
+
+	var lines []string
+	for line, err := range ScanLines(strings.NewReader(input)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		lines = append(lines, line)
+	}
+
+	if strings.Join(lines, ",") != "abc,def,ghi" {
+		t.Errorf("expected [abc def ghi], got %v", lines)
+	}
+}
+
+func TestYouTubeTranscriptBatchFromReader(t *testing.T) {
+	var sizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/youtube/transcript/batch" {
+			t.Errorf("expected path /youtube/transcript/batch, got %s", r.URL.Path)
+		}
+		var body YouTubeTranscriptBatchParams
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if body.Lang != "en" {
+			t.Errorf("expected lang %q, got %q", "en", body.Lang)
+		}
+		if body.WebhookUrl != "https://hooks.example.com/batch" {
+			t.Errorf("expected webhookUrl %q, got %q", "https://hooks.example.com/batch", body.WebhookUrl)
+		}
+		sizes = append(sizes, len(body.VideoIds))
+		jsonResponse(w, http.StatusOK, map[string]any{"jobId": fmt.Sprintf("job-%d", len(sizes))})
+	}))
+	defer server.Close()
+
+	var input strings.Builder
+	for i := 0; i < 2*MaxBatchVideoIds+5; i++ {
+		fmt.Fprintf(&input, "video-%d\n", i)
+	}
+
+	client := newTestClient(server)
+	var jobs []string
+	for job, err := range client.YouTubeTranscriptBatchFromReader(context.Background(), strings.NewReader(input.String()), &YouTubeTranscriptBatchParams{
+		Lang:       "en",
+		WebhookUrl: "https://hooks.example.com/batch",
+	}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		jobs = append(jobs, job.JobId)
+	}
+
+	if len(jobs) != 3 {
+		t.Fatalf("expected 3 jobs, got %d", len(jobs))
+	}
+	if sizes[0] != MaxBatchVideoIds || sizes[1] != MaxBatchVideoIds || sizes[2] != 5 {
+		t.Errorf("unexpected batch sizes %v", sizes)
+	}
+}
+
+func TestYouTubeVideoBatchFromReader_StopsOnError(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		errorResponse(w, http.StatusPaymentRequired, UpgradeRequired, "Upgrade required", "")
+	}))
+	defer server.Close()
+
+	var input strings.Builder
+	for i := 0; i < MaxBatchVideoIds+1; i++ {
+		fmt.Fprintf(&input, "video-%d\n", i)
+	}
+
+	client := newTestClient(server)
+	errs := 0
+	for _, err := range client.YouTubeVideoBatchFromReader(context.Background(), strings.NewReader(input.String()), nil) {
+		if err != nil {
+			errs++
+		}
+	}
+	if errs != 1 || requests != 1 {
+		t.Errorf("expected a single failed request, got %d errors and %d requests", errs, requests)
+	}
+}
+
+func TestYouTubeVideoBatch_SplitsOversizedRequests(t *testing.T) {
+	var sizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body YouTubeVideoBatchParams
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		sizes = append(sizes, len(body.VideoIds))
+		jsonResponse(w, http.StatusOK, map[string]any{"jobId": fmt.Sprintf("job-%d", len(sizes))})
+	}))
+	defer server.Close()
+
+	ids := make([]string, 2*MaxBatchVideoIds+1)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("video-%d", i)
+	}
+
+	client := newTestClient(server)
+	job, err := client.YouTubeVideoBatch(&YouTubeVideoBatchParams{VideoIds: ids})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fmt.Sprint(sizes) != fmt.Sprintf("[%d %d 1]", MaxBatchVideoIds, MaxBatchVideoIds) {
+		t.Errorf("unexpected batch sizes %v", sizes)
+	}
+	if job.JobId != "job-1" || fmt.Sprint(job.JobIds) != "[job-1 job-2 job-3]" {
+		t.Errorf("unexpected composite job %+v", job)
+	}
+}
+
+func TestYouTubeTranscriptBatch_PartialSubmission(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 2 {
+			errorResponse(w, http.StatusTooManyRequests, LimitExceeded, "Too many requests", "")
+			return
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"jobId": fmt.Sprintf("job-%d", requests)})
+	}))
+	defer server.Close()
+
+	ids := make([]string, 2*MaxBatchVideoIds)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("video-%d", i)
+	}
+
+	client := newTestClient(server)
+	job, err := client.YouTubeTranscriptBatch(&YouTubeTranscriptBatchParams{VideoIds: ids})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if job == nil || fmt.Sprint(job.JobIds) != "[job-1]" {
+		t.Errorf("expected the submitted job to be returned, got %+v", job)
+	}
+}
+
+func TestYouTubeBatchJobResult_Aggregates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/youtube/batch/job-1":
+			jsonResponse(w, http.StatusOK, map[string]any{
+				"status":      "completed",
+				"results":     []map[string]any{{"videoId": "a"}, {"videoId": "b", "errorCode": "not-found"}},
+				"stats":       map[string]any{"total": 2, "succeeded": 1, "failed": 1},
+				"completedAt": "2025-01-01T10:00:00Z",
+			})
+		case "/youtube/batch/job-2":
+			jsonResponse(w, http.StatusOK, map[string]any{
+				"status":  "active",
+				"results": []map[string]any{{"videoId": "c"}},
+				"stats":   map[string]any{"total": 3, "succeeded": 1, "failed": 0},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	result, err := client.YouTubeBatchJobResult(&YouTubeBatchJob{JobId: "job-1", JobIds: []string{"job-1", "job-2"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Status != BatchActive {
+		t.Errorf("expected status %q, got %q", BatchActive, result.Status)
+	}
+	if len(result.Results) != 3 {
+		t.Errorf("expected 3 results, got %d", len(result.Results))
+	}
+	if result.Stats != (YouTubeBatchStats{Total: 5, Succeeded: 2, Failed: 1}) {
+		t.Errorf("unexpected stats %+v", result.Stats)
+	}
+	if result.CompletedAt != nil {
+		t.Errorf("expected no completedAt while a job is active, got %v", *result.CompletedAt)
+	}
+}
+
+func TestMergeBatchResults_Status(t *testing.T) {
+	first := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+	merged := mergeBatchResults([]*YouTubeBatchResult{
+		{Status: BatchCompleted, CompletedAt: &second},
+		{Status: BatchCompleted, CompletedAt: &first},
+	})
+	if merged.Status != BatchCompleted || merged.CompletedAt == nil || !merged.CompletedAt.Equal(second) {
+		t.Errorf("unexpected merged result %+v", merged)
+	}
+
+	merged = mergeBatchResults([]*YouTubeBatchResult{{Status: BatchQueued}, {Status: BatchFailed}})
+	if merged.Status != BatchFailed {
+		t.Errorf("expected status %q, got %q", BatchFailed, merged.Status)
+	}
+}
+
+func TestYouTubeBatchItems_Streams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/youtube/batch/job-1" {
+			t.Errorf("expected path /youtube/batch/job-1, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"completed","stats":{"total":3,"succeeded":2,"failed":1},` +
+			`"results":[{"videoId":"a","video":{"id":"a"}},{"videoId":"b","errorCode":"not-found"},{"videoId":"c"}],` +
+			`"completedAt":"2025-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	var ids []string
+	for item, err := range client.YouTubeBatchItems(context.Background(), "job-1") {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, item.VideoId)
+		if item.VideoId == "b" && item.ErrorCode != "not-found" {
+			t.Errorf("expected errorCode not-found, got %q", item.ErrorCode)
+		}
+	}
+
+	if strings.Join(ids, ",") != "a,b,c" {
+		t.Errorf("expected [a b c], got %v", ids)
+	}
+}
+
+func TestYouTubeBatchItems_EarlyBreak(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"status":  "active",
+			"results": []map[string]any{{"videoId": "a"}, {"videoId": "b"}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	count := 0
+	for _, err := range client.YouTubeBatchItems(context.Background(), "job-1") {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("expected 1 item, got %d", count)
+	}
+}
+
+func TestYouTubeBatchItems_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorResponse(w, http.StatusNotFound, NotFound, "Not found", "")
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	errs := 0
+	for _, err := range client.YouTubeBatchItems(context.Background(), "missing") {
+		var errResp *ErrorResponse
+		if !errors.As(err, &errResp) || errResp.ErrorIdentifier != NotFound {
+			t.Errorf("expected not-found error, got %v", err)
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Errorf("expected 1 error, got %d", errs)
+	}
+}
+
+func TestRetryFailedBatchItems(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/youtube/transcript/batch":
+			var body YouTubeTranscriptBatchParams
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			if strings.Join(body.VideoIds, ",") != "b,c" || body.Lang != "en" {
+				t.Errorf("unexpected retry request %+v", body)
+			}
+			jsonResponse(w, http.StatusOK, map[string]any{"jobId": "retry-job"})
+		case "/youtube/batch/retry-job":
+			jsonResponse(w, http.StatusOK, map[string]any{
+				"status": "completed",
+				"results": []map[string]any{
+					{"videoId": "b", "transcript": map[string]any{"lang": "en"}},
+					{"videoId": "c", "errorCode": "transcript-unavailable"},
+				},
+				"stats": map[string]any{"total": 2, "succeeded": 1, "failed": 1},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	original := &YouTubeBatchResult{
+		Status: BatchCompleted,
+		Results: []YouTubeBatchResultItem{
+			{VideoId: "a", Transcript: &YouTubeTranscriptResult{Lang: "en"}},
+			{VideoId: "b", ErrorCode: "internal-error"},
+			{VideoId: "c", ErrorCode: "transcript-unavailable"},
+		},
+		Stats: YouTubeBatchStats{Total: 3, Succeeded: 1, Failed: 2},
+	}
+
+	client := newTestClient(server)
+	result, err := client.RetryFailedBatchItems(context.Background(), original, &YouTubeTranscriptBatchParams{Lang: "en"}, WithPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Results[1].ErrorCode != "" || result.Results[1].Transcript == nil {
+		t.Errorf("expected retried item b to succeed, got %+v", result.Results[1])
+	}
+	if result.Results[2].ErrorCode != "transcript-unavailable" {
+		t.Errorf("expected item c to still fail, got %+v", result.Results[2])
+	}
+	if result.Stats.Succeeded != 2 || result.Stats.Failed != 1 {
+		t.Errorf("unexpected stats %+v", result.Stats)
+	}
+	if original.Results[1].ErrorCode != "internal-error" {
+		t.Error("expected original result to be left untouched")
+	}
+}
+
+func TestRetryFailedBatchItems_NothingToRetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	original := &YouTubeBatchResult{
+		Status:  BatchCompleted,
+		Results: []YouTubeBatchResultItem{{VideoId: "a", Video: &YouTubeVideo{Id: "a"}}},
+	}
+
+	client := newTestClient(server)
+	result, err := client.RetryFailedBatchItems(context.Background(), original, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != original {
+		t.Error("expected the original result to be returned")
+	}
+}
+
+func TestRetryFailedBatchItems_RetryJobFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/youtube/video/batch" {
+			jsonResponse(w, http.StatusOK, map[string]any{"jobId": "retry-job"})
+			return
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"status": "failed"})
+	}))
+	defer server.Close()
+
+	original := &YouTubeBatchResult{
+		Status:  BatchCompleted,
+		Results: []YouTubeBatchResultItem{{VideoId: "a", ErrorCode: "internal-error"}},
+	}
+
+	client := newTestClient(server)
+	_, err := client.RetryFailedBatchItems(context.Background(), original, nil, WithPollInterval(time.Millisecond))
+	if !errors.Is(err, ErrBatchRetryFailed) {
+		t.Errorf("expected ErrBatchRetryFailed, got %v", err)
+	}
+}
+
+func TestYouTubeBatchResult_Accessors(t *testing.T) {
+	result := &YouTubeBatchResult{Results: []YouTubeBatchResultItem{
+		{VideoId: "a", Video: &YouTubeVideo{Title: "A"}},
+		{VideoId: "b", ErrorCode: "video-not-found"},
+		{VideoId: "c", Video: &YouTubeVideo{Title: "C"}},
+		{VideoId: "d", ErrorCode: "transcript-unavailable"},
+	}}
+
+	byVideoId := result.ByVideoID()
+	if len(byVideoId) != 4 || byVideoId["c"].Video.Title != "C" || byVideoId["b"].ErrorCode != "video-not-found" {
+		t.Errorf("unexpected index %+v", byVideoId)
+	}
+	if succeeded := result.Succeeded(); len(succeeded) != 2 || succeeded[0].VideoId != "a" || succeeded[1].VideoId != "c" {
+		t.Errorf("unexpected succeeded items %+v", succeeded)
+	}
+	if failed := result.FailedItems(); len(failed) != 2 || failed[0].VideoId != "b" || failed[1].VideoId != "d" {
+		t.Errorf("unexpected failed items %+v", failed)
+	}
+	if failed := (&YouTubeBatchResult{}).FailedItems(); failed != nil {
+		t.Errorf("expected no failed items, got %+v", failed)
+	}
+}
+
+func TestYouTubeBatchJob_Elapsed(t *testing.T) {
+	var submitted time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			jsonResponse(w, http.StatusOK, map[string]any{"jobId": "job-1"})
+			return
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"status":      "completed",
+			"completedAt": submitted.Add(90 * time.Second).Format(time.RFC3339Nano),
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	before := time.Now()
+	job, err := client.YouTubeVideoBatch(&YouTubeVideoBatchParams{VideoIds: []string{"dQw4w9WgXcQ"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.SubmittedAt.Before(before) || job.SubmittedAt.After(time.Now()) {
+		t.Fatalf("expected the submission time to be tracked, got %v", job.SubmittedAt)
+	}
+	submitted = job.SubmittedAt
+
+	result, err := client.YouTubeBatchJobResult(job)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.CompletedAt == nil || !result.CompletedAt.Equal(submitted.Add(90*time.Second)) {
+		t.Errorf("unexpected completedAt %v", result.CompletedAt)
+	}
+	if elapsed := job.Elapsed(result); elapsed != 90*time.Second {
+		t.Errorf("expected an elapsed time of 90s, got %v", elapsed)
+	}
+
+	if elapsed := job.Elapsed(nil); elapsed <= 0 {
+		t.Errorf("expected the running time of the job, got %v", elapsed)
+	}
+	if elapsed := (&YouTubeBatchJob{JobId: "job-2"}).Elapsed(result); elapsed != 0 {
+		t.Errorf("expected no elapsed time for a job not submitted by the client, got %v", elapsed)
+	}
+}
+
+func TestYouTubeBatchResult_HealthStats(t *testing.T) {
+	result := &YouTubeBatchResult{
+		Results: []YouTubeBatchResultItem{
+			{VideoId: "a"},
+			{VideoId: "b", ErrorCode: "video-not-found"},
+			{VideoId: "c", ErrorCode: "transcript-unavailable"},
+			{VideoId: "d", ErrorCode: "video-not-found"},
+		},
+		Stats: YouTubeBatchStats{Total: 5, Succeeded: 1, Failed: 3},
+	}
+
+	if rate := result.Stats.SuccessRate(); rate != 0.2 {
+		t.Errorf("expected a success rate of 0.2, got %f", rate)
+	}
+	if rate := (YouTubeBatchStats{}).SuccessRate(); rate != 0 {
+		t.Errorf("expected a success rate of 0 for an empty batch, got %f", rate)
+	}
+
+	counts := result.ErrorCodeCounts()
+	if len(counts) != 2 || counts["video-not-found"] != 2 || counts["transcript-unavailable"] != 1 {
+		t.Errorf("unexpected error code counts %v", counts)
+	}
+}
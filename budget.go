@@ -0,0 +1,120 @@
+package supadata
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// ErrBudgetExceeded is matched by errors.Is when a call would exceed the credit budget of the client
+var ErrBudgetExceeded = errors.New("credit budget exceeded")
+
+// BudgetExceededError is returned, without sending the request, when a call would exceed the credit budget
+type BudgetExceededError struct {
+	Endpoint string
+	// Cost is the estimated credit cost of the rejected call
+	Cost int64
+	// Remaining is the budget left when the call was rejected
+	Remaining int64
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("%s: %s costs %d credits, %d remaining", ErrBudgetExceeded, e.Endpoint, e.Cost, e.Remaining)
+}
+
+func (e *BudgetExceededError) Is(target error) bool {
+	return target == ErrBudgetExceeded
+}
+
+// WithCreditBudget limits the credits the client may spend to credits. Every call is charged the estimated cost of
+// its endpoint before being sent, and fails with a *BudgetExceededError once the budget would be exceeded.
+// Failed calls and cache hits are not charged, and the actual cost replaces the estimate when the API reports it.
+func WithCreditBudget(credits int64) ConfigOption {
+	return func(config *Config) {
+		config.creditBudget = credits
+	}
+}
+
+// creditBudget tracks the credits charged against the budget of a client
+type creditBudget struct {
+	mu    sync.Mutex
+	limit int64
+	spent int64
+}
+
+// charge reserves the cost of a call, failing when it does not fit in the remaining budget
+func (b *creditBudget) charge(endpoint string, cost int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if remaining := b.limit - b.spent; cost > remaining {
+		return &BudgetExceededError{Endpoint: endpoint, Cost: cost, Remaining: remaining}
+	}
+	b.spent += cost
+	return nil
+}
+
+func (b *creditBudget) refund(cost int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spent -= cost
+}
+
+func (b *creditBudget) remaining() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.limit - b.spent
+}
+
+// RemainingCredits returns the credits left in the budget of the client, or -1 when it has no budget
+func (s *Supadata) RemainingCredits() int64 {
+	if s.budget == nil {
+		return -1
+	}
+	return s.budget.remaining()
+}
+
+// RefreshCreditBudget lowers the remaining budget of the client to the credits left on the account, as reported by
+// Me. It does nothing when the client has no budget.
+func (s *Supadata) RefreshCreditBudget(opts ...RequestOption) error {
+	if s.budget == nil {
+		return nil
+	}
+	account, err := s.Me(append(opts, WithForceRefresh())...)
+	if err != nil {
+		return err
+	}
+
+	available := int64(account.MaxCredits - account.UsedCredits)
+	s.budget.mu.Lock()
+	defer s.budget.mu.Unlock()
+	if remaining := s.budget.limit - s.budget.spent; available < remaining {
+		s.budget.limit = s.budget.spent + max(available, 0)
+	}
+	return nil
+}
+
+// sendCharged sends req, charging its estimated cost against the budget and refunding it when the call fails.
+// The estimate is replaced by the actual cost when the API reports it.
+func (s *Supadata) sendCharged(req *http.Request) (*http.Response, error) {
+	if s.budget == nil {
+		return s.sendRetried(req)
+	}
+
+	endpoint := s.endpointPath(req)
+	cost := endpointCredits[endpoint]
+	if err := s.budget.charge(endpoint, cost); err != nil {
+		return nil, err
+	}
+	resp, err := s.sendRetried(req)
+	if err != nil {
+		s.budget.refund(cost)
+		return nil, err
+	}
+	if credits, ok := creditsUsed(resp); ok {
+		s.budget.refund(cost - int64(credits))
+	} else if resp.StatusCode >= 400 {
+		s.budget.refund(cost)
+	}
+	return resp, nil
+}
@@ -0,0 +1,93 @@
+package supadata
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithCreditBudget(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("id") == "missing" {
+			errorResponse(w, http.StatusNotFound, NotFound, "Not found", "")
+			return
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "abc"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithCreditBudget(2))
+
+	if _, err := client.YouTubeVideo("missing"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if client.RemainingCredits() != 2 {
+		t.Errorf("expected failed call to be refunded, got %d remaining", client.RemainingCredits())
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.YouTubeVideo("abc"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	_, err := client.YouTubeVideo("abc")
+	var budgetErr *BudgetExceededError
+	if !errors.Is(err, ErrBudgetExceeded) || !errors.As(err, &budgetErr) {
+		t.Fatalf("expected *BudgetExceededError, got %v", err)
+	}
+	if budgetErr.Endpoint != "/youtube/video" || budgetErr.Cost != 1 || budgetErr.Remaining != 0 {
+		t.Errorf("unexpected error %+v", budgetErr)
+	}
+	if requests != 3 {
+		t.Errorf("expected the rejected call not to be sent, got %d requests", requests)
+	}
+
+	// Polling endpoints are free
+	if _, err := client.YouTubeBatchResult("job-1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWithCreditBudget_CacheHitsAreFree(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "abc"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(
+		WithAPIKey("test-api-key"),
+		WithBaseURL(server.URL),
+		WithCache(NewMemoryCache(), time.Minute),
+		WithCreditBudget(1),
+	)
+	for i := 0; i < 3; i++ {
+		if _, err := client.YouTubeVideo("abc"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestRefreshCreditBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{"maxCredits": 100, "usedCredits": 95})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithCreditBudget(50))
+	if err := client.RefreshCreditBudget(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.RemainingCredits() != 5 {
+		t.Errorf("expected 5 remaining credits, got %d", client.RemainingCredits())
+	}
+
+	unlimited := newTestClient(server)
+	if unlimited.RemainingCredits() != -1 {
+		t.Errorf("expected -1 without a budget, got %d", unlimited.RemainingCredits())
+	}
+}
@@ -0,0 +1,369 @@
+package supadata
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidParams is returned by the Build method of the builders when the params cannot be valid
+var ErrInvalidParams = errors.New("invalid params")
+
+var (
+	searchUploadDates = []YouTubeSearchUploadDate{UploadDateAll, UploadDateHour, UploadDateToday, UploadDateWeek, UploadDateMonth, UploadDateYear}
+	searchTypes       = []YouTubeSearchType{SearchTypeAll, SearchTypeVideo, SearchTypeChannel, SearchTypePlaylist, SearchTypeMovie}
+	searchDurations   = []YouTubeSearchDuration{DurationAll, DurationShort, DurationMedium, DurationLong}
+	searchSortBys     = []YouTubeSearchSortBy{SortByRelevance, SortByRating, SortByDate, SortByViews}
+	searchFeatures    = []YouTubeSearchFeature{FeatureHD, FeatureSubtitles, FeatureCreativeCommon, Feature3D, FeatureLive, Feature4K, Feature360, FeatureLocation, FeatureHDR, FeatureVR180}
+)
+
+// invalidParams returns an error wrapping ErrInvalidParams
+func invalidParams(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidParams, fmt.Sprintf(format, args...))
+}
+
+// negativeParam returns the error of a count parameter set to a negative value
+func negativeParam(name string, n int) error {
+	return invalidParams("%s must not be negative, got %d", name, n)
+}
+
+// SearchBuilder builds YouTubeSearchParams, validating the combination of filters in Build
+type SearchBuilder struct {
+	params YouTubeSearchParams
+}
+
+// NewSearch starts building the params of a YouTube search for query, e.g.
+//
+//	params, err := supadata.NewSearch("golang").Type(supadata.SearchTypeVideo).UploadedWithin(supadata.UploadDateMonth).SortBy(supadata.SortByViews).Limit(50).Build()
+func NewSearch(query string) *SearchBuilder {
+	return &SearchBuilder{params: YouTubeSearchParams{Query: query}}
+}
+
+// Type restricts the results to a kind of item
+func (b *SearchBuilder) Type(t YouTubeSearchType) *SearchBuilder {
+	b.params.Type = t
+	return b
+}
+
+// UploadedWithin restricts the results to the videos uploaded within the period
+func (b *SearchBuilder) UploadedWithin(d YouTubeSearchUploadDate) *SearchBuilder {
+	b.params.UploadDate = d
+	return b
+}
+
+// Duration restricts the results to the videos of a length
+func (b *SearchBuilder) Duration(d YouTubeSearchDuration) *SearchBuilder {
+	b.params.Duration = d
+	return b
+}
+
+// SortBy sets the order of the results
+func (b *SearchBuilder) SortBy(s YouTubeSearchSortBy) *SearchBuilder {
+	b.params.SortBy = s
+	return b
+}
+
+// Features restricts the results to the videos having all the features, adding to the features already set
+func (b *SearchBuilder) Features(features ...YouTubeSearchFeature) *SearchBuilder {
+	b.params.Features = append(b.params.Features, features...)
+	return b
+}
+
+// Limit sets the maximum number of results
+func (b *SearchBuilder) Limit(n int) *SearchBuilder {
+	b.params.Limit = n
+	return b
+}
+
+// Region pins the locale of the results to an ISO 3166-1 alpha-2 country code, e.g. "US"
+func (b *SearchBuilder) Region(region string) *SearchBuilder {
+	b.params.Region = region
+	return b
+}
+
+// Lang sets the interface language of the results, e.g. "en"
+func (b *SearchBuilder) Lang(lang Lang) *SearchBuilder {
+	b.params.Lang = lang
+	return b
+}
+
+// SafeSearch enables or disables the filtering of restricted content
+func (b *SearchBuilder) SafeSearch(v bool) *SearchBuilder {
+	b.params.SafeSearch = Bool(v)
+	return b
+}
+
+// NextPageToken continues a previous search from its next page
+func (b *SearchBuilder) NextPageToken(token string) *SearchBuilder {
+	b.params.NextPageToken = token
+	return b
+}
+
+// Build returns the params, or an error wrapping ErrInvalidParams for each invalid value or combination of filters
+func (b *SearchBuilder) Build() (*YouTubeSearchParams, error) {
+	p := b.params
+	p.Features = slices.Clone(p.Features)
+
+	var errs []error
+	if strings.TrimSpace(p.Query) == "" {
+		errs = append(errs, invalidParams("query is required"))
+	}
+	if p.UploadDate != "" && !slices.Contains(searchUploadDates, p.UploadDate) {
+		errs = append(errs, invalidParams("unknown upload date %q", p.UploadDate))
+	}
+	if p.Type != "" && !slices.Contains(searchTypes, p.Type) {
+		errs = append(errs, invalidParams("unknown type %q", p.Type))
+	}
+	if p.Duration != "" && !slices.Contains(searchDurations, p.Duration) {
+		errs = append(errs, invalidParams("unknown duration %q", p.Duration))
+	}
+	if p.SortBy != "" && !slices.Contains(searchSortBys, p.SortBy) {
+		errs = append(errs, invalidParams("unknown sort order %q", p.SortBy))
+	}
+	for _, f := range p.Features {
+		if !slices.Contains(searchFeatures, f) {
+			errs = append(errs, invalidParams("unknown feature %q", f))
+		}
+	}
+	if p.Limit < 0 {
+		errs = append(errs, negativeParam("limit", p.Limit))
+	}
+	if err := p.Lang.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidParams, err))
+	}
+	if p.Region != "" && !isCountryCode(p.Region) {
+		errs = append(errs, invalidParams("region must be an ISO 3166-1 alpha-2 country code, got %q", p.Region))
+	}
+
+	// Upload date, duration and features only filter videos
+	if p.Type == SearchTypeChannel || p.Type == SearchTypePlaylist {
+		if p.UploadDate != "" && p.UploadDate != UploadDateAll {
+			errs = append(errs, invalidParams("upload date cannot filter %s results", p.Type))
+		}
+		if p.Duration != "" && p.Duration != DurationAll {
+			errs = append(errs, invalidParams("duration cannot filter %s results", p.Type))
+		}
+		if len(p.Features) > 0 {
+			errs = append(errs, invalidParams("features cannot filter %s results", p.Type))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return &p, nil
+}
+
+// isCountryCode reports whether s looks like an ISO 3166-1 alpha-2 country code
+func isCountryCode(s string) bool {
+	if len(s) != 2 {
+		return false
+	}
+	for _, c := range s {
+		if (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+// CrawlBuilder builds a CrawlBody, validating it in Build
+type CrawlBuilder struct {
+	body CrawlBody
+}
+
+// NewCrawl starts building a crawl of the website at url
+func NewCrawl(url string) *CrawlBuilder {
+	return &CrawlBuilder{body: CrawlBody{Url: url}}
+}
+
+// Limit sets the maximum number of pages crawled
+func (b *CrawlBuilder) Limit(n int) *CrawlBuilder {
+	b.body.Limit = n
+	return b
+}
+
+// Depth sets the maximum number of links followed from the start page
+func (b *CrawlBuilder) Depth(n int) *CrawlBuilder {
+	b.body.Depth = n
+	return b
+}
+
+// Include restricts the crawl to the paths matching one of the patterns, adding to the patterns already set
+func (b *CrawlBuilder) Include(paths ...string) *CrawlBuilder {
+	b.body.IncludePaths = append(b.body.IncludePaths, paths...)
+	return b
+}
+
+// Exclude skips the paths matching one of the patterns, adding to the patterns already set
+func (b *CrawlBuilder) Exclude(paths ...string) *CrawlBuilder {
+	b.body.ExcludePaths = append(b.body.ExcludePaths, paths...)
+	return b
+}
+
+// RenderJs enables or disables the rendering of the JavaScript of the pages
+func (b *CrawlBuilder) RenderJs(v bool) *CrawlBuilder {
+	b.body.RenderJs = Bool(v)
+	return b
+}
+
+// WebhookUrl sets the URL notified once the crawl has finished
+func (b *CrawlBuilder) WebhookUrl(url string) *CrawlBuilder {
+	b.body.WebhookUrl = url
+	return b
+}
+
+// Build returns the crawl body, or an error wrapping ErrInvalidParams for each invalid value
+func (b *CrawlBuilder) Build() (*CrawlBody, error) {
+	body := b.body
+	body.IncludePaths = slices.Clone(body.IncludePaths)
+	body.ExcludePaths = slices.Clone(body.ExcludePaths)
+
+	var errs []error
+	if !isHTTPURL(body.Url) {
+		errs = append(errs, invalidParams("url must be an absolute http or https URL, got %q", body.Url))
+	}
+	if body.Limit < 0 {
+		errs = append(errs, negativeParam("limit", body.Limit))
+	}
+	if body.Depth < 0 {
+		errs = append(errs, negativeParam("depth", body.Depth))
+	}
+	if slices.Contains(body.IncludePaths, "") || slices.Contains(body.ExcludePaths, "") {
+		errs = append(errs, invalidParams("path patterns must not be empty"))
+	}
+	if body.WebhookUrl != "" && !isHTTPURL(body.WebhookUrl) {
+		errs = append(errs, invalidParams("webhook URL must be an absolute http or https URL, got %q", body.WebhookUrl))
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return &body, nil
+}
+
+// isHTTPURL reports whether s is an absolute http or https URL
+func isHTTPURL(s string) bool {
+	if !strings.HasPrefix(s, "http://") && !strings.HasPrefix(s, "https://") {
+		return false
+	}
+	_, ok := parseURL(s)
+	return ok
+}
+
+// TranscriptBatchBuilder builds YouTubeTranscriptBatchParams, validating in Build that the batch has a single
+// source of videos
+type TranscriptBatchBuilder struct {
+	params YouTubeTranscriptBatchParams
+}
+
+// NewTranscriptBatch starts building a transcript batch, whose source is set with Videos, Playlist or Channel
+func NewTranscriptBatch() *TranscriptBatchBuilder {
+	return &TranscriptBatchBuilder{}
+}
+
+// Videos adds videos to the batch, given as IDs or URLs
+func (b *TranscriptBatchBuilder) Videos(videoIds ...string) *TranscriptBatchBuilder {
+	b.params.VideoIds = append(b.params.VideoIds, videoIds...)
+	return b
+}
+
+// Playlist takes the videos of the batch from a playlist, given as an ID or a URL
+func (b *TranscriptBatchBuilder) Playlist(playlistId string) *TranscriptBatchBuilder {
+	b.params.PlaylistId = playlistId
+	return b
+}
+
+// Channel takes the videos of the batch from a channel, given as an ID, a handle or a URL
+func (b *TranscriptBatchBuilder) Channel(channelId string) *TranscriptBatchBuilder {
+	b.params.ChannelId = channelId
+	return b
+}
+
+// Limit sets the maximum number of videos taken from the playlist or the channel
+func (b *TranscriptBatchBuilder) Limit(n int) *TranscriptBatchBuilder {
+	b.params.Limit = n
+	return b
+}
+
+// Lang sets the preferred language of the transcripts
+func (b *TranscriptBatchBuilder) Lang(lang Lang) *TranscriptBatchBuilder {
+	b.params.Lang = lang
+	return b
+}
+
+// TranslateTo translates every transcript into lang
+func (b *TranscriptBatchBuilder) TranslateTo(lang Lang) *TranscriptBatchBuilder {
+	b.params.Lang = lang
+	b.params.Translate = Bool(true)
+	return b
+}
+
+// Text returns the transcripts as plain text instead of timed segments
+func (b *TranscriptBatchBuilder) Text(v bool) *TranscriptBatchBuilder {
+	b.params.Text = Bool(v)
+	return b
+}
+
+// WebhookUrl sets the URL notified once the batch has finished
+func (b *TranscriptBatchBuilder) WebhookUrl(url string) *TranscriptBatchBuilder {
+	b.params.WebhookUrl = url
+	return b
+}
+
+// Build returns the params with the video, playlist and channel references normalized, or an error wrapping
+// ErrInvalidParams for each invalid value or combination
+func (b *TranscriptBatchBuilder) Build() (*YouTubeTranscriptBatchParams, error) {
+	p := b.params
+	p.VideoIds = slices.Clone(p.VideoIds)
+
+	var errs []error
+	sources := 0
+	for _, set := range []bool{len(p.VideoIds) > 0, p.PlaylistId != "", p.ChannelId != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		errs = append(errs, invalidParams("exactly one of videos, playlist or channel is required"))
+	}
+	for i, videoId := range p.VideoIds {
+		var err error
+		if p.VideoIds[i], err = resolveId(videoId, ParseVideoID); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidParams, err))
+		}
+	}
+	if p.PlaylistId != "" {
+		var err error
+		if p.PlaylistId, err = resolveId(p.PlaylistId, ParsePlaylistID); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidParams, err))
+		}
+	}
+	if p.ChannelId != "" {
+		var err error
+		if p.ChannelId, err = resolveId(p.ChannelId, ParseChannelRef); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidParams, err))
+		}
+	}
+	if p.Limit < 0 {
+		errs = append(errs, negativeParam("limit", p.Limit))
+	}
+	if p.Limit > 0 && len(p.VideoIds) > 0 {
+		errs = append(errs, invalidParams("limit only applies to playlists and channels"))
+	}
+	if err := p.Lang.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidParams, err))
+	}
+	if isTrue(p.Translate) && p.Lang == "" {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidParams, ErrTranslateLangRequired))
+	}
+	if p.WebhookUrl != "" && !isHTTPURL(p.WebhookUrl) {
+		errs = append(errs, invalidParams("webhook URL must be an absolute http or https URL, got %q", p.WebhookUrl))
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return &p, nil
+}
@@ -0,0 +1,97 @@
+package supadata
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSearchBuilder(t *testing.T) {
+	builder := NewSearch("golang").
+		Type(SearchTypeVideo).
+		UploadedWithin(UploadDateMonth).
+		SortBy(SortByViews).
+		Features(FeatureHD, FeatureSubtitles).
+		Limit(50).
+		Region("US").
+		SafeSearch(false)
+	params, err := builder.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if params.Query != "golang" || params.Type != SearchTypeVideo || params.UploadDate != UploadDateMonth ||
+		params.SortBy != SortByViews || len(params.Features) != 2 || params.Limit != 50 || params.Region != "US" ||
+		params.SafeSearch == nil || *params.SafeSearch {
+		t.Errorf("unexpected params %+v", params)
+	}
+
+	// The built params do not share the features of the builder
+	params.Features[0] = FeatureLive
+	if again, _ := builder.Build(); again.Features[0] != FeatureHD {
+		t.Error("expected the builder not to be changed through the built params")
+	}
+}
+
+func TestSearchBuilder_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *SearchBuilder
+	}{
+		{"empty query", NewSearch(" ")},
+		{"unknown type", NewSearch("go").Type("shorts")},
+		{"unknown feature", NewSearch("go").Features("8k")},
+		{"negative limit", NewSearch("go").Limit(-1)},
+		{"bad region", NewSearch("go").Region("USA")},
+		{"upload date on channels", NewSearch("go").Type(SearchTypeChannel).UploadedWithin(UploadDateWeek)},
+		{"features on playlists", NewSearch("go").Type(SearchTypePlaylist).Features(FeatureHD)},
+	}
+	for _, tt := range tests {
+		if _, err := tt.builder.Build(); !errors.Is(err, ErrInvalidParams) {
+			t.Errorf("%s: expected ErrInvalidParams, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestCrawlBuilder(t *testing.T) {
+	body, err := NewCrawl("https://example.com").Limit(10).Depth(2).Include("/blog/*").Exclude("/tag/*").RenderJs(true).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body.Url != "https://example.com" || body.Limit != 10 || body.Depth != 2 || !isTrue(body.RenderJs) ||
+		len(body.IncludePaths) != 1 || len(body.ExcludePaths) != 1 {
+		t.Errorf("unexpected body %+v", body)
+	}
+
+	_, err = NewCrawl("example.com").Depth(-1).WebhookUrl("ftp://hooks").Build()
+	if !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("expected ErrInvalidParams, got %v", err)
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); !ok || len(joined.Unwrap()) != 3 {
+		t.Errorf("expected every problem to be reported, got %v", err)
+	}
+}
+
+func TestTranscriptBatchBuilder(t *testing.T) {
+	params, err := NewTranscriptBatch().Videos("https://youtu.be/dQw4w9WgXcQ", "jNQXAC9IVRw").TranslateTo("fr").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(params.VideoIds) != 2 || params.VideoIds[0] != "dQw4w9WgXcQ" || params.Lang != "fr" || !isTrue(params.Translate) {
+		t.Errorf("unexpected params %+v", params)
+	}
+
+	tests := []struct {
+		name    string
+		builder *TranscriptBatchBuilder
+	}{
+		{"no source", NewTranscriptBatch()},
+		{"several sources", NewTranscriptBatch().Videos("dQw4w9WgXcQ").Playlist("PLxyz1234567890")},
+		{"invalid video", NewTranscriptBatch().Videos("not a video")},
+		{"limit on videos", NewTranscriptBatch().Videos("dQw4w9WgXcQ").Limit(5)},
+		{"translate without lang", NewTranscriptBatch().Channel("@creator").TranslateTo("")},
+	}
+	for _, tt := range tests {
+		if _, err := tt.builder.Build(); !errors.Is(err, ErrInvalidParams) {
+			t.Errorf("%s: expected ErrInvalidParams, got %v", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package supadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheableEndpoints are the idempotent GET endpoints whose responses may be served from the cache
+var cacheableEndpoints = map[string]bool{
+	"/metadata":                     true,
+	"/youtube/video":                true,
+	"/youtube/transcript":           true,
+	"/youtube/transcript/translate": true,
+	"/youtube/channel":              true,
+	"/youtube/playlist":             true,
+}
+
+// Cache stores raw API responses of idempotent GET endpoints
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// WithCache enables response caching for idempotent GET endpoints, keeping entries for ttl
+func WithCache(cache Cache, ttl time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.cache = cache
+		config.cacheTTL = ttl
+	}
+}
+
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-memory Cache safe for concurrent use
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+}
+
+// NewMemoryCache creates an empty in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]memoryCacheEntry)}
+}
+
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := memoryCacheEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	c.entries[key] = entry
+}
+
+// doCached serves cacheable requests from the configured cache, storing successful JSON responses on a miss.
+// When forceRefresh is set the cache is not read, but the fresh response is still stored.
+func (s *Supadata) doCached(req *http.Request, forceRefresh bool) (*http.Response, error) {
+	endpoint := s.endpointPath(req)
+	if s.config.cache == nil || req.Method != http.MethodGet || !cacheableEndpoints[endpoint] {
+		return s.sendCharged(req)
+	}
+
+	key := req.Method + " " + req.URL.String()
+	if !forceRefresh {
+		if body, ok := s.config.cache.Get(key); ok {
+			s.stats.recordCacheHit(endpoint)
+			return &http.Response{
+				Status:     "200 OK",
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       io.NopCloser(bytes.NewReader(body)),
+				Request:    req,
+			}, nil
+		}
+		s.stats.recordCacheMiss()
+	}
+
+	resp, err := s.sendCharged(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if s.cacheableResponse(resp, body) {
+		s.config.cache.Set(key, body, s.config.cacheTTL)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// cacheableResponse reports whether a successful response holds a JSON document, so that the error page of a proxy
+// returned with a 200 is not served from the cache until it expires
+func (s *Supadata) cacheableResponse(resp *http.Response, body []byte) bool {
+	if s.checkContentType(resp, body) != nil {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+		return false
+	}
+	return json.Valid(body)
+}
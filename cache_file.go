@@ -0,0 +1,242 @@
+package supadata
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileCache is a Cache storing one file per entry in a directory, so that cached responses survive restarts.
+// Each file holds the expiry of the entry in Unix nanoseconds on its first line, followed by the value.
+// Entries are written atomically and expired entries are removed when read. FileCache is safe for concurrent use.
+type FileCache struct {
+	dir     string
+	maxSize int64
+
+	mu   sync.Mutex
+	size int64
+}
+
+// FileCacheOption customizes a FileCache
+type FileCacheOption func(*FileCache)
+
+// WithMaxCacheSize bounds the total size of the cache files to maxBytes. When a new entry exceeds it, the expired
+// entries are removed first, then the least recently used ones until the cache fits again.
+func WithMaxCacheSize(maxBytes int64) FileCacheOption {
+	return func(c *FileCache) {
+		c.maxSize = maxBytes
+	}
+}
+
+// NewFileCache creates a FileCache in dir, creating the directory when needed
+func NewFileCache(dir string, opts ...FileCacheOption) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	c := &FileCache{dir: dir}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.maxSize > 0 {
+		entries, err := c.entries()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			c.size += entry.size
+		}
+	}
+	return c, nil
+}
+
+// path returns the file of an entry, named after the hash of its key
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+func (c *FileCache) Get(key string) ([]byte, bool) {
+	path := c.path(key)
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, false
+	}
+
+	header, value, ok := bytes.Cut(data, []byte("\n"))
+	if !ok {
+		return nil, false
+	}
+	expiresAt, err := strconv.ParseInt(string(header), 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	if expiresAt != 0 && time.Now().UnixNano() > expiresAt {
+		c.remove(path, int64(len(data)))
+		return nil, false
+	}
+	if c.maxSize > 0 {
+		// The modification time records the last use of the entry for the eviction
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+	}
+	return value, true
+}
+
+// Set stores the entry, silently giving up when it cannot be written since the cache is best effort
+func (c *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl).UnixNano()
+	}
+	data := strconv.AppendInt(nil, expiresAt, 10)
+	data = append(data, '\n')
+	data = append(data, value...)
+
+	path := c.path(key)
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var replaced int64
+	if info, statErr := os.Stat(path); statErr == nil {
+		replaced = info.Size()
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if c.maxSize > 0 {
+		c.size += int64(len(data)) - replaced
+		if c.size > c.maxSize {
+			c.evict()
+		}
+	}
+}
+
+// Prune removes the expired entries
+func (c *FileCache) Prune() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries, err := c.entries()
+	if err != nil {
+		return err
+	}
+	c.size = 0
+	for _, entry := range entries {
+		if entry.expired {
+			_ = os.Remove(entry.path)
+			continue
+		}
+		c.size += entry.size
+	}
+	return nil
+}
+
+// remove deletes the file of an entry of size bytes
+func (c *FileCache) remove(path string, size int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if os.Remove(path) == nil && c.maxSize > 0 {
+		c.size -= size
+	}
+}
+
+// evict removes the expired entries, then the least recently used ones until the cache fits in its maximum size.
+// The size is recomputed from the directory, correcting the changes made by other processes sharing it.
+func (c *FileCache) evict() {
+	entries, err := c.entries()
+	if err != nil {
+		return
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].expired != entries[j].expired {
+			return entries[i].expired
+		}
+		return entries[i].usedAt.Before(entries[j].usedAt)
+	})
+
+	c.size = 0
+	for _, entry := range entries {
+		c.size += entry.size
+	}
+	for _, entry := range entries {
+		if c.size <= c.maxSize && !entry.expired {
+			break
+		}
+		if os.Remove(entry.path) == nil {
+			c.size -= entry.size
+		}
+	}
+}
+
+type fileCacheEntry struct {
+	path    string
+	size    int64
+	usedAt  time.Time
+	expired bool
+}
+
+// entries lists the entries of the cache directory, ignoring the temporary files of entries being written
+func (c *FileCache) entries() ([]fileCacheEntry, error) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixNano()
+	entries := make([]fileCacheEntry, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || strings.HasPrefix(dirEntry.Name(), ".tmp-") {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(c.dir, dirEntry.Name())
+		expiresAt := readExpiry(path)
+		entries = append(entries, fileCacheEntry{
+			path:    path,
+			size:    info.Size(),
+			usedAt:  info.ModTime(),
+			expired: expiresAt != 0 && now > expiresAt,
+		})
+	}
+	return entries, nil
+}
+
+// readExpiry reads the expiry on the first line of an entry file, returning 0 when it cannot be read
+func readExpiry(path string) int64 {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return 0
+	}
+	header, err := bufio.NewReaderSize(f, 32).ReadString('\n')
+	_ = f.Close()
+	if err != nil {
+		return 0
+	}
+	expiresAt, err := strconv.ParseInt(strings.TrimSuffix(header, "\n"), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return expiresAt
+}
@@ -0,0 +1,237 @@
+package supadata
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache_GetSet(t *testing.T) {
+	cache := NewMemoryCache()
+
+	if _, ok := cache.Get("missing"); ok {
+		t.Error("expected miss for unknown key")
+	}
+
+	cache.Set("key", []byte("value"), time.Minute)
+	if got, ok := cache.Get("key"); !ok || string(got) != "value" {
+		t.Errorf("expected %q, got %q (ok=%v)", "value", got, ok)
+	}
+}
+
+func TestMemoryCache_Expiry(t *testing.T) {
+	cache := NewMemoryCache()
+	cache.Set("key", []byte("value"), time.Millisecond)
+
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := cache.Get("key"); ok {
+		t.Error("expected entry to expire")
+	}
+}
+
+func TestWithCache_ServesRepeatedGets(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		jsonResponse(w, http.StatusOK, map[string]any{"id": r.URL.Query().Get("id"), "title": "Video"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(
+		WithAPIKey("test-api-key"),
+		WithBaseURL(server.URL),
+		WithCache(NewMemoryCache(), time.Minute),
+	)
+
+	for i := 0; i < 3; i++ {
+		video, err := client.YouTubeVideo("abc")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if video.Id != "abc" {
+			t.Errorf("expected id %q, got %q", "abc", video.Id)
+		}
+	}
+	if _, err := client.YouTubeVideo("def"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 upstream calls, got %d", got)
+	}
+}
+
+func TestWithCache_SkipsErrorsAndNonCacheable(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path == "/youtube/video" {
+			errorResponse(w, http.StatusNotFound, NotFound, "Video not found", "")
+			return
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"plan": "free"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(
+		WithAPIKey("test-api-key"),
+		WithBaseURL(server.URL),
+		WithCache(NewMemoryCache(), time.Minute),
+	)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.YouTubeVideo("abc"); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if _, err := client.Me(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got := calls.Load(); got != 4 {
+		t.Errorf("expected 4 upstream calls, got %d", got)
+	}
+}
+
+func TestWithCache_SkipsNonJSONResponses(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{name: "html", contentType: "text/html", body: "<html>Please wait</html>"},
+		{name: "plain text", contentType: "text/plain", body: `{"id": "abc"}`},
+		{name: "truncated json", contentType: "application/json", body: `{"id": "abc"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewMemoryCache()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithCache(cache, time.Minute))
+			_, _ = client.YouTubeVideo("abc")
+			if len(cache.entries) != 0 {
+				t.Errorf("expected the response not to be cached, got %d entries", len(cache.entries))
+			}
+		})
+	}
+}
+
+func TestWithCache_ForceRefresh(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "abc", "viewCount": requests})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithCache(NewMemoryCache(), time.Minute))
+	if _, err := client.YouTubeVideo("abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.YouTubeVideo("abc", WithForceRefresh()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	video, err := client.YouTubeVideo("abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 2 || video.ViewCount == nil || *video.ViewCount != 2 {
+		t.Errorf("expected the refreshed response to be cached, got %v views after %d requests", video.ViewCount, requests)
+	}
+}
+
+func TestFileCache_GetSet(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewFileCache(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := cache.Get("GET https://api.supadata.ai/v1/youtube/video?id=abc"); ok {
+		t.Error("expected miss for missing key")
+	}
+	cache.Set("GET https://api.supadata.ai/v1/youtube/video?id=abc", []byte(`{"id":"abc"}`), 0)
+
+	// A new cache on the same directory sees the entry
+	reopened, err := NewFileCache(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	value, ok := reopened.Get("GET https://api.supadata.ai/v1/youtube/video?id=abc")
+	if !ok || string(value) != `{"id":"abc"}` {
+		t.Errorf("expected persisted value, got %q (%v)", value, ok)
+	}
+}
+
+func TestFileCache_Expiry(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewFileCache(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache.Set("key", []byte("value"), time.Millisecond)
+
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := cache.Get("key"); ok {
+		t.Error("expected expired entry to be a miss")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected expired entry to be removed, got %d files", len(entries))
+	}
+}
+
+func TestFileCache_MaxSizeEvictsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewFileCache(dir, WithMaxCacheSize(64))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	value := []byte(strings.Repeat("x", 20))
+	cache.Set("a", value, 0)
+	cache.Set("b", value, 0)
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(cache.path("b"), past, past); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected a hit for a")
+	}
+	cache.Set("c", value, 0)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("expected %s to be kept", key)
+		}
+	}
+}
+
+func TestFileCache_Prune(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewFileCache(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache.Set("expired", []byte("value"), time.Millisecond)
+	cache.Set("kept", []byte("value"), 0)
+
+	time.Sleep(5 * time.Millisecond)
+	if err := cache.Prune(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected only the live entry to be left, got %d files", len(entries))
+	}
+}
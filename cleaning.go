@@ -0,0 +1,65 @@
+package supadata
+
+import (
+	"regexp"
+	"strings"
+)
+
+// TranscriptCleaner transforms the text of a transcript, e.g. to remove the non-speech cues before NLP processing
+type TranscriptCleaner func(text string) string
+
+var (
+	bracketCuePattern     = regexp.MustCompile(`\[[^\]]*\]`)
+	parenthesisCuePattern = regexp.MustCompile(`(?i)\(\s*(?:music|applause|laughter|laughs|laughing|cheering|cheers|inaudible|silence|crosstalk|sighs|coughs|noise|background noise)\s*\)`)
+	musicNotePattern      = regexp.MustCompile(`[♪♫♬]+`)
+	speakerChangePattern  = regexp.MustCompile(`>>+|(?m:^\s*-\s+)`)
+	speakerNamePattern    = regexp.MustCompile(`(?m)^\s*(?:>>+\s*)?(?:[A-Z][A-Z0-9.'-]+(?: [A-Z0-9.'-]+){0,3}|[Ss]peaker \d+):\s+`)
+)
+
+// DefaultTranscriptCleaners strip the non-speech cues and the speaker tags, then collapse the whitespace left behind
+var DefaultTranscriptCleaners = []TranscriptCleaner{StripNonSpeech, StripSpeakerTags, CollapseWhitespace}
+
+// StripNonSpeech removes the sound cues in brackets such as "[Music]" or "[Applause]", the common cues in
+// parentheses such as "(laughs)" and the music notes
+func StripNonSpeech(text string) string {
+	text = bracketCuePattern.ReplaceAllString(text, " ")
+	text = parenthesisCuePattern.ReplaceAllString(text, " ")
+	return musicNotePattern.ReplaceAllString(text, " ")
+}
+
+// StripSpeakerTags removes the speaker changes marked by ">>" or a dash at the start of a line, and the speaker names
+// in capitals such as "JOHN:" or "Speaker 1:" at the start of a line
+func StripSpeakerTags(text string) string {
+	text = speakerNamePattern.ReplaceAllString(text, "")
+	return speakerChangePattern.ReplaceAllString(text, " ")
+}
+
+// CollapseWhitespace replaces the runs of whitespace, line breaks included, with a single space
+func CollapseWhitespace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// CleanText runs text through the cleaners in order, DefaultTranscriptCleaners when none are given
+func CleanText(text string, cleaners ...TranscriptCleaner) string {
+	if len(cleaners) == 0 {
+		cleaners = DefaultTranscriptCleaners
+	}
+	for _, clean := range cleaners {
+		text = clean(text)
+	}
+	return strings.TrimSpace(text)
+}
+
+// CleanTranscript returns a copy of the transcript segments with their text run through the cleaners in order,
+// DefaultTranscriptCleaners when none are given. The segments left without text, e.g. holding only "[Music]", are
+// dropped.
+func CleanTranscript(content []TranscriptContent, cleaners ...TranscriptCleaner) []TranscriptContent {
+	cleaned := make([]TranscriptContent, 0, len(content))
+	for _, segment := range content {
+		segment.Text = CleanText(segment.Text, cleaners...)
+		if segment.Text != "" {
+			cleaned = append(cleaned, segment)
+		}
+	}
+	return cleaned
+}
@@ -0,0 +1,47 @@
+package supadata
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCleanText(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[Music] hello there [Applause]", "hello there"},
+		{"♪ never gonna give you up ♪", "never gonna give you up"},
+		{"that was funny (laughs) really", "that was funny really"},
+		{"call me (maybe) later", "call me (maybe) later"},
+		{">> JOHN: welcome back\n>> thanks for having me", "welcome back thanks for having me"},
+		{"Speaker 1: hello\n- hi\nit's -5 outside", "hello hi it's -5 outside"},
+		{"the NASA mission: a success", "the NASA mission: a success"},
+		{"[Music]", ""},
+	}
+	for _, tt := range tests {
+		if got := CleanText(tt.input); got != tt.expected {
+			t.Errorf("CleanText(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+
+	// Custom pipelines run in order
+	if got := CleanText("[Music]  HELLO  ", StripNonSpeech, strings.ToLower); got != "hello" {
+		t.Errorf("unexpected custom pipeline result %q", got)
+	}
+}
+
+func TestCleanTranscript(t *testing.T) {
+	content := []TranscriptContent{
+		{Text: "[Music]", Offset: 0, Duration: 2},
+		{Text: ">> hello  world", Offset: 2, Duration: 1},
+		{Text: "[Applause] bye", Offset: 3, Duration: 1},
+	}
+	cleaned := CleanTranscript(content)
+	if len(cleaned) != 2 || cleaned[0].Text != "hello world" || cleaned[0].Offset != 2 || cleaned[1].Text != "bye" {
+		t.Errorf("unexpected cleaned transcript %+v", cleaned)
+	}
+	if content[1].Text != ">> hello  world" {
+		t.Error("expected the original transcript to be left unchanged")
+	}
+}
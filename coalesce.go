@@ -0,0 +1,144 @@
+package supadata
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// WithRequestCoalescing makes concurrent identical GET requests share a single upstream call, so that fan-out
+// services requesting the same popular video at the same time only pay for it once
+func WithRequestCoalescing() ConfigOption {
+	return func(config *Config) {
+		config.coalescing = true
+	}
+}
+
+// sharedResponse is a fully read response that can be handed to several callers
+type sharedResponse struct {
+	status     string
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+func (r *sharedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:     r.status,
+		StatusCode: r.statusCode,
+		Header:     r.header.Clone(),
+		Body:       io.NopCloser(bytes.NewReader(r.body)),
+		Request:    req,
+	}
+}
+
+// inflightCall is an upstream call that identical requests wait for. It runs on a context detached from the callers,
+// cancelled once every caller stopped waiting, so that a caller giving up does not fail the others.
+type inflightCall struct {
+	done    chan struct{}
+	resp    *sharedResponse
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+	charged atomic.Bool
+}
+
+// coalescer tracks the in-flight calls by request key
+type coalescer struct {
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+// doCoalesced sends GET requests through a single upstream call per identical request in flight. Callers waiting
+// for the call stop waiting when their own context is done, the call being cancelled when none is left. Requests
+// forcing a refresh only share the calls of other such requests, never one answered from the cache.
+func (s *Supadata) doCoalesced(req *http.Request, forceRefresh bool) (*http.Response, error) {
+	if !s.config.coalescing || req.Method != http.MethodGet {
+		return s.doCached(req, forceRefresh)
+	}
+
+	key := req.URL.String()
+	if forceRefresh {
+		key = "refresh " + key
+	}
+	s.inflight.mu.Lock()
+	call, ok := s.inflight.calls[key]
+	if ok {
+		s.stats.coalesced.Add(1)
+	} else {
+		if s.inflight.calls == nil {
+			s.inflight.calls = make(map[string]*inflightCall)
+		}
+		ctx, cancel := context.WithCancel(context.WithoutCancel(req.Context()))
+		call = &inflightCall{done: make(chan struct{}), cancel: cancel}
+		s.inflight.calls[key] = call
+		go s.runShared(key, call, req.WithContext(ctx), forceRefresh)
+	}
+	call.waiters++
+	s.inflight.mu.Unlock()
+
+	select {
+	case <-call.done:
+	case <-req.Context().Done():
+		s.leaveShared(key, call)
+		return nil, req.Context().Err()
+	}
+	if call.err != nil {
+		return nil, call.err
+	}
+	// Only the first caller receiving the response is charged its credits
+	resp := call.resp.response(req)
+	if !call.charged.CompareAndSwap(false, true) {
+		resp.Header.Del(headerCreditsUsed)
+	}
+	return resp, nil
+}
+
+// runShared makes the upstream call of call and releases its waiters
+func (s *Supadata) runShared(key string, call *inflightCall, req *http.Request, forceRefresh bool) {
+	call.resp, call.err = s.fetchShared(req, forceRefresh)
+	call.cancel()
+	s.inflight.mu.Lock()
+	if s.inflight.calls[key] == call {
+		delete(s.inflight.calls, key)
+	}
+	s.inflight.mu.Unlock()
+	close(call.done)
+}
+
+// leaveShared stops waiting for call, cancelling it when no caller is left so that later requests start a new one
+func (s *Supadata) leaveShared(key string, call *inflightCall) {
+	s.inflight.mu.Lock()
+	defer s.inflight.mu.Unlock()
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+	call.cancel()
+	if s.inflight.calls[key] == call {
+		delete(s.inflight.calls, key)
+	}
+}
+
+// fetchShared sends req and reads its whole response so that it can be shared
+func (s *Supadata) fetchShared(req *http.Request, forceRefresh bool) (*sharedResponse, error) {
+	resp, err := s.doCached(req, forceRefresh)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &sharedResponse{
+		status:     resp.Status,
+		statusCode: resp.StatusCode,
+		header:     resp.Header,
+		body:       body,
+	}, nil
+}
@@ -0,0 +1,166 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRequestCoalescing(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		w.Header().Set(headerCreditsUsed, "1")
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "abc", "title": "Popular"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRequestCoalescing())
+
+	const callers = 5
+	var wg sync.WaitGroup
+	titles := make([]string, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			video, err := client.YouTubeVideo("abc")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			titles[i] = video.Title
+		}()
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for client.Stats().CoalescedRequests < callers-1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if requests.Load() != 1 {
+		t.Errorf("expected a single upstream call, got %d", requests.Load())
+	}
+	for i, title := range titles {
+		if title != "Popular" {
+			t.Errorf("caller %d got title %q", i, title)
+		}
+	}
+	if client.CreditsUsed() != 1 {
+		t.Errorf("expected the shared call to be charged once, got %d", client.CreditsUsed())
+	}
+}
+
+func TestWithRequestCoalescing_SequentialCallsAreNotShared(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "abc"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRequestCoalescing())
+	for i := 0; i < 2; i++ {
+		if _, err := client.YouTubeVideo("abc"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestWithRequestCoalescing_LeaderCancellationDoesNotFailFollowers(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "dQw4w9WgXcQ", "title": "Popular"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRequestCoalescing())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := client.YouTubeVideo("dQw4w9WgXcQ", WithContext(ctx))
+		leaderErr <- err
+	}()
+	for requests.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	followerTitle := make(chan string, 1)
+	go func() {
+		video, err := client.YouTubeVideo("dQw4w9WgXcQ")
+		if err != nil {
+			t.Errorf("unexpected follower error: %v", err)
+			followerTitle <- ""
+			return
+		}
+		followerTitle <- video.Title
+	}()
+	for client.Stats().CoalescedRequests == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the leader to be cancelled, got %v", err)
+	}
+	close(release)
+	if title := <-followerTitle; title != "Popular" {
+		t.Errorf("expected the follower to get the shared response, got %q", title)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected a single upstream call, got %d", requests.Load())
+	}
+}
+
+func TestWithRequestCoalescing_ForceRefreshSkipsCachedCalls(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "dQw4w9WgXcQ", "title": fmt.Sprintf("v%d", n)})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL),
+		WithRequestCoalescing(), WithCache(NewMemoryCache(), time.Hour))
+	if _, err := client.YouTubeVideo("dQw4w9WgXcQ"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	video, err := client.YouTubeVideo("dQw4w9WgXcQ", WithForceRefresh())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if video.Title != "v2" {
+		t.Errorf("expected a fresh response, got %q", video.Title)
+	}
+	if key := coalesceKeyOf(t, client); key != "" {
+		t.Errorf("expected no call left in flight, got %q", key)
+	}
+}
+
+// coalesceKeyOf returns a key of a call still in flight, if any
+func coalesceKeyOf(t *testing.T, client *Supadata) string {
+	t.Helper()
+	client.inflight.mu.Lock()
+	defer client.inflight.mu.Unlock()
+	for key := range client.inflight.calls {
+		return key
+	}
+	return ""
+}
@@ -0,0 +1,16 @@
+package supadata
+
+// WithJSONCodec replaces encoding/json for encoding request bodies and decoding API responses, e.g. with a faster
+// drop-in implementation such as jsoniter or sonic. The functions must behave like json.Marshal and json.Unmarshal,
+// including honoring the json struct tags. A nil function keeps the encoding/json one. YouTubeBatchItems keeps
+// streaming its response with encoding/json.
+func WithJSONCodec(marshal func(v any) ([]byte, error), unmarshal func(data []byte, v any) error) ConfigOption {
+	return func(config *Config) {
+		if marshal != nil {
+			config.jsonMarshal = marshal
+		}
+		if unmarshal != nil {
+			config.jsonUnmarshal = unmarshal
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package supadata
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithJSONCodec(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/web/crawl":
+			jsonResponse(w, http.StatusOK, map[string]any{"jobId": "job-1"})
+		default:
+			errorResponse(w, http.StatusNotFound, NotFound, "Not found", "")
+		}
+	}))
+	defer server.Close()
+
+	var marshaled, unmarshaled int
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithJSONCodec(
+		func(v any) ([]byte, error) {
+			marshaled++
+			return json.Marshal(v)
+		},
+		func(data []byte, v any) error {
+			unmarshaled++
+			return json.Unmarshal(data, v)
+		},
+	))
+
+	job, err := client.Crawl(&CrawlBody{Url: "https://example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.JobId != "job-1" {
+		t.Errorf("expected jobId job-1, got %q", job.JobId)
+	}
+	if _, err := client.YouTubeVideo("missing"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if marshaled != 1 || unmarshaled != 2 {
+		t.Errorf("expected the codec to encode the body and decode both responses, got %d marshals and %d unmarshals", marshaled, unmarshaled)
+	}
+}
@@ -0,0 +1,76 @@
+package supadata
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// WithMaxConcurrentRequests limits the requests in flight to the API to n across every goroutine using the client.
+// A request holds its slot until its response body is closed. Requests served from the cache are not limited, and
+// the second request of a hedged call takes a slot of its own, see WithHedgedRequests.
+func WithMaxConcurrentRequests(n int) ConfigOption {
+	return func(config *Config) {
+		if n > 0 {
+			config.maxConcurrent = n
+		}
+	}
+}
+
+// semaphore bounds the number of requests in flight
+type semaphore chan struct{}
+
+// acquire takes a slot, blocking until one is free or ctx is done
+func (sem semaphore) acquire(ctx context.Context) error {
+	select {
+	case sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// tryAcquire takes a slot if one is free, without blocking
+func (sem semaphore) tryAcquire() bool {
+	select {
+	case sem <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (sem semaphore) release() {
+	<-sem
+}
+
+// releaseOnClose releases a slot of sem once body is closed
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// sendLimited sends req once a slot of the concurrency limit, if any, is free
+func (s *Supadata) sendLimited(req *http.Request) (*http.Response, error) {
+	if s.inflightLimit == nil {
+		return s.sendHedged(req)
+	}
+	if err := s.inflightLimit.acquire(req.Context()); err != nil {
+		return nil, err
+	}
+	resp, err := s.sendHedged(req)
+	if err != nil {
+		s.inflightLimit.release()
+		return nil, err
+	}
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: s.inflightLimit.release}
+	return resp, nil
+}
@@ -0,0 +1,60 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithMaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "video"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithMaxConcurrentRequests(2))
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.YouTubeVideo("video"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got != 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", got)
+	}
+	if len(client.inflightLimit) != 0 {
+		t.Errorf("expected every slot to be released, got %d held", len(client.inflightLimit))
+	}
+}
+
+func TestWithMaxConcurrentRequests_ContextCancelled(t *testing.T) {
+	client := NewSupadata(WithAPIKey("test-api-key"), WithMaxConcurrentRequests(1))
+	client.inflightLimit <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.YouTubeVideo("video", WithContext(ctx)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded while waiting for a slot, got %v", err)
+	}
+}
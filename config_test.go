@@ -0,0 +1,102 @@
+package supadata
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithClient_DoesNotMutateCallerClient(t *testing.T) {
+	shared := &http.Client{Timeout: 5 * time.Second}
+	client := NewSupadata(WithClient(shared), WithTimeout(time.Second))
+
+	if shared.Timeout != 5*time.Second {
+		t.Errorf("expected the caller's client to be left unchanged, got a timeout of %v", shared.Timeout)
+	}
+	if client.config.client == shared || client.config.client.Timeout != time.Second {
+		t.Errorf("expected the timeout to apply to a copy of the client, got %+v", client.config.client)
+	}
+
+	fallbacks := []string{"https://fallback.example.com"}
+	client = NewSupadata(WithBaseURLs("https://api.example.com", fallbacks...))
+	fallbacks[0] = "https://changed.example.com"
+	if client.config.fallbackURLs[0] != "https://fallback.example.com" {
+		t.Errorf("expected the fallback URLs to be copied, got %v", client.config.fallbackURLs)
+	}
+}
+
+func TestSupadata_ConcurrentUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/youtube/video":
+			jsonResponse(w, http.StatusOK, map[string]any{"id": r.URL.Query().Get("id"), "title": "Video"})
+		case "/youtube/transcript":
+			jsonResponse(w, http.StatusOK, map[string]any{"content": []map[string]any{{"text": "hello"}}, "lang": "en"})
+		default:
+			jsonResponse(w, http.StatusOK, map[string]any{"plan": "pro"})
+		}
+	}))
+	defer server.Close()
+
+	client := NewSupadata(
+		WithAPIKeys("key-1", "key-2"),
+		WithBaseURL(server.URL),
+		WithCache(NewMemoryCache(), time.Minute),
+		WithRequestCoalescing(),
+		WithRetries(2),
+		WithRateLimit(1000, 100),
+		WithMaxConcurrentRequests(4),
+		WithCreditBudget(1000),
+		WithScopeDetection(),
+	)
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			switch i % 3 {
+			case 0:
+				_, err = client.YouTubeVideo("dQw4w9WgXcQ")
+			case 1:
+				_, err = client.YouTubeTranscript(&YouTubeTranscriptParams{VideoId: "dQw4w9WgXcQ"}, WithContext(context.Background()))
+			default:
+				_, err = client.Me()
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			_ = client.Stats()
+		}()
+	}
+	wg.Wait()
+
+	if stats := client.Stats(); stats.CacheHits+stats.CacheMisses == 0 {
+		t.Errorf("expected the calls to be counted, got %+v", stats)
+	}
+}
+
+func TestWithTimeout_ComposesWithClient(t *testing.T) {
+	transport := &http.Transport{}
+	for name, opts := range map[string][]ConfigOption{
+		"timeout first": {WithTimeout(time.Second), WithClient(&http.Client{Transport: transport})},
+		"timeout last":  {WithClient(&http.Client{Transport: transport}), WithTimeout(time.Second)},
+	} {
+		client := NewSupadata(opts...)
+		if client.config.client.Timeout != time.Second || client.config.client.Transport != transport {
+			t.Errorf("%s: expected the timeout to apply to the custom client, got %+v", name, client.config.client)
+		}
+	}
+
+	custom := &http.Client{Timeout: 5 * time.Second}
+	if client := NewSupadata(WithClient(custom)); client.config.client.Timeout != 5*time.Second {
+		t.Errorf("expected the timeout of the custom client to be kept, got %v", client.config.client.Timeout)
+	}
+	if client := NewSupadata(WithTimeout(0)); client.config.client.Timeout != 0 {
+		t.Errorf("expected a zero timeout to disable the timeout, got %v", client.config.client.Timeout)
+	}
+}
@@ -0,0 +1,146 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrCrawlNotCompleted is returned when crawl pages are requested before the crawl has completed
+var ErrCrawlNotCompleted = errors.New("crawl is not completed")
+
+// CrawlPages iterates over every page of a completed crawl job, transparently following the pagination.
+// Iteration stops after the first error.
+func (s *Supadata) CrawlPages(ctx context.Context, jobId string) iter.Seq2[CrawlPage, error] {
+	return pageItems(ctx, s, func(ctx context.Context) (*Page[CrawlPage], error) {
+		return s.CrawlResultPage(ctx, jobId)
+	})
+}
+
+// ResumeCrawlPages iterates over the pages of a completed crawl job like CrawlPages, recording in the job store the
+// skip offset of every page once its items have all been yielded. An iteration interrupted by an error, a crash or
+// a break is resumed from the first page not fully consumed, instead of fetching every page again. The record is
+// removed once the last page has been yielded. Without a job store, ResumeCrawlPages behaves like CrawlPages.
+// Iteration stops after the first error.
+func (s *Supadata) ResumeCrawlPages(ctx context.Context, jobId string) iter.Seq2[CrawlPage, error] {
+	return func(yield func(CrawlPage, error) bool) {
+		ctx := ensureLineage(ctx)
+		recordId := "crawl-pages:" + jobId
+		cursor, err := s.crawlPagesCursor(recordId)
+		if err != nil {
+			yield(CrawlPage{}, err)
+			return
+		}
+
+		page, err := fetchCrawlResultPage(ctx, s, cursor, jobId)
+		for {
+			if err != nil {
+				yield(CrawlPage{}, err)
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !page.HasNext() {
+				if err := s.forgetJob(recordId); err != nil {
+					yield(CrawlPage{}, err)
+				}
+				return
+			}
+			if err := s.registerJobRecord(JobRecord{Id: recordId, Kind: JobCrawlPages, Cursor: page.NextCursor}); err != nil {
+				yield(CrawlPage{}, err)
+				return
+			}
+			err = s.NextPage(ctx, page)
+		}
+	}
+}
+
+// crawlPagesCursor returns the cursor recorded by ResumeCrawlPages, or an empty cursor to start from the first page
+func (s *Supadata) crawlPagesCursor(recordId string) (string, error) {
+	if s.config.jobStore == nil {
+		return "", nil
+	}
+	record, err := s.config.jobStore.Load(recordId)
+	if errors.Is(err, ErrJobNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return record.Cursor, nil
+}
+
+// CrawlPageFilter selects the crawl pages worth keeping, e.g. to keep boilerplate pages out of an index.
+// Zero fields do not filter.
+type CrawlPageFilter struct {
+	// MinCharacters skips pages with fewer characters of content
+	MinCharacters int
+	// ExcludeExtensions skips pages whose URL path ends with one of the extensions, e.g. ".pdf" or ".xml",
+	// regardless of case
+	ExcludeExtensions []string
+	// Keep, when set, skips the pages for which it returns false
+	Keep func(CrawlPage) bool
+}
+
+// Match reports whether page passes the filter
+func (f CrawlPageFilter) Match(page CrawlPage) bool {
+	if f.MinCharacters > 0 {
+		characters := page.CountCharacters
+		if characters == 0 {
+			characters = utf8.RuneCountInString(page.Content)
+		}
+		if characters < f.MinCharacters {
+			return false
+		}
+	}
+	if len(f.ExcludeExtensions) > 0 {
+		p := page.Url
+		if u, err := url.Parse(page.Url); err == nil {
+			p = u.Path
+		}
+		ext := path.Ext(p)
+		for _, excluded := range f.ExcludeExtensions {
+			if ext != "" && strings.EqualFold(ext, "."+strings.TrimPrefix(excluded, ".")) {
+				return false
+			}
+		}
+	}
+	return f.Keep == nil || f.Keep(page)
+}
+
+// CrawlPagesFiltered iterates over the pages of a completed crawl job that match filter.
+// Iteration stops after the first error.
+func (s *Supadata) CrawlPagesFiltered(ctx context.Context, jobId string, filter CrawlPageFilter) iter.Seq2[CrawlPage, error] {
+	return func(yield func(CrawlPage, error) bool) {
+		for page, err := range s.CrawlPages(ctx, jobId) {
+			if err != nil {
+				yield(CrawlPage{}, err)
+				return
+			}
+			if filter.Match(page) && !yield(page, nil) {
+				return
+			}
+		}
+	}
+}
+
+// nextSkip returns the skip offset encoded in the next link of a crawl result, or fallback when it has none
+func nextSkip(next string, fallback int) int {
+	u, err := url.Parse(next)
+	if err != nil {
+		return fallback
+	}
+	skip, err := strconv.Atoi(u.Query().Get("skip"))
+	if err != nil {
+		return fallback
+	}
+	return skip
+}
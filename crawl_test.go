@@ -0,0 +1,194 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// crawlPagesServer serves total pages of a completed crawl in chunks of size pageSize
+func crawlPagesServer(t *testing.T, total, pageSize int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		skip := 0
+		_, _ = fmt.Sscanf(r.URL.Query().Get("skip"), "%d", &skip)
+
+		var pages []map[string]any
+		for i := skip; i < total && i < skip+pageSize; i++ {
+			pages = append(pages, map[string]any{"url": fmt.Sprintf("https://example.com/%d", i)})
+		}
+		resp := map[string]any{"status": "completed", "pages": pages}
+		if skip+pageSize < total {
+			resp["next"] = fmt.Sprintf("https://api.supadata.ai/v1/web/crawl/job-1?skip=%d", skip+pageSize)
+		}
+		jsonResponse(w, http.StatusOK, resp)
+	}))
+}
+
+func TestCrawlPages_FollowsPagination(t *testing.T) {
+	server := crawlPagesServer(t, 7, 3)
+	defer server.Close()
+
+	client := newTestClient(server)
+	var urls []string
+	for page, err := range client.CrawlPages(context.Background(), "job-1") {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		urls = append(urls, page.Url)
+	}
+
+	if len(urls) != 7 {
+		t.Fatalf("expected 7 pages, got %d", len(urls))
+	}
+	for i, u := range urls {
+		if expected := fmt.Sprintf("https://example.com/%d", i); u != expected {
+			t.Errorf("expected page %d to be %q, got %q", i, expected, u)
+		}
+	}
+}
+
+func TestCrawlPages_EarlyBreak(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"status": "completed",
+			"pages":  []map[string]any{{"url": "https://example.com/a"}, {"url": "https://example.com/b"}},
+			"next":   "https://api.supadata.ai/v1/web/crawl/job-1?skip=2",
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	for range client.CrawlPages(context.Background(), "job-1") {
+		break
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
+func TestResumeCrawlPages(t *testing.T) {
+	server := crawlPagesServer(t, 7, 3)
+	defer server.Close()
+
+	store := NewMemoryJobStore()
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithJobStore(store))
+	var urls []string
+	for page, err := range client.ResumeCrawlPages(context.Background(), "job-1") {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		urls = append(urls, page.Url)
+		if len(urls) == 4 {
+			break
+		}
+	}
+	record, err := store.Load("crawl-pages:job-1")
+	if err != nil || record.Kind != JobCrawlPages || record.Cursor != "3" {
+		t.Fatalf("expected the second page to be recorded, got %+v (%v)", record, err)
+	}
+
+	urls = nil
+	for page, err := range client.ResumeCrawlPages(context.Background(), "job-1") {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		urls = append(urls, page.Url)
+	}
+	if len(urls) != 4 || urls[0] != "https://example.com/3" {
+		t.Errorf("expected the pages from the second page on, got %v", urls)
+	}
+	if _, err := store.Load("crawl-pages:job-1"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("expected the record to be removed, got %v", err)
+	}
+}
+
+func TestCrawlPages_NotCompleted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{"status": "scraping"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	for _, err := range client.CrawlPages(context.Background(), "job-1") {
+		if !errors.Is(err, ErrCrawlNotCompleted) {
+			t.Errorf("expected ErrCrawlNotCompleted, got %v", err)
+		}
+	}
+}
+
+func TestNextSkip(t *testing.T) {
+	tests := []struct {
+		next     string
+		fallback int
+		expected int
+	}{
+		{"https://api.supadata.ai/v1/web/crawl/job-1?skip=100", 5, 100},
+		{"/web/crawl/job-1?skip=20", 5, 20},
+		{"opaque-token", 5, 5},
+		{"", 5, 5},
+	}
+	for _, tt := range tests {
+		if got := nextSkip(tt.next, tt.fallback); got != tt.expected {
+			t.Errorf("nextSkip(%q, %d) = %d, expected %d", tt.next, tt.fallback, got, tt.expected)
+		}
+	}
+}
+
+func TestCrawlPageFilter_Match(t *testing.T) {
+	filter := CrawlPageFilter{
+		MinCharacters:     10,
+		ExcludeExtensions: []string{"pdf", ".XML"},
+		Keep: func(page CrawlPage) bool {
+			return !strings.Contains(page.Url, "/tag/")
+		},
+	}
+
+	tests := []struct {
+		page CrawlPage
+		want bool
+	}{
+		{CrawlPage{Url: "https://example.com/guide", Content: "A long enough page"}, true},
+		{CrawlPage{Url: "https://example.com/short", Content: "Too short"}, false},
+		{CrawlPage{Url: "https://example.com/counted", Content: "short", CountCharacters: 500}, true},
+		{CrawlPage{Url: "https://example.com/file.PDF?download=1", Content: "A long enough page"}, false},
+		{CrawlPage{Url: "https://example.com/sitemap.xml", Content: "A long enough page"}, false},
+		{CrawlPage{Url: "https://example.com/tag/go", Content: "A long enough page"}, false},
+	}
+	for _, tt := range tests {
+		if got := filter.Match(tt.page); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.page.Url, tt.want, got)
+		}
+	}
+
+	if !(CrawlPageFilter{}).Match(CrawlPage{Url: "https://example.com/empty.pdf"}) {
+		t.Error("expected the zero filter to match every page")
+	}
+}
+
+func TestCrawlPagesFiltered(t *testing.T) {
+	server := crawlPagesServer(t, 10, 4)
+	defer server.Close()
+
+	client := newTestClient(server)
+	var urls []string
+	filter := CrawlPageFilter{Keep: func(page CrawlPage) bool {
+		return strings.HasSuffix(page.Url, "1") || strings.HasSuffix(page.Url, "8")
+	}}
+	for page, err := range client.CrawlPagesFiltered(context.Background(), "job-1", filter) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		urls = append(urls, page.Url)
+	}
+
+	if strings.Join(urls, ",") != "https://example.com/1,https://example.com/8" {
+		t.Errorf("unexpected pages %v", urls)
+	}
+}
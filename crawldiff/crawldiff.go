@@ -0,0 +1,98 @@
+// Package crawldiff detects the pages added, removed and changed between two crawls of a site, with a Store
+// persisting the snapshot of the previous run.
+package crawldiff
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/petros0/supadata-go"
+)
+
+// Snapshot records the content hash of every page of a crawl
+type Snapshot struct {
+	JobId   string    `json:"jobId,omitempty"`
+	TakenAt time.Time `json:"takenAt"`
+	// Pages maps the URL of every page to the hash of its content
+	Pages map[string]string `json:"pages"`
+}
+
+// NewSnapshot creates a snapshot of pages
+func NewSnapshot(pages []supadata.CrawlPage) *Snapshot {
+	s := &Snapshot{TakenAt: time.Now().UTC(), Pages: make(map[string]string, len(pages))}
+	for _, page := range pages {
+		s.Add(page)
+	}
+	return s
+}
+
+// FromCrawl creates a snapshot of the pages of a completed crawl job
+func FromCrawl(ctx context.Context, client *supadata.Supadata, jobId string) (*Snapshot, error) {
+	s := NewSnapshot(nil)
+	s.JobId = jobId
+	for page, err := range client.CrawlPages(ctx, jobId) {
+		if err != nil {
+			return nil, err
+		}
+		s.Add(page)
+	}
+	return s, nil
+}
+
+// Add records the content hash of page
+func (s *Snapshot) Add(page supadata.CrawlPage) {
+	s.Pages[strings.TrimSpace(page.Url)] = HashContent(page.Content)
+}
+
+// HashContent returns the SHA-256 of content with surrounding whitespace removed and line endings normalized, so
+// that insignificant formatting differences are not reported as changes
+func HashContent(content string) string {
+	normalized := strings.TrimSpace(strings.ReplaceAll(content, "\r\n", "\n"))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// Diff lists the URLs of the pages that differ between two snapshots, each sorted
+type Diff struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Changed []string `json:"changed"`
+}
+
+// Empty reports whether both snapshots have the same pages with the same content
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Compare returns the differences from previous to current. A nil previous snapshot reports every page as added.
+func Compare(previous, current *Snapshot) Diff {
+	d := Diff{Added: []string{}, Removed: []string{}, Changed: []string{}}
+	var before map[string]string
+	if previous != nil {
+		before = previous.Pages
+	}
+
+	for url, hash := range current.Pages {
+		previousHash, ok := before[url]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, url)
+		case previousHash != hash:
+			d.Changed = append(d.Changed, url)
+		}
+	}
+	for url := range before {
+		if _, ok := current.Pages[url]; !ok {
+			d.Removed = append(d.Removed, url)
+		}
+	}
+
+	slices.Sort(d.Added)
+	slices.Sort(d.Removed)
+	slices.Sort(d.Changed)
+	return d
+}
@@ -0,0 +1,100 @@
+package crawldiff
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/petros0/supadata-go"
+)
+
+func TestCompare(t *testing.T) {
+	previous := NewSnapshot([]supadata.CrawlPage{
+		{Url: "https://example.com/", Content: "Home"},
+		{Url: "https://example.com/old", Content: "Old"},
+		{Url: "https://example.com/docs", Content: "Docs v1"},
+		{Url: "https://example.com/same", Content: "Same\r\n"},
+	})
+	current := NewSnapshot([]supadata.CrawlPage{
+		{Url: "https://example.com/", Content: "Home"},
+		{Url: "https://example.com/new", Content: "New"},
+		{Url: "https://example.com/docs", Content: "Docs v2"},
+		{Url: "https://example.com/same", Content: "Same\n"},
+	})
+
+	d := Compare(previous, current)
+	if !slices.Equal(d.Added, []string{"https://example.com/new"}) ||
+		!slices.Equal(d.Removed, []string{"https://example.com/old"}) ||
+		!slices.Equal(d.Changed, []string{"https://example.com/docs"}) {
+		t.Errorf("unexpected diff %+v", d)
+	}
+	if d.Empty() {
+		t.Error("expected a non-empty diff")
+	}
+	if !Compare(current, current).Empty() {
+		t.Error("expected identical snapshots to have an empty diff")
+	}
+	if first := Compare(nil, current); len(first.Added) != 4 {
+		t.Errorf("expected every page to be added without a previous snapshot, got %+v", first)
+	}
+}
+
+func testStore(t *testing.T, store Store) {
+	t.Helper()
+	if _, err := store.Load("docs"); err != ErrNoSnapshot {
+		t.Fatalf("expected ErrNoSnapshot, got %v", err)
+	}
+
+	first := NewSnapshot([]supadata.CrawlPage{{Url: "https://example.com/", Content: "v1"}})
+	d, err := Update(store, "docs", first)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(d.Added) != 1 {
+		t.Errorf("expected the first run to add every page, got %+v", d)
+	}
+
+	second := NewSnapshot([]supadata.CrawlPage{{Url: "https://example.com/", Content: "v2"}})
+	d, err = Update(store, "docs", second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(d.Changed, []string{"https://example.com/"}) {
+		t.Errorf("expected the page to be changed, got %+v", d)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testStore(t, store)
+}
+
+func TestFromCrawl(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status": "completed",
+			"pages":  []map[string]any{{"url": "https://example.com/", "content": "Home"}},
+		})
+	}))
+	defer server.Close()
+
+	client := supadata.NewSupadata(supadata.WithAPIKey("test-api-key"), supadata.WithBaseURL(server.URL))
+	snapshot, err := FromCrawl(context.Background(), client, "job-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if snapshot.JobId != "job-1" || snapshot.Pages["https://example.com/"] != HashContent("Home") {
+		t.Errorf("unexpected snapshot %+v", snapshot)
+	}
+}
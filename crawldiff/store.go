@@ -0,0 +1,107 @@
+package crawldiff
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrNoSnapshot is returned by a Store when no snapshot was saved under a name
+var ErrNoSnapshot = errors.New("crawldiff: no snapshot")
+
+// Store persists the latest snapshot of every monitored site under a name of the caller's choosing
+type Store interface {
+	Save(name string, snapshot *Snapshot) error
+	// Load returns ErrNoSnapshot when no snapshot was saved under name
+	Load(name string) (*Snapshot, error)
+}
+
+// Update compares current with the snapshot saved under name, then saves current in its place
+func Update(store Store, name string, current *Snapshot) (Diff, error) {
+	previous, err := store.Load(name)
+	if err != nil && !errors.Is(err, ErrNoSnapshot) {
+		return Diff{}, err
+	}
+	diff := Compare(previous, current)
+	if err := store.Save(name, current); err != nil {
+		return Diff{}, err
+	}
+	return diff, nil
+}
+
+// MemoryStore is a Store keeping snapshots in memory
+type MemoryStore struct {
+	mu        sync.Mutex
+	snapshots map[string]*Snapshot
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{snapshots: make(map[string]*Snapshot)}
+}
+
+func (m *MemoryStore) Save(name string, snapshot *Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.snapshots[name] = snapshot
+	return nil
+}
+
+func (m *MemoryStore) Load(name string) (*Snapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snapshot, ok := m.snapshots[name]
+	if !ok {
+		return nil, ErrNoSnapshot
+	}
+	return snapshot, nil
+}
+
+// FileStore is a Store keeping one JSON file per snapshot in a directory
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a FileStore in dir, creating the directory when needed
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (f *FileStore) path(name string) string {
+	return filepath.Join(f.dir, url.PathEscape(name)+".json")
+}
+
+// Save writes the snapshot to a temporary file before renaming it, so that a crash never leaves a truncated snapshot
+func (f *FileStore) Save(name string, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	path := f.path(name)
+	if err := os.WriteFile(path+".tmp", data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (f *FileStore) Load(name string) (*Snapshot, error) {
+	data, err := os.ReadFile(filepath.Clean(f.path(name)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoSnapshot
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
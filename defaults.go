@@ -0,0 +1,36 @@
+package supadata
+
+// WithDefaultLang sets the language of the transcripts requested without a Lang, including the target language of
+// translations and transcript batches
+func WithDefaultLang(lang Lang) ConfigOption {
+	return func(config *Config) {
+		config.defaultLang = lang
+	}
+}
+
+// WithDefaultChunkSize sets the chunk size of the transcripts requested without a ChunkSize
+func WithDefaultChunkSize(n int) ConfigOption {
+	return func(config *Config) {
+		config.defaultChunkSize = n
+	}
+}
+
+// WithDefaultTranscriptMode sets the mode of the transcripts requested without a Mode
+func WithDefaultTranscriptMode(mode TranscriptModeParam) ConfigOption {
+	return func(config *Config) {
+		config.defaultMode = mode
+	}
+}
+
+// applyTranscriptDefaults fills the empty transcript params with the client defaults, skipping the nil ones
+func (s *Supadata) applyTranscriptDefaults(lang *Lang, chunkSize *int, mode *TranscriptModeParam) {
+	if lang != nil && *lang == "" {
+		*lang = s.config.defaultLang
+	}
+	if chunkSize != nil && *chunkSize == 0 {
+		*chunkSize = s.config.defaultChunkSize
+	}
+	if mode != nil && *mode == "" {
+		*mode = s.config.defaultMode
+	}
+}
@@ -0,0 +1,61 @@
+package supadata
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTranscriptDefaults(t *testing.T) {
+	var queries []map[string]string
+	var batchLang string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var body YouTubeTranscriptBatchParams
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			batchLang = string(body.Lang)
+			jsonResponse(w, http.StatusOK, map[string]any{"jobId": "job-1"})
+			return
+		}
+		q := r.URL.Query()
+		queries = append(queries, map[string]string{"lang": q.Get("lang"), "chunkSize": q.Get("chunkSize"), "mode": q.Get("mode")})
+		jsonResponse(w, http.StatusOK, map[string]any{"content": []map[string]any{{"text": "Hello"}}, "lang": "en"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(
+		WithAPIKey("test-api-key"),
+		WithBaseURL(server.URL),
+		WithDefaultLang("en"),
+		WithDefaultChunkSize(500),
+		WithDefaultTranscriptMode(Native),
+	)
+
+	if _, err := client.Transcript(&TranscriptParams{Url: "https://youtu.be/dQw4w9WgXcQ"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.YouTubeTranscript(&YouTubeTranscriptParams{VideoId: "dQw4w9WgXcQ", Lang: "fr", Mode: Generate}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []map[string]string{
+		{"lang": "en", "chunkSize": "500", "mode": "native"},
+		{"lang": "fr", "chunkSize": "500", "mode": "generate"},
+	}
+	for i := range expected {
+		for key, value := range expected[i] {
+			if queries[i][key] != value {
+				t.Errorf("call %d: expected %s=%q, got %q", i, key, value, queries[i][key])
+			}
+		}
+	}
+
+	// Translating a batch uses the default language as its target
+	if _, err := client.YouTubeTranscriptBatch(&YouTubeTranscriptBatchParams{VideoIds: []string{"dQw4w9WgXcQ"}, Translate: Bool(true)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if batchLang != "en" {
+		t.Errorf("expected the batch to use the default lang, got %q", batchLang)
+	}
+}
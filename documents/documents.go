@@ -0,0 +1,100 @@
+// Package documents converts transcripts, scrape results and crawl pages into a generic Document shape for RAG
+// ingestion pipelines. A Document maps directly onto a langchaingo schema.Document:
+//
+//	schema.Document{PageContent: doc.Content, Metadata: doc.Metadata}
+package documents
+
+import (
+	"context"
+	"iter"
+	"strings"
+
+	"github.com/petros0/supadata-go"
+)
+
+// Metadata keys set on the documents
+const (
+	// MetadataSource is the URL the content was extracted from
+	MetadataSource      = "source"
+	MetadataKind        = "kind"
+	MetadataTitle       = "title"
+	MetadataDescription = "description"
+	MetadataLang        = "lang"
+	MetadataVideoId     = "videoId"
+)
+
+// Kind values of the MetadataKind key
+const (
+	KindTranscript = "transcript"
+	KindPage       = "page"
+)
+
+// Document is a piece of text along with metadata describing where it comes from
+type Document struct {
+	Content  string         `json:"content"`
+	Metadata map[string]any `json:"metadata"`
+}
+
+// FromTranscript converts the transcript of the video at url
+func FromTranscript(url string, transcript *supadata.SyncTranscript) Document {
+	return transcriptDocument(transcript.Content, transcript.Lang, map[string]any{MetadataSource: url})
+}
+
+// FromYouTubeTranscript converts the transcript of a YouTube video
+func FromYouTubeTranscript(videoId string, transcript *supadata.YouTubeTranscriptResult) Document {
+	return transcriptDocument(transcript.Content, transcript.Lang, map[string]any{
+		MetadataSource:  "https://www.youtube.com/watch?v=" + videoId,
+		MetadataVideoId: videoId,
+	})
+}
+
+// FromScrape converts a scraped web page
+func FromScrape(result *supadata.ScrapeResult) Document {
+	return pageDocument(result.Url, result.Content, result.Name, result.Description)
+}
+
+// FromCrawlPage converts a crawled web page
+func FromCrawlPage(page supadata.CrawlPage) Document {
+	return pageDocument(page.Url, page.Content, page.Name, page.Description)
+}
+
+// LoadCrawl iterates over the pages of a completed crawl job as documents. Iteration stops after the first error.
+func LoadCrawl(ctx context.Context, client *supadata.Supadata, jobId string) iter.Seq2[Document, error] {
+	return func(yield func(Document, error) bool) {
+		for page, err := range client.CrawlPages(ctx, jobId) {
+			if err != nil {
+				yield(Document{}, err)
+				return
+			}
+			if !yield(FromCrawlPage(page), nil) {
+				return
+			}
+		}
+	}
+}
+
+func transcriptDocument(content []supadata.TranscriptContent, lang string, metadata map[string]any) Document {
+	texts := make([]string, len(content))
+	for i, c := range content {
+		texts[i] = c.Text
+	}
+	metadata[MetadataKind] = KindTranscript
+	if lang != "" {
+		metadata[MetadataLang] = lang
+	}
+	return Document{Content: strings.Join(texts, " "), Metadata: metadata}
+}
+
+func pageDocument(url, content, title, description string) Document {
+	metadata := map[string]any{
+		MetadataSource: url,
+		MetadataKind:   KindPage,
+	}
+	if title != "" {
+		metadata[MetadataTitle] = title
+	}
+	if description != "" {
+		metadata[MetadataDescription] = description
+	}
+	return Document{Content: content, Metadata: metadata}
+}
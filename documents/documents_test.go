@@ -0,0 +1,74 @@
+package documents
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/petros0/supadata-go"
+)
+
+func TestFromYouTubeTranscript(t *testing.T) {
+	doc := FromYouTubeTranscript("abc", &supadata.YouTubeTranscriptResult{
+		Lang:    "en",
+		Content: []supadata.TranscriptContent{{Text: "Hello"}, {Text: "world"}},
+	})
+
+	if doc.Content != "Hello world" {
+		t.Errorf("expected %q, got %q", "Hello world", doc.Content)
+	}
+	want := map[string]any{
+		MetadataSource:  "https://www.youtube.com/watch?v=abc",
+		MetadataVideoId: "abc",
+		MetadataKind:    KindTranscript,
+		MetadataLang:    "en",
+	}
+	for key, value := range want {
+		if doc.Metadata[key] != value {
+			t.Errorf("expected metadata %s=%v, got %v", key, value, doc.Metadata[key])
+		}
+	}
+}
+
+func TestFromScrape(t *testing.T) {
+	doc := FromScrape(&supadata.ScrapeResult{Url: "https://example.com", Content: "# Example", Name: "Example"})
+
+	if doc.Content != "# Example" || doc.Metadata[MetadataSource] != "https://example.com" || doc.Metadata[MetadataTitle] != "Example" {
+		t.Errorf("unexpected document %+v", doc)
+	}
+	if _, ok := doc.Metadata[MetadataDescription]; ok {
+		t.Error("expected empty description to be omitted")
+	}
+}
+
+func TestLoadCrawl(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status": "completed",
+			"pages": []map[string]any{
+				{"url": "https://example.com/a", "content": "A"},
+				{"url": "https://example.com/b", "content": "B"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := supadata.NewSupadata(supadata.WithAPIKey("test-api-key"), supadata.WithBaseURL(server.URL))
+	var sources []any
+	for doc, err := range LoadCrawl(context.Background(), client, "job-1") {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if doc.Metadata[MetadataKind] != KindPage {
+			t.Errorf("expected kind %q, got %v", KindPage, doc.Metadata[MetadataKind])
+		}
+		sources = append(sources, doc.Metadata[MetadataSource])
+	}
+
+	if len(sources) != 2 || sources[0] != "https://example.com/a" || sources[1] != "https://example.com/b" {
+		t.Errorf("unexpected sources %v", sources)
+	}
+}
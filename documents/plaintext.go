@@ -0,0 +1,89 @@
+package documents
+
+import (
+	"regexp"
+	"strings"
+)
+
+// PlainTextOptions customizes PlainText
+type PlainTextOptions struct {
+	// KeepCodeBlocks keeps the content of fenced code blocks, without the fences, instead of dropping it
+	KeepCodeBlocks bool
+}
+
+var (
+	imagePattern          = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)|!\[[^\]]*\]\[[^\]]*\]`)
+	inlineLinkPattern     = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	referenceLinkPattern  = regexp.MustCompile(`\[([^\]]*)\]\[[^\]]*\]`)
+	linkDefinitionPattern = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*\S+.*$`)
+	autolinkPattern       = regexp.MustCompile(`<((?:https?|mailto):[^>\s]+)>`)
+	htmlTagPattern        = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	inlineCodePattern     = regexp.MustCompile("`+([^`]*)`+")
+	strongPattern         = regexp.MustCompile(`(\*\*|__)(\S(?:.*?\S)?)(\*\*|__)`)
+	emphasisPattern       = regexp.MustCompile(`(^|[^\w*])[*_](\S(?:[^*_]*?\S)?)[*_]($|[^\w*])`)
+	strikethroughPattern  = regexp.MustCompile(`~~(.+?)~~`)
+	headingPattern        = regexp.MustCompile(`^\s{0,3}#{1,6}\s+(.*?)(?:\s+#+)?\s*$`)
+	blockquotePattern     = regexp.MustCompile(`^\s*(?:>\s?)+`)
+	listMarkerPattern     = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+(?:\[[ xX]\]\s+)?`)
+	ruleLinePattern       = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	tableSeparatorPattern = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(?:\|\s*:?-+:?\s*)*\|?\s*$`)
+	blankLinesPattern     = regexp.MustCompile(`\n{3,}`)
+)
+
+// PlainText converts the markdown content returned by Scrape and Crawl into plain text for search indexing and NLP.
+// Links are replaced by their text, images, HTML tags and link definitions are removed, and headings, emphasis,
+// lists, quotes and tables lose their markup.
+func PlainText(markdown string, opts PlainTextOptions) string {
+	var lines []string
+	inFence := false
+	for _, line := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			if opts.KeepCodeBlocks {
+				lines = append(lines, line)
+			}
+			continue
+		}
+		if linkDefinitionPattern.MatchString(line) || ruleLinePattern.MatchString(line) || isTableSeparator(line) {
+			continue
+		}
+		lines = append(lines, plainLine(line))
+	}
+
+	text := blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text)
+}
+
+// isTableSeparator reports whether line is the separator row between the header and the body of a table
+func isTableSeparator(line string) bool {
+	return strings.Contains(line, "-") && tableSeparatorPattern.MatchString(line)
+}
+
+// plainLine removes the markdown markup of a single line outside of code blocks
+func plainLine(line string) string {
+	line = headingPattern.ReplaceAllString(line, "$1")
+	line = blockquotePattern.ReplaceAllString(line, "")
+	line = listMarkerPattern.ReplaceAllString(line, "$1")
+	line = imagePattern.ReplaceAllString(line, "")
+	line = inlineLinkPattern.ReplaceAllString(line, "$1")
+	line = referenceLinkPattern.ReplaceAllString(line, "$1")
+	line = autolinkPattern.ReplaceAllString(line, "$1")
+	line = htmlTagPattern.ReplaceAllString(line, "")
+	line = inlineCodePattern.ReplaceAllString(line, "$1")
+	line = strongPattern.ReplaceAllString(line, "$2")
+	line = emphasisPattern.ReplaceAllString(line, "$1$2$3")
+	line = strikethroughPattern.ReplaceAllString(line, "$1")
+
+	if strings.Contains(line, "|") && strings.HasPrefix(strings.TrimSpace(line), "|") {
+		cells := strings.Split(strings.Trim(strings.TrimSpace(line), "|"), "|")
+		for i, cell := range cells {
+			cells[i] = strings.TrimSpace(cell)
+		}
+		line = strings.Join(cells, " ")
+	}
+	return strings.TrimRight(line, " \t")
+}
@@ -0,0 +1,40 @@
+package documents
+
+import "testing"
+
+func TestPlainText(t *testing.T) {
+	markdown := "# Getting *started* #\n\n" +
+		"Read the [guide](https://example.com/guide) or the [API][api] docs.\n" +
+		"![Logo](https://example.com/logo.png)Visit <https://example.com>.\n\n" +
+		"> **Note:** keep `snake_case_names` and __bold__ ~~old~~ text.\n\n" +
+		"- first item\n" +
+		"1. second item\n" +
+		"- [x] done item\n\n" +
+		"---\n\n" +
+		"| Name | Value |\n|------|:-----:|\n| a | 1 |\n\n" +
+		"```go\nfmt.Println(\"hi\")\n```\n\n\n\n" +
+		"Line with <b>html</b>.\n\n" +
+		"[api]: https://example.com/api\n"
+
+	want := "Getting started\n\n" +
+		"Read the guide or the API docs.\n" +
+		"Visit https://example.com.\n\n" +
+		"Note: keep snake_case_names and bold old text.\n\n" +
+		"first item\n" +
+		"second item\n" +
+		"done item\n\n" +
+		"Name Value\na 1\n\n" +
+		"Line with html."
+
+	if got := PlainText(markdown, PlainTextOptions{}); got != want {
+		t.Errorf("unexpected plain text:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestPlainText_KeepCodeBlocks(t *testing.T) {
+	markdown := "Example:\n\n```go\nfmt.Println(\"hi\")\n```"
+
+	if got := PlainText(markdown, PlainTextOptions{KeepCodeBlocks: true}); got != "Example:\n\nfmt.Println(\"hi\")" {
+		t.Errorf("unexpected plain text %q", got)
+	}
+}
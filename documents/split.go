@@ -0,0 +1,173 @@
+package documents
+
+import (
+	"maps"
+	"strings"
+)
+
+// DefaultMaxTokens is the token budget of a chunk when SplitOptions.MaxTokens is not set
+const DefaultMaxTokens = 512
+
+// Metadata keys set on the chunks produced by SplitMarkdown
+const (
+	// MetadataHeadings is the path of headings, outermost first, of the section a chunk belongs to
+	MetadataHeadings = "headings"
+	// MetadataChunk is the position of a chunk among the chunks of its document
+	MetadataChunk = "chunk"
+)
+
+// SplitOptions customizes SplitMarkdown
+type SplitOptions struct {
+	// MaxTokens is the maximum number of tokens of a chunk, DefaultMaxTokens when zero
+	MaxTokens int
+	// CountTokens counts the tokens of a text, EstimateTokens when nil. Pass the tokenizer of the embedding model
+	// for exact budgets.
+	CountTokens func(string) int
+}
+
+// EstimateTokens approximates the number of tokens of s, assuming about four characters per token
+func EstimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
+
+// SplitMarkdown splits the markdown content of doc, such as a scraped or crawled page, into chunks that fit the
+// token budget. Chunks never span two sections; within a section, paragraphs and code blocks are kept whole when
+// they fit. Every chunk carries the metadata of doc along with its heading path and position.
+func SplitMarkdown(doc Document, opts SplitOptions) []Document {
+	maxTokens := opts.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = DefaultMaxTokens
+	}
+	count := opts.CountTokens
+	if count == nil {
+		count = EstimateTokens
+	}
+
+	var chunks []Document
+	for _, section := range markdownSections(doc.Content) {
+		for _, text := range packBlocks(section.blocks, maxTokens, count) {
+			metadata := maps.Clone(doc.Metadata)
+			if metadata == nil {
+				metadata = make(map[string]any)
+			}
+			metadata[MetadataHeadings] = section.headings
+			metadata[MetadataChunk] = len(chunks)
+			chunks = append(chunks, Document{Content: text, Metadata: metadata})
+		}
+	}
+	return chunks
+}
+
+// markdownSection is the content under a heading, split into paragraphs and code blocks
+type markdownSection struct {
+	headings []string
+	blocks   []string
+}
+
+// markdownSections splits markdown into sections at every ATX heading outside of code fences
+func markdownSections(markdown string) []markdownSection {
+	var sections []markdownSection
+	current := markdownSection{headings: []string{}}
+	var block []string
+	inFence := false
+
+	flushBlock := func() {
+		if text := strings.TrimSpace(strings.Join(block, "\n")); text != "" {
+			current.blocks = append(current.blocks, text)
+		}
+		block = nil
+	}
+	flushSection := func() {
+		flushBlock()
+		if len(current.blocks) > 0 {
+			sections = append(sections, current)
+		}
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			if !inFence {
+				flushBlock()
+			}
+			block = append(block, line)
+			if inFence {
+				flushBlock()
+			}
+			inFence = !inFence
+		case inFence:
+			block = append(block, line)
+		case trimmed == "":
+			flushBlock()
+		default:
+			if level, title, ok := parseHeading(trimmed); ok {
+				flushSection()
+				headings := append([]string{}, current.headings[:min(level-1, len(current.headings))]...)
+				current = markdownSection{headings: append(headings, title)}
+				continue
+			}
+			block = append(block, line)
+		}
+	}
+	flushSection()
+	return sections
+}
+
+// parseHeading returns the level and title of an ATX heading line
+func parseHeading(line string) (int, string, bool) {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(line) && line[level] != ' ') {
+		return 0, "", false
+	}
+	title := strings.TrimSpace(strings.TrimRight(strings.TrimSpace(line[level:]), "#"))
+	return level, title, true
+}
+
+// packBlocks groups consecutive blocks into texts of at most maxTokens, splitting blocks that are too large on
+// word boundaries
+func packBlocks(blocks []string, maxTokens int, count func(string) int) []string {
+	var texts []string
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			texts = append(texts, strings.Join(current, "\n\n"))
+			current = nil
+		}
+	}
+
+	for _, block := range blocks {
+		if count(block) > maxTokens {
+			flush()
+			texts = append(texts, splitWords(block, maxTokens, count)...)
+			continue
+		}
+		if len(current) > 0 && count(strings.Join(append(current, block), "\n\n")) > maxTokens {
+			flush()
+		}
+		current = append(current, block)
+	}
+	flush()
+	return texts
+}
+
+// splitWords splits text into pieces of at most maxTokens on word boundaries. A single word larger than the budget
+// is kept whole.
+func splitWords(text string, maxTokens int, count func(string) int) []string {
+	var pieces []string
+	var current []string
+	for _, word := range strings.Fields(text) {
+		if len(current) > 0 && count(strings.Join(append(current, word), " ")) > maxTokens {
+			pieces = append(pieces, strings.Join(current, " "))
+			current = nil
+		}
+		current = append(current, word)
+	}
+	if len(current) > 0 {
+		pieces = append(pieces, strings.Join(current, " "))
+	}
+	return pieces
+}
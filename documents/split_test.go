@@ -0,0 +1,75 @@
+package documents
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/petros0/supadata-go"
+)
+
+func wordCount(s string) int {
+	return len(strings.Fields(s))
+}
+
+func TestSplitMarkdown_Sections(t *testing.T) {
+	page := supadata.CrawlPage{
+		Url: "https://example.com/docs",
+		Content: "Intro text.\n\n" +
+			"# Guide\n\nGuide text.\n\n" +
+			"## Install\n\nRun the installer.\n\n```sh\n# not a heading\ngo get example.com\n```\n\n" +
+			"## Usage ##\n\nCall it.\n\n" +
+			"# Reference\n\nSee the API.",
+	}
+
+	chunks := SplitMarkdown(FromCrawlPage(page), SplitOptions{})
+
+	want := []struct {
+		headings []string
+		content  string
+	}{
+		{[]string{}, "Intro text."},
+		{[]string{"Guide"}, "Guide text."},
+		{[]string{"Guide", "Install"}, "Run the installer.\n\n```sh\n# not a heading\ngo get example.com\n```"},
+		{[]string{"Guide", "Usage"}, "Call it."},
+		{[]string{"Reference"}, "See the API."},
+	}
+	if len(chunks) != len(want) {
+		t.Fatalf("expected %d chunks, got %d: %+v", len(want), len(chunks), chunks)
+	}
+	for i, chunk := range chunks {
+		if chunk.Content != want[i].content {
+			t.Errorf("chunk %d: expected content %q, got %q", i, want[i].content, chunk.Content)
+		}
+		if headings, _ := chunk.Metadata[MetadataHeadings].([]string); !slices.Equal(headings, want[i].headings) {
+			t.Errorf("chunk %d: expected headings %v, got %v", i, want[i].headings, headings)
+		}
+		if chunk.Metadata[MetadataSource] != page.Url || chunk.Metadata[MetadataChunk] != i {
+			t.Errorf("chunk %d: unexpected metadata %v", i, chunk.Metadata)
+		}
+	}
+}
+
+func TestSplitMarkdown_TokenBudget(t *testing.T) {
+	doc := Document{Content: "# Title\n\none two three\n\nfour five\n\nsix seven eight nine ten eleven"}
+
+	chunks := SplitMarkdown(doc, SplitOptions{MaxTokens: 5, CountTokens: wordCount})
+
+	var contents []string
+	for _, chunk := range chunks {
+		if n := wordCount(chunk.Content); n > 5 {
+			t.Errorf("chunk %q has %d tokens", chunk.Content, n)
+		}
+		contents = append(contents, chunk.Content)
+	}
+	want := []string{"one two three\n\nfour five", "six seven eight nine ten", "eleven"}
+	if !slices.Equal(contents, want) {
+		t.Errorf("expected %q, got %q", want, contents)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	if EstimateTokens("") != 0 || EstimateTokens("abcd") != 1 || EstimateTokens("abcde") != 2 {
+		t.Error("unexpected token estimates")
+	}
+}
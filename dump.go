@@ -0,0 +1,79 @@
+package supadata
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+)
+
+// WithHTTPDump writes every request sent to the API and its response to w, in the wire format of
+// httputil.DumpRequestOut and httputil.DumpResponse, with the API key, credential headers and sensitive query
+// parameters redacted. Intended for debugging.
+func WithHTTPDump(w io.Writer) ConfigOption {
+	return func(config *Config) {
+		config.dump = &httpDump{w: w}
+	}
+}
+
+// httpDump serializes the dumps of concurrent requests
+type httpDump struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// roundTrip sends req with the HTTP client, dumping the exchange when WithHTTPDump is set
+func (s *Supadata) roundTrip(req *http.Request) (*http.Response, error) {
+	d := s.config.dump
+	if d == nil {
+		resp, err := s.config.client.Do(req)
+		return resp, redactTransportError(err)
+	}
+
+	reqDump, err := dumpRequest(req)
+	if err != nil {
+		reqDump = fmt.Appendf(nil, "%s %s (dump failed: %v)\r\n", req.Method, redactURL(req.URL), err)
+	}
+	resp, err := s.config.client.Do(req)
+	err = redactTransportError(err)
+	var respDump []byte
+	if err != nil {
+		respDump = fmt.Appendf(nil, "error: %v\r\n", err)
+	} else if respDump, err = dumpResponse(resp); err != nil {
+		respDump = fmt.Appendf(nil, "%s (dump failed: %v)\r\n", resp.Status, err)
+	}
+
+	d.mu.Lock()
+	_, _ = io.WriteString(d.w, s.Redact(string(reqDump)))
+	_, _ = io.WriteString(d.w, "\r\n")
+	_, _ = io.WriteString(d.w, s.Redact(string(respDump)))
+	_, _ = io.WriteString(d.w, "\r\n\r\n")
+	d.mu.Unlock()
+	return resp, err
+}
+
+// dumpRequest dumps a copy of req whose sensitive headers and query parameters are redacted, leaving req untouched
+func dumpRequest(req *http.Request) ([]byte, error) {
+	clone := req.Clone(req.Context())
+	clone.Header = redactHeader(clone.Header)
+	clone.URL = redactURL(clone.URL)
+	clone.Body = nil
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+	return httputil.DumpRequestOut(clone, clone.Body != nil)
+}
+
+// dumpResponse dumps resp with its sensitive headers redacted, leaving its body readable
+func dumpResponse(resp *http.Response) ([]byte, error) {
+	redacted := *resp
+	redacted.Header = redactHeader(resp.Header)
+	dump, err := httputil.DumpResponse(&redacted, true)
+	resp.Body = redacted.Body
+	return dump, err
+}
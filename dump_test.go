@@ -0,0 +1,45 @@
+package supadata
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithHTTPDump(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-key") != "sd_secret" {
+			t.Errorf("expected the real API key to be sent, got %q", r.Header.Get("x-api-key"))
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"jobId": "job-1"})
+	}))
+	defer server.Close()
+
+	var dump bytes.Buffer
+	client := NewSupadata(WithAPIKey("sd_secret"), WithBaseURL(server.URL), WithHTTPDump(&dump))
+	job, err := client.Crawl(&CrawlBody{Url: "https://example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.JobId != "job-1" {
+		t.Errorf("expected the response body to be left readable, got %q", job.JobId)
+	}
+
+	out := dump.String()
+	for _, want := range []string{
+		"POST /web/crawl HTTP/1.1",
+		"X-Api-Key: REDACTED",
+		`"url":"https://example.com"`,
+		"HTTP/1.1 200 OK",
+		`"jobId":"job-1"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected dump to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "sd_secret") {
+		t.Error("expected the API key to be redacted")
+	}
+}
@@ -0,0 +1,196 @@
+package supadata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// RequestError wraps every error returned by a call to the API with the method and endpoint of the call, so that an
+// error such as a context deadline reported deep inside a pipeline still tells which call failed. Use errors.As or
+// errors.Is to inspect the underlying error, e.g. an *ErrorResponse.
+type RequestError struct {
+	Method   string
+	Endpoint string
+	Err      error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("supadata: %s %s: %v", e.Method, e.Endpoint, e.Err)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// requestError wraps err with the method and endpoint of req, unless it is nil or already wrapped
+func (s *Supadata) requestError(req *http.Request, err error) error {
+	var reqErr *RequestError
+	if err == nil || errors.As(err, &reqErr) {
+		return err
+	}
+	return &RequestError{Method: req.Method, Endpoint: s.endpointPath(req), Err: err}
+}
+
+// ContentTypeError is returned, wrapped in a *RequestError, when a response is an HTML page instead of JSON, typically
+// the error page of a proxy or a CDN in front of the API. Snippet holds the start of the body.
+type ContentTypeError struct {
+	StatusCode  int
+	ContentType string
+	Snippet     string
+}
+
+func (e *ContentTypeError) Error() string {
+	return fmt.Sprintf("expected a JSON response, got %q with status %d, likely from a proxy or a CDN in front of the API (body: %q)",
+		e.ContentType, e.StatusCode, e.Snippet)
+}
+
+// checkContentType returns a *ContentTypeError when resp is an HTML page, body being its start. Other non-JSON
+// content types are left to the decoding, which reports a *DecodeError when the body is not JSON after all.
+func (s *Supadata) checkContentType(resp *http.Response, body []byte) error {
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || (mediaType != "text/html" && mediaType != "application/xhtml+xml") {
+		return nil
+	}
+	return &ContentTypeError{StatusCode: resp.StatusCode, ContentType: contentType, Snippet: s.Redact(bodySnippet(body, nil))}
+}
+
+// maxSnippetLength bounds the length of the body snippet of a DecodeError
+const maxSnippetLength = 200
+
+// DecodeError is returned, wrapped in a *RequestError, when a successful response cannot be decoded into its result
+// type, e.g. after a change of the API. Snippet holds the part of the body around the decoding failure.
+type DecodeError struct {
+	Endpoint string
+	// Type is the Go type the body was decoded into, e.g. "supadata.YouTubeVideo"
+	Type    string
+	Snippet string
+	Err     error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decoding %s: %v (body: %q)", e.Type, e.Err, e.Snippet)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decode unmarshals the body of a successful response to req into v, returning a *DecodeError when it fails
+func (s *Supadata) decode(req *http.Request, body []byte, v any) error {
+	err := s.config.jsonUnmarshal(body, v)
+	if err == nil {
+		return nil
+	}
+	return s.requestError(req, &DecodeError{
+		Endpoint: s.endpointPath(req),
+		Type:     reflect.TypeOf(v).Elem().String(),
+		Snippet:  s.Redact(bodySnippet(body, err)),
+		Err:      err,
+	})
+}
+
+// bodySnippet returns at most maxSnippetLength bytes of body, centered on the offset of the decoding failure when
+// err reports one, with an ellipsis marking the cut parts
+func bodySnippet(body []byte, err error) string {
+	if len(body) <= maxSnippetLength {
+		return string(body)
+	}
+
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	}
+	start := max(0, min(int(offset)-maxSnippetLength/2, len(body)-maxSnippetLength))
+	end := start + maxSnippetLength
+
+	snippet := strings.ToValidUTF8(string(body[start:end]), "")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(body) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// errorHints maps the known error identifiers to actionable guidance
+var errorHints = map[ErrorIdentifier]string{
+	InvalidRequest:        "Check the request parameters, e.g. that the URL or ID is well-formed and the language code is valid.",
+	InternalError:         "The API failed to process the request; retry it later and contact support if it keeps failing.",
+	Forbidden:             "The API key is not allowed to call this endpoint; check the scopes of the key in the dashboard.",
+	Unauthorized:          "Check that the API key is set, e.g. with WithAPIKey or SUPADATA_API_KEY, and has not been revoked.",
+	UpgradeRequired:       "This feature is not included in the current plan; upgrade the plan to use it.",
+	TranscriptUnavailable: "The video has no transcript in the requested language; try another language or the generate mode.",
+	NotFound:              "The resource does not exist or is private; check the URL or ID.",
+	LimitExceeded:         "The rate limit or credit quota was exceeded; retry after the limit resets or upgrade the plan.",
+}
+
+// Hint returns actionable guidance for the error, followed by its documentation link when the API provided one.
+// It returns an empty string for an unknown identifier without documentation.
+func (e *ErrorResponse) Hint() string {
+	hint := errorHints[e.ErrorIdentifier]
+	if e.DocumentationUrl == "" {
+		return hint
+	}
+	if hint == "" {
+		return "See " + e.DocumentationUrl
+	}
+	return hint + " See " + e.DocumentationUrl
+}
+
+// HasErrorIdentifier reports whether an *ErrorResponse with one of ids is found in the chain of err
+func HasErrorIdentifier(err error, ids ...ErrorIdentifier) bool {
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		return false
+	}
+	for _, id := range ids {
+		if errResp.ErrorIdentifier == id {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether err was caused by the API not finding the requested resource
+func IsNotFound(err error) bool {
+	return HasErrorIdentifier(err, NotFound)
+}
+
+// IsUnauthorized reports whether err was caused by a missing or invalid API key
+func IsUnauthorized(err error) bool {
+	return HasErrorIdentifier(err, Unauthorized)
+}
+
+// IsRateLimited reports whether err was caused by the API rejecting a request over the rate limit or credit quota,
+// including a bare 429 status, e.g. from a proxy or gateway in front of the API
+func IsRateLimited(err error) bool {
+	return HasErrorIdentifier(err, LimitExceeded) || errorStatusCode(err) == http.StatusTooManyRequests
+}
+
+// errorStatusCode returns the HTTP status of the failed response found in the chain of err, or zero
+func errorStatusCode(err error) int {
+	var errResp *ErrorResponse
+	var statusErr *RetryableStatusError
+	var contentTypeErr *ContentTypeError
+	switch {
+	case errors.As(err, &errResp):
+		return errResp.StatusCode
+	case errors.As(err, &statusErr):
+		return statusErr.StatusCode
+	case errors.As(err, &contentTypeErr):
+		return contentTypeErr.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,181 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequestError_WrapsTransportErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	client := newTestClient(server)
+	_, err := client.YouTubeTranscript(&YouTubeTranscriptParams{VideoId: "abc"}, WithContext(ctx))
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("expected *RequestError, got %T: %v", err, err)
+	}
+	if reqErr.Method != http.MethodGet || reqErr.Endpoint != "/youtube/transcript" {
+		t.Errorf("unexpected endpoint %s %s", reqErr.Method, reqErr.Endpoint)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to be kept in the chain, got %v", err)
+	}
+}
+
+func TestRequestError_WrapsDecodeErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{invalid json"))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.YouTubeVideo("abc")
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) || reqErr.Endpoint != "/youtube/video" {
+		t.Fatalf("expected a *RequestError for /youtube/video, got %v", err)
+	}
+	if !strings.HasPrefix(err.Error(), "supadata: GET /youtube/video: ") {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
+
+func TestErrorPredicates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("id") {
+		case "missing":
+			errorResponse(w, http.StatusNotFound, NotFound, "Not found", "")
+		case "limited":
+			errorResponse(w, http.StatusTooManyRequests, LimitExceeded, "Too many requests", "")
+		default:
+			errorResponse(w, http.StatusUnauthorized, Unauthorized, "Invalid API key", "")
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, notFound := client.YouTubeVideo("missing")
+	_, limited := client.YouTubeVideo("limited")
+	_, unauthorized := client.YouTubeVideo("video")
+
+	if !IsNotFound(notFound) || IsNotFound(limited) {
+		t.Errorf("unexpected IsNotFound results")
+	}
+	if !IsRateLimited(limited) || IsRateLimited(unauthorized) {
+		t.Errorf("unexpected IsRateLimited results")
+	}
+	if !IsUnauthorized(unauthorized) || IsUnauthorized(notFound) {
+		t.Errorf("unexpected IsUnauthorized results")
+	}
+	if IsNotFound(nil) || IsNotFound(errors.New("not-found")) {
+		t.Error("expected errors without an *ErrorResponse not to match")
+	}
+	if !HasErrorIdentifier(limited, NotFound, LimitExceeded) {
+		t.Error("expected any of the identifiers to match")
+	}
+}
+
+func TestIsRateLimited_BareStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") == "json" {
+			jsonResponse(w, http.StatusTooManyRequests, map[string]any{"message": "Slow down"})
+			return
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	for _, id := range []string{"json", "empty"} {
+		if _, err := client.YouTubeVideo(id); !IsRateLimited(err) {
+			t.Errorf("%s: expected a 429 to be reported as rate limited, got %v", id, err)
+		}
+	}
+	if IsRateLimited(&RequestError{Err: &ErrorResponse{StatusCode: http.StatusServiceUnavailable}}) {
+		t.Error("expected a 503 not to be reported as rate limited")
+	}
+}
+
+func TestErrorResponse_Hint(t *testing.T) {
+	for _, id := range []ErrorIdentifier{InvalidRequest, InternalError, Forbidden, Unauthorized, UpgradeRequired, TranscriptUnavailable, NotFound, LimitExceeded} {
+		if (&ErrorResponse{ErrorIdentifier: id}).Hint() == "" {
+			t.Errorf("expected a hint for %s", id)
+		}
+	}
+
+	errResp := &ErrorResponse{ErrorIdentifier: Unauthorized, DocumentationUrl: "https://docs.supadata.ai/errors"}
+	if hint := errResp.Hint(); !strings.HasSuffix(hint, " See https://docs.supadata.ai/errors") {
+		t.Errorf("expected the documentation link to be appended, got %q", hint)
+	}
+	if hint := (&ErrorResponse{ErrorIdentifier: "unknown"}).Hint(); hint != "" {
+		t.Errorf("expected no hint for an unknown identifier, got %q", hint)
+	}
+	if hint := (&ErrorResponse{ErrorIdentifier: "unknown", DocumentationUrl: "https://docs"}).Hint(); hint != "See https://docs" {
+		t.Errorf("expected only the documentation link, got %q", hint)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		padding := strings.Repeat(`"x",`, 100)
+		_, _ = w.Write([]byte(`{"id": "dQw4w9WgXcQ", "tags": [` + padding + `"y"], "duration": "long"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.YouTubeVideo("dQw4w9WgXcQ")
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected *DecodeError, got %T: %v", err, err)
+	}
+	if decodeErr.Endpoint != "/youtube/video" || decodeErr.Type != "supadata.YouTubeVideo" {
+		t.Errorf("unexpected error %+v", decodeErr)
+	}
+	if !strings.Contains(decodeErr.Snippet, `"duration": "long"`) || !strings.HasPrefix(decodeErr.Snippet, "…") {
+		t.Errorf("expected the snippet to show the failing field, got %q", decodeErr.Snippet)
+	}
+	if len(decodeErr.Snippet) > maxSnippetLength+len("…") {
+		t.Errorf("expected the snippet to be truncated, got %d bytes", len(decodeErr.Snippet))
+	}
+	if !strings.HasPrefix(err.Error(), "supadata: GET /youtube/video: decoding supadata.YouTubeVideo: ") {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
+
+func TestContentTypeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if accept := r.Header.Get("Accept"); accept != "application/json" {
+			t.Errorf("expected JSON to be accepted, got %q", accept)
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("<html><body><h1>502 Bad Gateway</h1></body></html>"))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.YouTubeVideo("dQw4w9WgXcQ")
+	var contentTypeErr *ContentTypeError
+	if !errors.As(err, &contentTypeErr) {
+		t.Fatalf("expected *ContentTypeError, got %T: %v", err, err)
+	}
+	if contentTypeErr.StatusCode != http.StatusBadGateway || !strings.Contains(contentTypeErr.Snippet, "502 Bad Gateway") {
+		t.Errorf("unexpected error %+v", contentTypeErr)
+	}
+
+	for item, err := range client.YouTubeBatchItems(context.Background(), "job-1") {
+		if !errors.As(err, &contentTypeErr) {
+			t.Errorf("expected *ContentTypeError when streaming, got %+v, %v", item, err)
+		}
+	}
+}
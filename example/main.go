@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -68,19 +69,19 @@ func handleError(err error) {
 		if apiErr.Details != "" {
 			fmt.Printf("Details: %s\n", apiErr.Details)
 		}
-		if apiErr.DocumentationUrl != "" {
-			fmt.Printf("Documentation: %s\n", apiErr.DocumentationUrl)
+		if hint := apiErr.Hint(); hint != "" {
+			fmt.Printf("Hint: %s\n", hint)
 		}
 
 		// Handle specific error types
-		switch apiErr.ErrorIdentifier {
-		case supadata.Unauthorized:
+		switch {
+		case supadata.IsUnauthorized(err):
 			fmt.Println("Check your API key")
-		case supadata.LimitExceeded:
+		case supadata.IsRateLimited(err):
 			fmt.Println("Rate limit exceeded, please wait before retrying")
-		case supadata.TranscriptUnavailable:
+		case supadata.HasErrorIdentifier(err, supadata.TranscriptUnavailable):
 			fmt.Println("Transcript not available for this content")
-		case supadata.NotFound:
+		case supadata.IsNotFound(err):
 			fmt.Println("Resource not found")
 		}
 	} else {
@@ -143,43 +144,40 @@ func transcriptExample(client *supadata.Supadata) {
 	fmt.Println()
 }
 
-// pollTranscript demonstrates the TranscriptResult endpoint for async jobs
+// pollTranscript demonstrates waiting for an async transcript job with WaitForTranscript
 func pollTranscript(client *supadata.Supadata, jobId string) {
-	fmt.Printf("Polling for transcript job: %s\n", jobId)
-
-	for {
-		result, err := client.TranscriptResult(jobId)
-		if err != nil {
-			handleError(err)
-			return
-		}
+	fmt.Printf("Waiting for transcript job: %s\n", jobId)
+
+	// Poll every second at first, backing off up to 10 seconds, and give up after 5 minutes
+	result, err := client.WaitForTranscript(context.Background(), jobId, supadata.WithPollOptions(supadata.PollOptions{
+		InitialInterval: time.Second,
+		MaxInterval:     10 * time.Second,
+		MaxWait:         5 * time.Minute,
+	}))
+	if err != nil {
+		handleError(err)
+		return
+	}
 
-		fmt.Printf("Status: %s\n", result.Status)
-
-		switch result.Status {
-		case supadata.Completed:
-			fmt.Printf("Transcript completed!\n")
-			fmt.Printf("Language: %s\n", result.Lang)
-			fmt.Printf("Segments: %d\n", len(result.Content))
-			for i, segment := range result.Content {
-				if i >= 3 {
-					fmt.Println("...")
-					break
-				}
-				fmt.Printf("  [%.2fs] %s\n", segment.Offset, segment.Text)
-			}
-			return
+	fmt.Printf("Status: %s\n", result.Status)
 
-		case supadata.Failed:
-			fmt.Println("Transcript job failed")
-			if result.Error != nil {
-				fmt.Printf("Error: %s - %s\n", result.Error.ErrorIdentifier, result.Error.Message)
+	switch result.Status {
+	case supadata.Completed:
+		fmt.Printf("Transcript completed!\n")
+		fmt.Printf("Language: %s\n", result.Lang)
+		fmt.Printf("Segments: %d\n", len(result.Content))
+		for i, segment := range result.Content {
+			if i >= 3 {
+				fmt.Println("...")
+				break
 			}
-			return
+			fmt.Printf("  [%.2fs] %s\n", segment.Offset, segment.Text)
+		}
 
-		case supadata.Queued, supadata.Active:
-			fmt.Println("Still processing, waiting 2 seconds...")
-			time.Sleep(2 * time.Second)
+	case supadata.Failed:
+		fmt.Println("Transcript job failed")
+		if result.Error != nil {
+			fmt.Printf("Error: %s - %s\n", result.Error.ErrorIdentifier, result.Error.Message)
 		}
 	}
 }
@@ -304,40 +302,33 @@ func crawlExample(client *supadata.Supadata) {
 
 	fmt.Printf("Crawl job started with ID: %s\n", job.JobId)
 
-	// Poll for results
-	for {
-		result, err := client.CrawlResult(job.JobId, 0)
-		if err != nil {
-			handleError(err)
-			return
-		}
+	// Wait for the crawl, polling with the default backoff for at most 10 minutes
+	result, err := client.WaitForCrawl(context.Background(), job.JobId, supadata.WithPollOptions(supadata.PollOptions{
+		MaxWait: 10 * time.Minute,
+	}))
+	if err != nil {
+		handleError(err)
+		return
+	}
 
-		fmt.Printf("Status: %s\n", result.Status)
-
-		switch result.Status {
-		case supadata.CrawlCompleted:
-			fmt.Printf("Crawl completed! Found %d pages\n", len(result.Pages))
-			for i, page := range result.Pages {
-				if i >= 5 {
-					fmt.Printf("  ... and %d more pages\n", len(result.Pages)-5)
-					break
-				}
-				fmt.Printf("  - %s (%d chars)\n", page.Name, page.CountCharacters)
-			}
-			return
+	fmt.Printf("Status: %s\n", result.Status)
 
-		case supadata.CrawlFailed:
-			fmt.Println("Crawl job failed")
-			return
+	switch result.Status {
+	case supadata.CrawlCompleted:
+		fmt.Printf("Crawl completed! Found %d pages\n", len(result.Pages))
+		for i, page := range result.Pages {
+			if i >= 5 {
+				fmt.Printf("  ... and %d more pages\n", len(result.Pages)-5)
+				break
+			}
+			fmt.Printf("  - %s (%d chars)\n", page.Name, page.CountCharacters)
+		}
 
-		case supadata.Cancelled:
-			fmt.Println("Crawl job was cancelled")
-			return
+	case supadata.CrawlFailed:
+		fmt.Println("Crawl job failed")
 
-		case supadata.Scraping:
-			fmt.Println("Still crawling, waiting 5 seconds...")
-			time.Sleep(5 * time.Second)
-		}
+	case supadata.Cancelled:
+		fmt.Println("Crawl job was cancelled")
 	}
 }
 
@@ -504,48 +495,42 @@ func youtubeTranscriptTranslateExample(client *supadata.Supadata) {
 	fmt.Println()
 }
 
-// pollYouTubeBatch demonstrates polling for YouTube batch job results
+// pollYouTubeBatch demonstrates waiting for a YouTube batch job with WaitForYouTubeBatch
 func pollYouTubeBatch(client *supadata.Supadata, jobId string) {
-	fmt.Printf("Polling for batch job: %s\n", jobId)
+	fmt.Printf("Waiting for batch job: %s\n", jobId)
 
-	for {
-		result, err := client.YouTubeBatchResult(jobId)
-		if err != nil {
-			handleError(err)
-			return
-		}
+	result, err := client.WaitForYouTubeBatch(context.Background(), jobId, supadata.WithPollOptions(supadata.PollOptions{
+		MaxWait: 10 * time.Minute,
+	}))
+	if err != nil {
+		handleError(err)
+		return
+	}
 
-		fmt.Printf("Status: %s\n", result.Status)
-
-		switch result.Status {
-		case supadata.BatchCompleted:
-			fmt.Printf("Batch completed!\n")
-			fmt.Printf("Stats: %d total, %d succeeded, %d failed\n",
-				result.Stats.Total, result.Stats.Succeeded, result.Stats.Failed)
-
-			for i, item := range result.Results {
-				if i >= 5 {
-					fmt.Printf("  ... and %d more\n", len(result.Results)-5)
-					break
-				}
-				if item.ErrorCode != "" {
-					fmt.Printf("  - %s: ERROR (%s)\n", item.VideoId, item.ErrorCode)
-				} else if item.Video != nil {
-					fmt.Printf("  - %s: %s\n", item.VideoId, item.Video.Title)
-				} else if item.Transcript != nil {
-					fmt.Printf("  - %s: %d segments\n", item.VideoId, len(item.Transcript.Content))
-				}
-			}
-			return
+	fmt.Printf("Status: %s\n", result.Status)
 
-		case supadata.BatchFailed:
-			fmt.Println("Batch job failed")
-			return
+	switch result.Status {
+	case supadata.BatchCompleted:
+		fmt.Printf("Batch completed!\n")
+		fmt.Printf("Stats: %d total, %d succeeded, %d failed\n",
+			result.Stats.Total, result.Stats.Succeeded, result.Stats.Failed)
 
-		case supadata.BatchQueued, supadata.BatchActive:
-			fmt.Println("Still processing, waiting 2 seconds...")
-			time.Sleep(2 * time.Second)
+		for i, item := range result.Results {
+			if i >= 5 {
+				fmt.Printf("  ... and %d more\n", len(result.Results)-5)
+				break
+			}
+			if item.ErrorCode != "" {
+				fmt.Printf("  - %s: ERROR (%s)\n", item.VideoId, item.ErrorCode)
+			} else if item.Video != nil {
+				fmt.Printf("  - %s: %s\n", item.VideoId, item.Video.Title)
+			} else if item.Transcript != nil {
+				fmt.Printf("  - %s: %d segments\n", item.VideoId, len(item.Transcript.Content))
+			}
 		}
+
+	case supadata.BatchFailed:
+		fmt.Println("Batch job failed")
 	}
 }
 
@@ -0,0 +1,302 @@
+package supadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxFilenameLength bounds the length of generated file names, leaving room for suffixes and extensions
+const maxFilenameLength = 200
+
+var unsafeFilenameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// CrawlExportOptions configures ExportCrawlToDir
+type CrawlExportOptions struct {
+	// Overwrite replaces existing files instead of failing
+	Overwrite bool
+}
+
+// ExportCrawl writes every page of a completed crawl job to sink and returns the number of pages written.
+// The sink is closed once all pages are written.
+func (s *Supadata) ExportCrawl(ctx context.Context, jobId string, sink OutputSink) (int, error) {
+	ctx = ensureLineage(ctx)
+	written := 0
+	for page, err := range s.CrawlPages(ctx, jobId) {
+		if err != nil {
+			return written, errors.Join(err, sink.Close())
+		}
+		if err := sink.WritePage(&page); err != nil {
+			return written, errors.Join(err, sink.Close())
+		}
+		written++
+	}
+	return written, sink.Close()
+}
+
+// ExportBatch writes every result of a batch job to sink as it is decoded, transcripts with WriteTranscript and
+// videos with WriteVideo, and returns the number of results written. Failed results are skipped. The sink is closed
+// once all results are written.
+func (s *Supadata) ExportBatch(ctx context.Context, jobId string, sink OutputSink) (int, error) {
+	ctx = ensureLineage(ctx)
+	written := 0
+	for item, err := range s.YouTubeBatchItems(ctx, jobId) {
+		if err != nil {
+			return written, errors.Join(err, sink.Close())
+		}
+		switch {
+		case item.Transcript != nil:
+			err = sink.WriteTranscript(item.VideoId, item.Transcript)
+		case item.Video != nil:
+			err = sink.WriteVideo(item.Video)
+		default:
+			continue
+		}
+		if err != nil {
+			return written, errors.Join(err, sink.Close())
+		}
+		written++
+	}
+	return written, sink.Close()
+}
+
+// ExportCrawlToDir writes every page of a completed crawl job to dir as a markdown file with front-matter
+// holding the page URL, title and description. It returns the paths of the written files.
+func (s *Supadata) ExportCrawlToDir(ctx context.Context, jobId, dir string, opts *CrawlExportOptions) ([]string, error) {
+	if opts == nil {
+		opts = &CrawlExportOptions{}
+	}
+	sink, err := NewDirSink(dir, opts.Overwrite)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.ExportCrawl(ctx, jobId, sink)
+	return sink.Files(), err
+}
+
+// DirSink writes every record to its own file in a directory: pages as markdown with front-matter,
+// transcripts as plain text and videos as JSON. It is safe for concurrent use.
+type DirSink struct {
+	dir   string
+	flags int
+
+	mu    sync.Mutex
+	used  map[string]bool
+	files []string
+}
+
+// NewDirSink creates dir when missing and returns a sink writing to it. Existing files are replaced when
+// overwrite is set, otherwise writing to them fails.
+func NewDirSink(dir string, overwrite bool) (*DirSink, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	return &DirSink{dir: dir, flags: flags, used: make(map[string]bool)}, nil
+}
+
+// Files returns the paths of the files written so far
+func (d *DirSink) Files() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.files...)
+}
+
+func (d *DirSink) write(name, ext string, data []byte) error {
+	d.mu.Lock()
+	path := filepath.Join(d.dir, uniqueFilename(d.used, name, ext))
+	d.mu.Unlock()
+
+	if err := writeFile(path, d.flags, data); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.files = append(d.files, path)
+	d.mu.Unlock()
+	return nil
+}
+
+func (d *DirSink) WriteTranscript(videoId string, transcript *YouTubeTranscriptResult) error {
+	return d.write(transcriptFilename(videoId, transcript), ".txt", []byte(joinTranscript(transcript.Content)+"\n"))
+}
+
+func (d *DirSink) WritePage(page *CrawlPage) error {
+	return d.write(pageFilename(page.Url), ".md", pageMarkdown(page))
+}
+
+func (d *DirSink) WriteVideo(video *YouTubeVideo) error {
+	data, err := json.MarshalIndent(video, "", "  ")
+	if err != nil {
+		return err
+	}
+	return d.write(sanitizeFilename(video.Id), ".json", append(data, '\n'))
+}
+
+func (d *DirSink) Close() error {
+	return nil
+}
+
+// transcriptFilename derives a file name without extension from a video ID and the language of its transcript
+func transcriptFilename(videoId string, transcript *YouTubeTranscriptResult) string {
+	name := sanitizeFilename(videoId)
+	if transcript.Lang != "" {
+		name += "." + sanitizeFilename(transcript.Lang)
+	}
+	return name
+}
+
+// pageFilename derives a file name without extension from a page URL
+func pageFilename(pageUrl string) string {
+	name := pageUrl
+	if u, err := url.Parse(pageUrl); err == nil && u.Host != "" {
+		name = u.Host + strings.TrimSuffix(u.Path, "/")
+		if u.RawQuery != "" {
+			name += "_" + u.RawQuery
+		}
+	}
+	return sanitizeFilename(name)
+}
+
+// sanitizeFilename replaces characters unsafe in file names and bounds the result length
+func sanitizeFilename(name string) string {
+	name = strings.Trim(unsafeFilenameChars.ReplaceAllString(name, "_"), "._-")
+	if len(name) > maxFilenameLength {
+		name = name[:maxFilenameLength]
+	}
+	if name == "" {
+		name = "index"
+	}
+	return name
+}
+
+// uniqueFilename appends a numeric suffix to name until it has not been used yet
+func uniqueFilename(used map[string]bool, name, ext string) string {
+	candidate := name + ext
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s-%d%s", name, i, ext)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// pageMarkdown renders a crawl page as markdown with a YAML front-matter block
+func pageMarkdown(page *CrawlPage) []byte {
+	var b strings.Builder
+	b.WriteString("---\n")
+	writeFrontMatter(&b, "url", page.Url)
+	writeFrontMatter(&b, "title", page.Name)
+	writeFrontMatter(&b, "description", page.Description)
+	b.WriteString("---\n\n")
+	b.WriteString(page.Content)
+	if !strings.HasSuffix(page.Content, "\n") {
+		b.WriteString("\n")
+	}
+	return []byte(b.String())
+}
+
+// writeFrontMatter writes a front-matter entry, quoting the value as a JSON string which is valid YAML
+func writeFrontMatter(b *strings.Builder, key, value string) {
+	quoted, _ := json.Marshal(value)
+	fmt.Fprintf(b, "%s: %s\n", key, quoted)
+}
+
+func writeFile(path string, flags int, data []byte) error {
+	f, err := os.OpenFile(filepath.Clean(path), flags, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ManifestFilename is the name of the manifest written by ExportPlaylistTranscripts
+const ManifestFilename = "manifest.json"
+
+// TranscriptManifest describes the files written by ExportPlaylistTranscripts
+type TranscriptManifest struct {
+	PlaylistId string                    `json:"playlistId"`
+	Format     TranscriptFormat          `json:"format"`
+	ExportedAt time.Time                 `json:"exportedAt"`
+	Videos     []TranscriptManifestEntry `json:"videos"`
+}
+
+// TranscriptManifestEntry is a video of an exported playlist, in the playlist order
+type TranscriptManifestEntry struct {
+	VideoId string `json:"videoId"`
+	// File is the name of the transcript file in the export directory, empty when the transcript was not retrieved
+	File string `json:"file,omitempty"`
+	Lang string `json:"lang,omitempty"`
+	// Error is set when the transcript was not retrieved
+	Error string `json:"error,omitempty"`
+}
+
+// ExportPlaylistTranscripts retrieves the transcripts of every video of a YouTube playlist like PlaylistTranscripts
+// and writes them to dir, one file per video named after its ID, plus a manifest listing the videos and their files
+// in ManifestFilename. Existing files are replaced. Videos whose transcript could not be retrieved are reported in
+// the manifest and in the returned error, alongside the manifest of the transcripts that were written.
+func (s *Supadata) ExportPlaylistTranscripts(ctx context.Context, playlistId, dir string, format TranscriptFormat) (*TranscriptManifest, error) {
+	if !format.valid() {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	ctx = ensureLineage(ctx)
+	var videoIds []string
+	for videoId, err := range s.AllPlaylistVideoIds(ctx, playlistId) {
+		if err != nil {
+			return nil, err
+		}
+		videoIds = append(videoIds, videoId)
+	}
+	transcripts, fetchErr := s.collectTranscripts(ctx, videoIds, "", nil)
+	if transcripts == nil {
+		return nil, fetchErr
+	}
+
+	manifest := &TranscriptManifest{PlaylistId: playlistId, Format: format, ExportedAt: time.Now().UTC()}
+	used := make(map[string]bool, len(videoIds)+1)
+	used[ManifestFilename] = true
+	errs := []error{fetchErr}
+	for _, videoId := range videoIds {
+		entry := TranscriptManifestEntry{VideoId: videoId}
+		if transcript, ok := transcripts[videoId]; ok {
+			entry.Lang = transcript.Lang
+			entry.File = uniqueFilename(used, sanitizeFilename(videoId), format.Ext())
+			var b strings.Builder
+			err := WriteTranscript(&b, transcript, format)
+			if err == nil {
+				err = writeFile(filepath.Join(dir, entry.File), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, []byte(b.String()))
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("video %s: %w", videoId, err))
+				entry.File, entry.Error = "", err.Error()
+			}
+		} else {
+			entry.Error = "transcript not retrieved"
+		}
+		manifest.Videos = append(manifest.Videos, entry)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err == nil {
+		err = writeFile(filepath.Join(dir, ManifestFilename), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, append(data, '\n'))
+	}
+	return manifest, errors.Join(append(errs, err)...)
+}
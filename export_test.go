@@ -0,0 +1,159 @@
+package supadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportCrawlToDir(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"status": "completed",
+			"pages": []map[string]any{
+				{"url": "https://example.com/", "name": "Home", "description": "Welcome \"home\"", "content": "# Home"},
+				{"url": "https://example.com/docs/intro/", "name": "Intro", "content": "# Intro\n"},
+				{"url": "https://example.com/docs/intro", "name": "Intro again", "content": "dup"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	client := newTestClient(server)
+	files, err := client.ExportCrawlToDir(context.Background(), "job-1", dir, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"example.com.md", "example.com_docs_intro.md", "example.com_docs_intro-2.md"}
+	if len(files) != len(expected) {
+		t.Fatalf("expected %d files, got %v", len(expected), files)
+	}
+	for i, name := range expected {
+		if files[i] != filepath.Join(dir, name) {
+			t.Errorf("expected file %q, got %q", name, files[i])
+		}
+	}
+
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	want := "---\nurl: \"https://example.com/\"\ntitle: \"Home\"\ndescription: \"Welcome \\\"home\\\"\"\n---\n\n# Home\n"
+	if string(data) != want {
+		t.Errorf("unexpected file content:\n%s", data)
+	}
+}
+
+func TestExportCrawlToDir_Overwrite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"status": "completed",
+			"pages":  []map[string]any{{"url": "https://example.com/a", "content": "A"}},
+		})
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	client := newTestClient(server)
+	if _, err := client.ExportCrawlToDir(context.Background(), "job-1", dir, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.ExportCrawlToDir(context.Background(), "job-1", dir, nil); err == nil {
+		t.Fatal("expected error when file already exists")
+	}
+	if _, err := client.ExportCrawlToDir(context.Background(), "job-1", dir, &CrawlExportOptions{Overwrite: true}); err != nil {
+		t.Fatalf("unexpected error with overwrite: %v", err)
+	}
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := map[string]string{
+		"example.com/a b/c?d": "example.com_a_b_c_d",
+		"../../etc/passwd":    "etc_passwd",
+		"":                    "index",
+		"...":                 "index",
+	}
+	for input, expected := range tests {
+		if got := sanitizeFilename(input); got != expected {
+			t.Errorf("sanitizeFilename(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestExportPlaylistTranscripts(t *testing.T) {
+	server := playlistTranscriptsServer(t, "Free")
+	defer server.Close()
+
+	dir := t.TempDir()
+	client := newTestClient(server)
+	manifest, err := client.ExportPlaylistTranscripts(context.Background(), "PLxyz123", dir, FormatSRT)
+	if err == nil {
+		t.Fatal("expected the missing transcript to be reported")
+	}
+	if manifest == nil || len(manifest.Videos) != 3 {
+		t.Fatalf("expected 3 videos in the manifest, got %+v", manifest)
+	}
+	if entry := manifest.Videos[0]; entry.VideoId != "a" || entry.File != "a.srt" || entry.Lang != "en" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	if entry := manifest.Videos[2]; entry.VideoId != "missing" || entry.File != "" || entry.Error == "" {
+		t.Errorf("expected the missing transcript to be recorded, got %+v", entry)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "b.srt"))
+	if err != nil {
+		t.Fatalf("failed to read transcript: %v", err)
+	}
+	if string(data) != "1\n00:00:00,000 --> 00:00:00,000\nb\n\n" {
+		t.Errorf("unexpected transcript file %q", data)
+	}
+	var written TranscriptManifest
+	data, err = os.ReadFile(filepath.Join(dir, ManifestFilename))
+	if err != nil || json.Unmarshal(data, &written) != nil || len(written.Videos) != 3 {
+		t.Errorf("unexpected manifest file %q: %v", data, err)
+	}
+
+	if _, err := client.ExportPlaylistTranscripts(context.Background(), "PLxyz123", dir, "docx"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
+
+func TestExportBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"status": "completed",
+			"results": []map[string]any{
+				{"videoId": "dQw4w9WgXcQ", "transcript": map[string]any{"lang": "en", "content": []map[string]any{{"text": "Hello"}}}},
+				{"videoId": "missing-vid", "errorCode": "transcript-unavailable"},
+				{"videoId": "jNQXAC9IVRw", "video": map[string]any{"id": "jNQXAC9IVRw"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "batch.jsonl")
+	sink, err := NewJSONLFileSink(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := newTestClient(server)
+	written, err := client.ExportBatch(context.Background(), "job-1", sink)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if written != 2 {
+		t.Errorf("expected 2 results written, got %d", written)
+	}
+	data, _ := os.ReadFile(path)
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("expected 2 lines, got %q", data)
+	}
+}
@@ -0,0 +1,118 @@
+package supadata
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// failoverThreshold is the number of consecutive 5xx responses after which the client switches to the next base URL
+const failoverThreshold = 3
+
+// WithBaseURLs sets the base URL of the API along with fallbacks used when it is unreachable. On a connection
+// error, the request is retried on the next base URLs in order; after failoverThreshold consecutive 5xx
+// responses, subsequent requests are sent to the next base URL. The last base URL that worked stays in use.
+func WithBaseURLs(primary string, fallbacks ...string) ConfigOption {
+	return func(config *Config) {
+		config.baseURL = primary
+		config.fallbackURLs = append([]string(nil), fallbacks...)
+	}
+}
+
+// failover tracks which base URL requests are sent to
+type failover struct {
+	mu       sync.Mutex
+	bases    []string
+	active   int
+	failures int
+}
+
+func newFailover(primary string, fallbacks []string) *failover {
+	return &failover{bases: append([]string{primary}, fallbacks...)}
+}
+
+// current returns the index and value of the base URL requests are sent to
+func (f *failover) current() (int, string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.active, f.bases[f.active]
+}
+
+// succeeded makes base the active base URL and resets the failure count
+func (f *failover) succeeded(base int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.active = base
+	f.failures = 0
+}
+
+// serverError records a 5xx response from base, switching to the next base URL once the threshold is reached
+func (f *failover) serverError(base int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if base != f.active {
+		return
+	}
+	f.failures++
+	if f.failures >= failoverThreshold {
+		f.active = (f.active + 1) % len(f.bases)
+		f.failures = 0
+	}
+}
+
+// doFailover sends req to the active base URL, retrying on the other base URLs when the connection fails
+func (s *Supadata) doFailover(req *http.Request) (*http.Response, error) {
+	if s.failover == nil {
+		return s.roundTrip(req)
+	}
+
+	endpoint := s.endpointPath(req)
+	first, _ := s.failover.current()
+	var lastErr error
+	for attempt := range len(s.failover.bases) {
+		index := (first + attempt) % len(s.failover.bases)
+		attemptReq, err := rebaseRequest(req, s.failover.bases[index], endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := s.roundTrip(attemptReq)
+		if err != nil {
+			lastErr = err
+			if req.Context().Err() != nil {
+				return nil, err
+			}
+			continue
+		}
+		if resp.StatusCode >= 500 {
+			s.failover.serverError(index)
+		} else {
+			s.failover.succeeded(index)
+		}
+		return resp, nil
+	}
+	return nil, lastErr
+}
+
+// rebaseRequest returns a copy of req sent to endpoint on base, with a fresh body
+func rebaseRequest(req *http.Request, base, endpoint string) (*http.Request, error) {
+	target, err := url.JoinPath(base, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = req.URL.RawQuery
+
+	clone := req.Clone(req.Context())
+	clone.URL = u
+	clone.Host = ""
+	if req.GetBody != nil {
+		if clone.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	return clone, nil
+}
@@ -0,0 +1,74 @@
+package supadata
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithBaseURLs_ConnectionError(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	down.Close()
+
+	requests := 0
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var body CrawlBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Url != "https://example.com" {
+			t.Errorf("expected the request body to be replayed, got %+v (%v)", body, err)
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"jobId": "job-1"})
+	}))
+	defer fallback.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURLs(down.URL, fallback.URL))
+	for i := 0; i < 2; i++ {
+		job, err := client.Crawl(&CrawlBody{Url: "https://example.com"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if job.JobId != "job-1" {
+			t.Errorf("expected job-1, got %s", job.JobId)
+		}
+	}
+
+	if active, _ := client.failover.current(); active != 1 {
+		t.Errorf("expected the fallback to stay active, got %d", active)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests to the fallback, got %d", requests)
+	}
+}
+
+func TestWithBaseURLs_SustainedServerErrors(t *testing.T) {
+	primaryRequests := 0
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryRequests++
+		errorResponse(w, http.StatusBadGateway, InternalError, "Bad gateway", "")
+	}))
+	defer primary.Close()
+
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/youtube/video" || r.URL.Query().Get("id") != "abc" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "abc"})
+	}))
+	defer fallback.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURLs(primary.URL, fallback.URL))
+	for i := 0; i < failoverThreshold; i++ {
+		if _, err := client.YouTubeVideo("abc"); err == nil {
+			t.Fatal("expected an error from the primary")
+		}
+	}
+
+	video, err := client.YouTubeVideo("abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if video.Id != "abc" || primaryRequests != failoverThreshold {
+		t.Errorf("expected the fallback to be used after %d failures, got %d", failoverThreshold, primaryRequests)
+	}
+}
@@ -0,0 +1,82 @@
+package supadata
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultFetchConcurrency is the number of transcripts fetched at the same time by FetchTranscripts by default
+const DefaultFetchConcurrency = 4
+
+// FetchTranscriptsOptions customizes FetchTranscripts
+type FetchTranscriptsOptions struct {
+	// Concurrency is the number of workers fetching transcripts, DefaultFetchConcurrency when zero
+	Concurrency int
+	Lang        Lang
+	Text        *bool
+	// Progress, when set, is called after every fetched transcript with the number of videos done so far
+	Progress func(done, total int)
+}
+
+// VideoTranscript is the outcome of fetching the transcript of a single video
+type VideoTranscript struct {
+	VideoId    string
+	Transcript *YouTubeTranscriptResult
+	Err        error
+}
+
+// FetchTranscripts fetches the transcripts of many YouTube videos through the synchronous transcript endpoint using a
+// bounded pool of workers, as an alternative to transcript batches. Requests respect the client rate limiter.
+// The results are returned in the order of videoIds, with failures reported on their VideoTranscript.
+func (s *Supadata) FetchTranscripts(ctx context.Context, videoIds []string, opts *FetchTranscriptsOptions) []VideoTranscript {
+	ctx, stop := s.bindClose(ensureLineage(ctx))
+	defer stop()
+	if opts == nil {
+		opts = &FetchTranscriptsOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultFetchConcurrency
+	}
+
+	results := make([]VideoTranscript, len(videoIds))
+	indexes := make(chan int)
+	var mu sync.Mutex
+	done := 0
+
+	var wg sync.WaitGroup
+	for range min(concurrency, len(videoIds)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i].VideoId = videoIds[i]
+				if err := ctx.Err(); err != nil {
+					results[i].Err = interrupted(ctx, err)
+				} else {
+					results[i].Transcript, results[i].Err = s.YouTubeTranscript(&YouTubeTranscriptParams{
+						VideoId: videoIds[i],
+						Lang:    opts.Lang,
+						Text:    opts.Text,
+					}, WithContext(ctx))
+					results[i].Err = interrupted(ctx, results[i].Err)
+				}
+
+				if opts.Progress != nil {
+					mu.Lock()
+					done++
+					opts.Progress(done, len(videoIds))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := range videoIds {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,92 @@
+package supadata
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFetchTranscripts(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		videoId := r.URL.Query().Get("videoId")
+		if videoId == "missing" {
+			errorResponse(w, http.StatusNotFound, NotFound, "Not found", "")
+			return
+		}
+		if r.URL.Query().Get("lang") != "en" {
+			t.Errorf("expected lang en, got %q", r.URL.Query().Get("lang"))
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"lang":    "en",
+			"content": []map[string]any{{"text": "transcript of " + videoId}},
+		})
+	}))
+	defer server.Close()
+
+	videoIds := []string{"a", "b", "missing", "c", "d"}
+	var progress []int
+	client := newTestClient(server)
+	results := client.FetchTranscripts(context.Background(), videoIds, &FetchTranscriptsOptions{
+		Concurrency: 2,
+		Lang:        "en",
+		Progress: func(done, total int) {
+			if total != len(videoIds) {
+				t.Errorf("expected total %d, got %d", len(videoIds), total)
+			}
+			progress = append(progress, done)
+		},
+	})
+
+	if len(results) != len(videoIds) {
+		t.Fatalf("expected %d results, got %d", len(videoIds), len(results))
+	}
+	for i, result := range results {
+		if result.VideoId != videoIds[i] {
+			t.Errorf("expected result %d for %s, got %s", i, videoIds[i], result.VideoId)
+		}
+		if result.VideoId == "missing" {
+			if result.Err == nil {
+				t.Error("expected an error for the missing video")
+			}
+			continue
+		}
+		if result.Err != nil || result.Transcript == nil {
+			t.Errorf("unexpected result for %s: %+v", result.VideoId, result)
+		}
+	}
+	if len(progress) != len(videoIds) || progress[len(progress)-1] != len(videoIds) {
+		t.Errorf("unexpected progress %v", progress)
+	}
+	if maxInFlight.Load() > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", maxInFlight.Load())
+	}
+}
+
+func TestFetchTranscripts_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := newTestClient(server)
+	for _, result := range client.FetchTranscripts(ctx, []string{"a", "b"}, nil) {
+		if result.Err != context.Canceled {
+			t.Errorf("expected context.Canceled for %s, got %v", result.VideoId, result.Err)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Package fixtures provides realistic sample payloads of the Supadata API, both as the raw JSON bodies and as the
+// decoded supadata values, so that tests share the same data instead of inventing fake payloads that drift from
+// the API. Every call returns a fresh copy that the caller may modify.
+package fixtures
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/petros0/supadata-go"
+)
+
+//go:embed data
+var data embed.FS
+
+// Names of the sample payloads accepted by JSON
+const (
+	// TranscriptJSON is a synchronous response of the universal transcript endpoint
+	TranscriptJSON = "transcript.json"
+	// TranscriptJobJSON is an asynchronous response of the universal transcript endpoint
+	TranscriptJobJSON = "transcript_job.json"
+	// TranscriptResultJSON is a completed transcript job
+	TranscriptResultJSON = "transcript_result.json"
+	// TranscriptResultFailedJSON is a failed transcript job
+	TranscriptResultFailedJSON = "transcript_result_failed.json"
+	// YouTubeTranscriptJSON is a response of the YouTube transcript endpoint
+	YouTubeTranscriptJSON = "youtube_transcript.json"
+	// CrawlJobJSON is a response of the crawl endpoint
+	CrawlJobJSON = "crawl_job.json"
+	// CrawlResultJSON is the first page of a completed crawl, followed by another page
+	CrawlResultJSON = "crawl_result.json"
+	// CrawlResultScrapingJSON is a crawl still running
+	CrawlResultScrapingJSON = "crawl_result_scraping.json"
+	// BatchJobJSON is a response of the YouTube batch endpoints
+	BatchJobJSON = "batch_job.json"
+	// BatchResultJSON is a completed transcript batch with two transcripts and a failed video
+	BatchResultJSON = "batch_result.json"
+)
+
+// ErrorIdentifiers lists every error identifier with a sample error payload
+var ErrorIdentifiers = []supadata.ErrorIdentifier{
+	supadata.InvalidRequest,
+	supadata.InternalError,
+	supadata.Forbidden,
+	supadata.Unauthorized,
+	supadata.UpgradeRequired,
+	supadata.TranscriptUnavailable,
+	supadata.NotFound,
+	supadata.LimitExceeded,
+}
+
+// errorStatuses is the HTTP status the API answers with for every error identifier
+var errorStatuses = map[supadata.ErrorIdentifier]int{
+	supadata.InvalidRequest:        http.StatusBadRequest,
+	supadata.InternalError:         http.StatusInternalServerError,
+	supadata.Forbidden:             http.StatusForbidden,
+	supadata.Unauthorized:          http.StatusUnauthorized,
+	supadata.UpgradeRequired:       http.StatusPaymentRequired,
+	supadata.TranscriptUnavailable: http.StatusNotFound,
+	supadata.NotFound:              http.StatusNotFound,
+	supadata.LimitExceeded:         http.StatusTooManyRequests,
+}
+
+// JSON returns the sample payload called name, one of the *JSON constants. It panics when no such payload exists.
+func JSON(name string) []byte {
+	body, err := data.ReadFile(path.Join("data", name))
+	if err != nil {
+		panic(fmt.Sprintf("fixtures: unknown payload %q", name))
+	}
+	return body
+}
+
+// ErrorJSON returns the sample error payload of id. It panics for an identifier missing from ErrorIdentifiers.
+func ErrorJSON(id supadata.ErrorIdentifier) []byte {
+	return JSON(path.Join("errors", string(id)+".json"))
+}
+
+// ErrorStatus returns the HTTP status the API answers with along with the error payload of id
+func ErrorStatus(id supadata.ErrorIdentifier) int {
+	if status, ok := errorStatuses[id]; ok {
+		return status
+	}
+	return http.StatusBadRequest
+}
+
+// decode decodes the sample payload called name
+func decode[T any](name string) *T {
+	var v T
+	if err := json.Unmarshal(JSON(name), &v); err != nil {
+		panic(fmt.Sprintf("fixtures: decoding %s: %v", name, err))
+	}
+	return &v
+}
+
+// Transcript returns the decoded TranscriptJSON
+func Transcript() *supadata.SyncTranscript {
+	return decode[supadata.SyncTranscript](TranscriptJSON)
+}
+
+// TranscriptJob returns the decoded TranscriptJobJSON
+func TranscriptJob() *supadata.AsyncTranscript {
+	return decode[supadata.AsyncTranscript](TranscriptJobJSON)
+}
+
+// TranscriptResult returns the decoded TranscriptResultJSON
+func TranscriptResult() *supadata.TranscriptResult {
+	return decode[supadata.TranscriptResult](TranscriptResultJSON)
+}
+
+// TranscriptResultFailed returns the decoded TranscriptResultFailedJSON
+func TranscriptResultFailed() *supadata.TranscriptResult {
+	return decode[supadata.TranscriptResult](TranscriptResultFailedJSON)
+}
+
+// YouTubeTranscript returns the decoded YouTubeTranscriptJSON
+func YouTubeTranscript() *supadata.YouTubeTranscriptResult {
+	return decode[supadata.YouTubeTranscriptResult](YouTubeTranscriptJSON)
+}
+
+// CrawlJob returns the decoded CrawlJobJSON
+func CrawlJob() *supadata.CrawlJob {
+	return decode[supadata.CrawlJob](CrawlJobJSON)
+}
+
+// CrawlResult returns the decoded CrawlResultJSON
+func CrawlResult() *supadata.CrawlResult {
+	return decode[supadata.CrawlResult](CrawlResultJSON)
+}
+
+// CrawlResultScraping returns the decoded CrawlResultScrapingJSON
+func CrawlResultScraping() *supadata.CrawlResult {
+	return decode[supadata.CrawlResult](CrawlResultScrapingJSON)
+}
+
+// BatchJob returns the decoded BatchJobJSON
+func BatchJob() *supadata.YouTubeBatchJob {
+	return decode[supadata.YouTubeBatchJob](BatchJobJSON)
+}
+
+// BatchResult returns the decoded BatchResultJSON
+func BatchResult() *supadata.YouTubeBatchResult {
+	return decode[supadata.YouTubeBatchResult](BatchResultJSON)
+}
+
+// Error returns the decoded error payload of id
+func Error(id supadata.ErrorIdentifier) *supadata.ErrorResponse {
+	var errResp supadata.ErrorResponse
+	if err := json.Unmarshal(ErrorJSON(id), &errResp); err != nil {
+		panic(fmt.Sprintf("fixtures: decoding error %s: %v", id, err))
+	}
+	return &errResp
+}
+
+// WriteError writes the error payload of id to w with its HTTP status, e.g. in an httptest handler
+func WriteError(w http.ResponseWriter, id supadata.ErrorIdentifier) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(ErrorStatus(id))
+	_, _ = w.Write(ErrorJSON(id))
+}
+
+// WriteJSON writes the sample payload called name to w with a 200 status, e.g. in an httptest handler
+func WriteJSON(w http.ResponseWriter, name string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(JSON(name))
+}
@@ -0,0 +1,82 @@
+package fixtures
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/petros0/supadata-go"
+)
+
+func TestPayloadsDecode(t *testing.T) {
+	if transcript := Transcript(); len(transcript.Content) == 0 || transcript.Lang == "" {
+		t.Errorf("unexpected transcript %+v", transcript)
+	}
+	if job := TranscriptJob(); job.JobId == "" {
+		t.Error("expected a transcript job ID")
+	}
+	if result := TranscriptResult(); result.Status != supadata.Completed || len(result.Content) == 0 {
+		t.Errorf("unexpected transcript result %+v", result)
+	}
+	if result := TranscriptResultFailed(); result.Status != supadata.Failed || result.Error == nil {
+		t.Errorf("unexpected failed transcript result %+v", result)
+	}
+	if transcript := YouTubeTranscript(); len(transcript.Content) == 0 {
+		t.Errorf("unexpected YouTube transcript %+v", transcript)
+	}
+	if job := CrawlJob(); job.JobId == "" {
+		t.Error("expected a crawl job ID")
+	}
+	if result := CrawlResult(); result.Status != supadata.CrawlCompleted || len(result.Pages) != 2 || result.Next == "" {
+		t.Errorf("unexpected crawl result %+v", result)
+	}
+	if result := CrawlResultScraping(); result.Status != supadata.Scraping {
+		t.Errorf("unexpected running crawl %+v", result)
+	}
+	if job := BatchJob(); job.JobId == "" {
+		t.Error("expected a batch job ID")
+	}
+	if result := BatchResult(); result.Stats.Total != len(result.Results) || result.Stats.Failed != 1 {
+		t.Errorf("unexpected batch result %+v", result)
+	}
+}
+
+func TestCopiesAreIndependent(t *testing.T) {
+	first := Transcript()
+	first.Content[0].Text = "changed"
+	if Transcript().Content[0].Text == "changed" {
+		t.Error("expected every call to return a fresh copy")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, id := range ErrorIdentifiers {
+		t.Run(string(id), func(t *testing.T) {
+			if errResp := Error(id); errResp.ErrorIdentifier != id || errResp.Message == "" || errResp.DocumentationUrl == "" {
+				t.Errorf("unexpected error payload %+v", errResp)
+			}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				WriteError(w, id)
+			}))
+			defer server.Close()
+
+			client := supadata.NewSupadata(supadata.WithAPIKey("test-api-key"), supadata.WithBaseURL(server.URL))
+			_, err := client.YouTubeVideo("dQw4w9WgXcQ")
+			var errResp *supadata.ErrorResponse
+			if !errors.As(err, &errResp) || errResp.ErrorIdentifier != id {
+				t.Errorf("expected the client to report %s, got %v", id, err)
+			}
+		})
+	}
+}
+
+func TestJSON_UnknownPayload(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an unknown payload")
+		}
+	}()
+	JSON("missing.json")
+}
@@ -0,0 +1,90 @@
+package supadata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TranscriptFormat is a file format transcripts are written in
+type TranscriptFormat string
+
+const (
+	// FormatSRT writes SubRip subtitles
+	FormatSRT TranscriptFormat = "srt"
+	// FormatVTT writes WebVTT subtitles
+	FormatVTT TranscriptFormat = "vtt"
+	// FormatTXT writes the text of the transcript on a single line
+	FormatTXT TranscriptFormat = "txt"
+	// FormatJSON writes the transcript as returned by the API
+	FormatJSON TranscriptFormat = "json"
+)
+
+// ErrUnsupportedFormat is returned when a transcript is written in an unknown format
+var ErrUnsupportedFormat = errors.New("unsupported transcript format")
+
+// valid reports whether the format is one of the supported formats
+func (f TranscriptFormat) valid() bool {
+	switch f {
+	case FormatSRT, FormatVTT, FormatTXT, FormatJSON:
+		return true
+	}
+	return false
+}
+
+// Ext returns the file extension of the format, including the dot
+func (f TranscriptFormat) Ext() string {
+	return "." + string(f)
+}
+
+// WriteTranscript writes transcript to w in format. Subtitle cues are timed with the segment offsets and durations,
+// in milliseconds.
+func WriteTranscript(w io.Writer, transcript *YouTubeTranscriptResult, format TranscriptFormat) error {
+	var data string
+	switch format {
+	case FormatSRT:
+		data = subtitles(transcript.Content, "", ",", true)
+	case FormatVTT:
+		data = subtitles(transcript.Content, "WEBVTT\n\n", ".", false)
+	case FormatTXT:
+		data = joinTranscript(transcript.Content) + "\n"
+	case FormatJSON:
+		encoded, err := json.MarshalIndent(transcript, "", "  ")
+		if err != nil {
+			return err
+		}
+		data = string(encoded) + "\n"
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+	_, err := io.WriteString(w, data)
+	return err
+}
+
+// subtitles renders the segments as subtitle cues, numbered when numbered is set, the milliseconds of the timestamps
+// following msSeparator
+func subtitles(content []TranscriptContent, header, msSeparator string, numbered bool) string {
+	var b strings.Builder
+	b.WriteString(header)
+	for i, segment := range content {
+		if numbered {
+			fmt.Fprintf(&b, "%d\n", i+1)
+		}
+		fmt.Fprintf(&b, "%s --> %s\n%s\n\n",
+			timestamp(segment.Offset, msSeparator),
+			timestamp(segment.Offset+segment.Duration, msSeparator),
+			strings.TrimSpace(segment.Text))
+	}
+	return b.String()
+}
+
+// timestamp formats an offset in milliseconds as hours:minutes:seconds followed by the milliseconds
+func timestamp(ms float64, msSeparator string) string {
+	total := int64(ms + 0.5)
+	if total < 0 {
+		total = 0
+	}
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", total/3600000, total/60000%60, total/1000%60, msSeparator, total%1000)
+}
@@ -0,0 +1,44 @@
+package supadata
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWriteTranscript(t *testing.T) {
+	transcript := &YouTubeTranscriptResult{
+		Lang: "en",
+		Content: []TranscriptContent{
+			{Text: "We're no strangers to love", Offset: 18800, Duration: 2240},
+			{Text: "You know the rules", Offset: 3661001, Duration: 1500},
+		},
+	}
+
+	tests := []struct {
+		format   TranscriptFormat
+		expected string
+	}{
+		{FormatSRT, "1\n00:00:18,800 --> 00:00:21,040\nWe're no strangers to love\n\n2\n01:01:01,001 --> 01:01:02,501\nYou know the rules\n\n"},
+		{FormatVTT, "WEBVTT\n\n00:00:18.800 --> 00:00:21.040\nWe're no strangers to love\n\n01:01:01.001 --> 01:01:02.501\nYou know the rules\n\n"},
+		{FormatTXT, "We're no strangers to love You know the rules\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := WriteTranscript(&buf, transcript, tt.format); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.format, err)
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.format, tt.expected, buf.String())
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTranscript(&buf, transcript, FormatJSON); err != nil || !strings.Contains(buf.String(), `"offset": 18800`) {
+		t.Errorf("unexpected JSON %q: %v", buf.String(), err)
+	}
+	if err := WriteTranscript(&buf, transcript, "docx"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package supadata
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// hedgedEndpoints are the idempotent endpoints whose calls are hedged by WithHedgedRequests
+var hedgedEndpoints = map[string]bool{
+	"/metadata":      true,
+	"/youtube/video": true,
+}
+
+// WithHedgedRequests sends a second, identical request when a Metadata or YouTubeVideo call has not been answered
+// after delay, and returns whichever response arrives first, cutting the tail latency of interactive calls.
+// The slower request is cancelled. Both requests may be charged by the API. With WithMaxConcurrentRequests, the
+// second request takes a slot of its own and is not sent when none is free, so that the limit still holds.
+func WithHedgedRequests(delay time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.hedgeDelay = delay
+	}
+}
+
+// hedgeAttempt is the outcome of one of the requests of a hedged call
+type hedgeAttempt struct {
+	index int
+	resp  *http.Response
+	err   error
+}
+
+// cancelOnClose cancels the context of a request once its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	once   sync.Once
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.cancel)
+	return err
+}
+
+// sendHedged sends req, hedging it with a second request after the configured delay when its endpoint allows it
+func (s *Supadata) sendHedged(req *http.Request) (*http.Response, error) {
+	if s.config.hedgeDelay <= 0 || req.Method != http.MethodGet || !hedgedEndpoints[s.endpointPath(req)] {
+		return s.send(req)
+	}
+
+	attempts := make(chan hedgeAttempt, 2)
+	var cancels []context.CancelFunc
+	launch := func() {
+		ctx, cancel := context.WithCancel(req.Context())
+		index := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			resp, err := s.send(req.Clone(ctx))
+			attempts <- hedgeAttempt{index: index, resp: resp, err: err}
+		}()
+	}
+
+	// The hedges hold a slot of the concurrency limit each, released as soon as any of the requests ends so that
+	// the slot of the call itself is left to the response
+	hedgeSlots := 0
+	releaseSlot := func() {
+		if hedgeSlots > 0 {
+			hedgeSlots--
+			s.inflightLimit.release()
+		}
+	}
+
+	launch()
+	timer := time.NewTimer(s.config.hedgeDelay)
+	defer timer.Stop()
+
+	pending := 1
+	var firstErr error
+	for {
+		select {
+		case <-timer.C:
+			if s.inflightLimit != nil {
+				if !s.inflightLimit.tryAcquire() {
+					continue
+				}
+				hedgeSlots++
+			}
+			launch()
+			pending++
+			s.stats.hedged.Add(1)
+		case attempt := <-attempts:
+			pending--
+			if attempt.err == nil {
+				for i, cancel := range cancels {
+					if i != attempt.index {
+						cancel()
+					}
+				}
+				go discardAttempts(attempts, pending, releaseSlot)
+				attempt.resp.Body = &cancelOnClose{ReadCloser: attempt.resp.Body, cancel: cancels[attempt.index]}
+				return attempt.resp, nil
+			}
+
+			cancels[attempt.index]()
+			releaseSlot()
+			if firstErr == nil {
+				firstErr = attempt.err
+			}
+			// Without a hedge in flight there is nothing left to wait for
+			if pending == 0 {
+				return nil, firstErr
+			}
+		}
+	}
+}
+
+// discardAttempts closes the responses of the requests of a hedged call that lost the race, calling release once
+// each has ended
+func discardAttempts(attempts <-chan hedgeAttempt, pending int, release func()) {
+	for range pending {
+		if attempt := <-attempts; attempt.resp != nil {
+			attempt.resp.Body.Close()
+		}
+		release()
+	}
+}
@@ -0,0 +1,99 @@
+package supadata
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithHedgedRequests(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			// The first request stalls until the client gives up on it
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+				t.Error("expected the slow request to be cancelled")
+			}
+			return
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "hedged"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithHedgedRequests(10*time.Millisecond))
+	video, err := client.YouTubeVideo("video")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if video.Id != "hedged" {
+		t.Errorf("expected the response of the hedged request, got %q", video.Id)
+	}
+	if requests.Load() != 2 || client.Stats().HedgedRequests != 1 {
+		t.Errorf("expected a single hedge, got %d requests and stats %+v", requests.Load(), client.Stats())
+	}
+}
+
+func TestWithHedgedRequests_FastResponse(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "video"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithHedgedRequests(time.Second))
+	if _, err := client.YouTubeVideo("video"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.YouTubeChannel("channel"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests.Load() != 2 || client.Stats().HedgedRequests != 0 {
+		t.Errorf("expected no hedge, got %d requests", requests.Load())
+	}
+}
+
+func TestWithHedgedRequests_RespectsConcurrencyLimit(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(50 * time.Millisecond):
+			}
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "video"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL),
+		WithHedgedRequests(5*time.Millisecond), WithMaxConcurrentRequests(1))
+	if _, err := client.YouTubeVideo("video"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests.Load() != 1 || client.Stats().HedgedRequests != 0 {
+		t.Errorf("expected no hedge without a free slot, got %d requests", requests.Load())
+	}
+
+	requests.Store(0)
+	client = NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL),
+		WithHedgedRequests(5*time.Millisecond), WithMaxConcurrentRequests(2))
+	if _, err := client.YouTubeVideo("video"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.Stats().HedgedRequests != 1 {
+		t.Errorf("expected a hedge with a free slot, got %+v", client.Stats())
+	}
+	deadline := time.Now().Add(time.Second)
+	for len(client.inflightLimit) != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := len(client.inflightLimit); n != 0 {
+		t.Errorf("expected every slot to be released, got %d held", n)
+	}
+}
@@ -0,0 +1,196 @@
+package supadata
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrInvalidChannelRef is returned when a string is not a recognized YouTube channel reference
+	ErrInvalidChannelRef = errors.New("invalid YouTube channel reference")
+	// ErrInvalidVideoID is returned when a string is neither a YouTube video URL nor a video ID
+	ErrInvalidVideoID = errors.New("invalid YouTube video ID")
+	// ErrInvalidPlaylistID is returned when a string is neither a YouTube playlist URL nor a playlist ID
+	ErrInvalidPlaylistID = errors.New("invalid YouTube playlist ID")
+	// ErrInvalidJobId is returned when a job ID is empty or would change the endpoint it is sent to
+	ErrInvalidJobId = errors.New("invalid job ID")
+
+	channelIdPattern     = regexp.MustCompile(`^UC[A-Za-z0-9_-]{22}$`)
+	channelHandlePattern = regexp.MustCompile(`^@[\p{L}\p{M}\p{N}._-]{3,30}$`)
+	legacyNamePattern    = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+	videoIdPattern       = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	playlistIdPattern    = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// youTubeHosts are the hosts recognized in YouTube URLs
+var youTubeHosts = map[string]bool{
+	"youtube.com":       true,
+	"www.youtube.com":   true,
+	"m.youtube.com":     true,
+	"music.youtube.com": true,
+}
+
+// videoPathPrefixes are the first path segments of YouTube URLs followed by a video ID
+var videoPathPrefixes = map[string]bool{
+	"shorts": true,
+	"embed":  true,
+	"live":   true,
+	"v":      true,
+	"e":      true,
+}
+
+// ParseVideoID extracts the ID of a YouTube video from a watch, youtu.be, shorts, embed or live URL, ignoring the
+// other parameters such as the playlist or the timestamp, or returns a raw video ID as is
+func ParseVideoID(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if videoIdPattern.MatchString(s) {
+		return s, nil
+	}
+
+	u, ok := parseURL(s)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidVideoID, s)
+	}
+	host := strings.ToLower(u.Hostname())
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	var id string
+	switch {
+	case host == "youtu.be" || host == "www.youtu.be":
+		id = segments[0]
+	case youTubeHosts[host] && segments[0] == "watch":
+		id = u.Query().Get("v")
+	case (youTubeHosts[host] || host == "www.youtube-nocookie.com" || host == "youtube-nocookie.com") &&
+		len(segments) >= 2 && videoPathPrefixes[segments[0]]:
+		id = segments[1]
+	}
+	if !videoIdPattern.MatchString(id) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidVideoID, s)
+	}
+	return id, nil
+}
+
+// ParseChannelRef normalizes a YouTube channel reference given as a channel URL, an @handle, a legacy /c/ or
+// /user/ URL, or a raw channel ID. Channel IDs and handles are returned as is, legacy names as their canonical
+// channel URL since they cannot be resolved client-side.
+func ParseChannelRef(ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	switch {
+	case channelIdPattern.MatchString(ref), channelHandlePattern.MatchString(ref):
+		return ref, nil
+	case ref == "" || strings.HasPrefix(ref, "@"):
+		return "", fmt.Errorf("%w: %q", ErrInvalidChannelRef, ref)
+	}
+
+	u, ok := parseYouTubeURL(ref)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidChannelRef, ref)
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case channelHandlePattern.MatchString(segments[0]):
+		return segments[0], nil
+	case len(segments) >= 2 && segments[0] == "channel" && channelIdPattern.MatchString(segments[1]):
+		return segments[1], nil
+	case len(segments) >= 2 && (segments[0] == "c" || segments[0] == "user") && legacyNamePattern.MatchString(segments[1]):
+		return "https://www.youtube.com/" + segments[0] + "/" + segments[1], nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidChannelRef, ref)
+}
+
+// ParsePlaylistID extracts the ID of a YouTube playlist from the list parameter of a playlist, watch or youtu.be
+// URL, or returns a raw playlist ID as is
+func ParsePlaylistID(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if playlistIdPattern.MatchString(s) {
+		return s, nil
+	}
+
+	u, ok := parseURL(s)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPlaylistID, s)
+	}
+	host := strings.ToLower(u.Hostname())
+	if !youTubeHosts[host] && host != "youtu.be" && host != "www.youtu.be" {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPlaylistID, s)
+	}
+	if id := u.Query().Get("list"); playlistIdPattern.MatchString(id) {
+		return id, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidPlaylistID, s)
+}
+
+// resolveId returns the ID parse extracts from s, or s itself when parse does not recognize it but it is not clearly
+// malformed either, leaving the API to judge the IDs the client does not know about. Empty values and values holding
+// whitespace or URL delimiters are rejected with the error of parse.
+func resolveId(s string, parse func(string) (string, error)) (string, error) {
+	id, err := parse(s)
+	if err == nil {
+		return id, nil
+	}
+	if s == "" || strings.ContainsFunc(s, unicode.IsSpace) || strings.ContainsAny(s, `/\?#`) {
+		return "", err
+	}
+	return s, nil
+}
+
+// validateVideoId fails fast when an optional video ID parameter is set to a malformed ID
+func validateVideoId(videoId string) error {
+	if videoId == "" {
+		return nil
+	}
+	_, err := resolveId(videoId, ParseVideoID)
+	return err
+}
+
+// parseBatchSource resolves the video IDs and the playlist of a batch, either of which may be empty
+func parseBatchSource(videoIds []string, playlist string) ([]string, string, error) {
+	var parsed []string
+	if videoIds != nil {
+		parsed = make([]string, len(videoIds))
+	}
+	for i, videoId := range videoIds {
+		var err error
+		if parsed[i], err = resolveId(videoId, ParseVideoID); err != nil {
+			return nil, "", err
+		}
+	}
+	if playlist == "" {
+		return parsed, "", nil
+	}
+	playlistId, err := resolveId(playlist, ParsePlaylistID)
+	return parsed, playlistId, err
+}
+
+// jobPath returns the path of the job endpoint for jobId, escaping the ID as a single path segment
+func jobPath(endpoint, jobId string) (string, error) {
+	if jobId == "" || jobId == "." || jobId == ".." {
+		return "", fmt.Errorf("%w: %q", ErrInvalidJobId, jobId)
+	}
+	return endpoint + "/" + url.PathEscape(jobId), nil
+}
+
+// parseYouTubeURL parses s as a YouTube URL, tolerating a missing scheme
+func parseYouTubeURL(s string) (*url.URL, bool) {
+	u, ok := parseURL(s)
+	if !ok || !youTubeHosts[strings.ToLower(u.Hostname())] {
+		return nil, false
+	}
+	return u, true
+}
+
+// parseURL parses s as an absolute URL, tolerating a missing scheme
+func parseURL(s string) (*url.URL, bool) {
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return nil, false
+	}
+	return u, true
+}
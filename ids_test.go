@@ -0,0 +1,208 @@
+package supadata
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseChannelRef(t *testing.T) {
+	tests := []struct {
+		ref      string
+		expected string
+	}{
+		{"UCuAXFkgsw1L7xaCfnd5JJOw", "UCuAXFkgsw1L7xaCfnd5JJOw"},
+		{"@GoogleDevelopers", "@GoogleDevelopers"},
+		{"  @GoogleDevelopers ", "@GoogleDevelopers"},
+		{"https://www.youtube.com/@GoogleDevelopers", "@GoogleDevelopers"},
+		{"https://www.youtube.com/@GoogleDevelopers/videos", "@GoogleDevelopers"},
+		{"youtube.com/@GoogleDevelopers", "@GoogleDevelopers"},
+		{"@日本語チャンネル", "@日本語チャンネル"},
+		{"https://www.youtube.com/@%E6%97%A5%E6%9C%AC%E8%AA%9E%E3%83%81%E3%83%A3%E3%83%B3%E3%83%8D%E3%83%AB/videos", "@日本語チャンネル"},
+		{"https://m.youtube.com/channel/UCuAXFkgsw1L7xaCfnd5JJOw", "UCuAXFkgsw1L7xaCfnd5JJOw"},
+		{"https://www.youtube.com/channel/UCuAXFkgsw1L7xaCfnd5JJOw/featured?view=0", "UCuAXFkgsw1L7xaCfnd5JJOw"},
+		{"https://www.youtube.com/c/GoogleDevelopers", "https://www.youtube.com/c/GoogleDevelopers"},
+		{"http://youtube.com/user/GoogleDevelopers/videos", "https://www.youtube.com/user/GoogleDevelopers"},
+	}
+	for _, tt := range tests {
+		got, err := ParseChannelRef(tt.ref)
+		if err != nil {
+			t.Errorf("ParseChannelRef(%q) returned error: %v", tt.ref, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ParseChannelRef(%q) = %q, expected %q", tt.ref, got, tt.expected)
+		}
+	}
+}
+
+func TestParseChannelRef_Invalid(t *testing.T) {
+	for _, ref := range []string{
+		"",
+		"@",
+		"@a",
+		"GoogleDevelopers",
+		"https://example.com/@GoogleDevelopers",
+		"https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+		"https://www.youtube.com/channel/not-an-id",
+	} {
+		if _, err := ParseChannelRef(ref); !errors.Is(err, ErrInvalidChannelRef) {
+			t.Errorf("ParseChannelRef(%q) expected ErrInvalidChannelRef, got %v", ref, err)
+		}
+	}
+}
+
+func TestParseVideoID(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+		{" dQw4w9WgXcQ ", "dQw4w9WgXcQ"},
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+		{"https://www.youtube.com/watch?list=PLFgquLnL59alCl_2TQvOiD5Vgm1hCaGSI&v=dQw4w9WgXcQ&t=42s", "dQw4w9WgXcQ"},
+		{"youtube.com/watch?v=dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+		{"https://m.youtube.com/watch?v=dQw4w9WgXcQ&feature=share", "dQw4w9WgXcQ"},
+		{"https://music.youtube.com/watch?v=dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+		{"https://youtu.be/dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+		{"https://youtu.be/dQw4w9WgXcQ?si=abc&t=10", "dQw4w9WgXcQ"},
+		{"https://www.youtube.com/shorts/dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+		{"https://www.youtube.com/embed/dQw4w9WgXcQ?start=30", "dQw4w9WgXcQ"},
+		{"https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+		{"https://www.youtube.com/live/dQw4w9WgXcQ?feature=shared", "dQw4w9WgXcQ"},
+		{"https://www.youtube.com/v/dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+	}
+	for _, tt := range tests {
+		got, err := ParseVideoID(tt.input)
+		if err != nil {
+			t.Errorf("ParseVideoID(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ParseVideoID(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseVideoID_Invalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"dQw4w9WgXc",
+		"https://www.youtube.com/watch?list=PLFgquLnL59alCl_2TQvOiD5Vgm1hCaGSI",
+		"https://www.youtube.com/@GoogleDevelopers",
+		"https://vimeo.com/dQw4w9WgXcQ",
+		"https://example.com/watch?v=dQw4w9WgXcQ",
+		"https://youtu.be/",
+	} {
+		if _, err := ParseVideoID(input); !errors.Is(err, ErrInvalidVideoID) {
+			t.Errorf("ParseVideoID(%q) = %v, expected ErrInvalidVideoID", input, err)
+		}
+	}
+}
+
+func TestParsePlaylistID(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"PLj6h78yzYM2N8nw1YcqqKveySH6_0VnI0", "PLj6h78yzYM2N8nw1YcqqKveySH6_0VnI0"},
+		{"https://www.youtube.com/playlist?list=PLj6h78yzYM2N8nw1YcqqKveySH6_0VnI0", "PLj6h78yzYM2N8nw1YcqqKveySH6_0VnI0"},
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ&list=PLj6h78yzYM2N8nw1YcqqKveySH6_0VnI0&index=3", "PLj6h78yzYM2N8nw1YcqqKveySH6_0VnI0"},
+		{"youtu.be/dQw4w9WgXcQ?list=UUuAXFkgsw1L7xaCfnd5JJOw", "UUuAXFkgsw1L7xaCfnd5JJOw"},
+		{"https://music.youtube.com/playlist?list=OLAK5uy_kL3d2Ykx1AfrJ5vKr7dyUMQnLzEOQP3Xw", "OLAK5uy_kL3d2Ykx1AfrJ5vKr7dyUMQnLzEOQP3Xw"},
+	}
+	for _, tt := range tests {
+		got, err := ParsePlaylistID(tt.input)
+		if err != nil {
+			t.Errorf("ParsePlaylistID(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ParsePlaylistID(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+
+	for _, input := range []string{"", "PL 1", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", "https://example.com/playlist?list=PLj6h78yzYM2N8nw1YcqqKveySH6_0VnI0"} {
+		if _, err := ParsePlaylistID(input); !errors.Is(err, ErrInvalidPlaylistID) {
+			t.Errorf("ParsePlaylistID(%q) = %v, expected ErrInvalidPlaylistID", input, err)
+		}
+	}
+}
+
+func TestEndpoints_FailFastOnInvalidIds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, err := client.YouTubeVideo("not a video"); !errors.Is(err, ErrInvalidVideoID) {
+		t.Errorf("expected ErrInvalidVideoID, got %v", err)
+	}
+	if _, err := client.YouTubeTranscript(&YouTubeTranscriptParams{VideoId: "abc/def"}); !errors.Is(err, ErrInvalidVideoID) {
+		t.Errorf("expected ErrInvalidVideoID, got %v", err)
+	}
+	if _, err := client.YouTubePlaylist("PL 1"); !errors.Is(err, ErrInvalidPlaylistID) {
+		t.Errorf("expected ErrInvalidPlaylistID, got %v", err)
+	}
+	if _, err := client.YouTubeChannelVideos(&YouTubeChannelVideosParams{Id: "https://example.com/@creator"}); !errors.Is(err, ErrInvalidChannelRef) {
+		t.Errorf("expected ErrInvalidChannelRef, got %v", err)
+	}
+	if _, err := client.YouTubeTranscriptBatch(&YouTubeTranscriptBatchParams{VideoIds: []string{"dQw4w9WgXcQ", "abc?def"}}); !errors.Is(err, ErrInvalidVideoID) {
+		t.Errorf("expected ErrInvalidVideoID, got %v", err)
+	}
+}
+
+func TestEndpoints_PassUnrecognizedIdsThrough(t *testing.T) {
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.URL.Query().Get("id"))
+		jsonResponse(w, http.StatusOK, map[string]any{})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, err := client.YouTubeVideo("abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.YouTubePlaylist("ZZnewPrefix123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "abc" || ids[1] != "ZZnewPrefix123" {
+		t.Errorf("expected the IDs to be sent as is, got %v", ids)
+	}
+}
+
+func TestYouTubeVideo_SendsParsedId(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("id"); got != "dQw4w9WgXcQ" {
+			t.Errorf("expected the video ID to be extracted from the URL, got %q", got)
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "dQw4w9WgXcQ"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, err := client.YouTubeVideo("https://youtu.be/dQw4w9WgXcQ?t=42"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestYouTubeChannel_NormalizesRef(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("id"); got != "@GoogleDevelopers" {
+			t.Errorf("expected id %q, got %q", "@GoogleDevelopers", got)
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "UCuAXFkgsw1L7xaCfnd5JJOw", "videoIds": []string{}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, err := client.YouTubeChannel("https://www.youtube.com/@GoogleDevelopers"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.YouTubeChannelVideos(&YouTubeChannelVideosParams{Id: "https://www.youtube.com/@GoogleDevelopers/videos"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,245 @@
+package supadata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrJobNotFound is returned by a JobStore when no record exists for a job
+var ErrJobNotFound = errors.New("job not found")
+
+// JobKind identifies the endpoint a job was submitted to
+type JobKind string
+
+const (
+	JobTranscript JobKind = "transcript"
+	JobCrawl      JobKind = "crawl"
+	JobBatch      JobKind = "batch"
+	// JobChannelTranscripts records the unfinished transcript batches of ChannelTranscripts in JobIds
+	JobChannelTranscripts JobKind = "channel-transcripts"
+	// JobCrawlPages records in Cursor the progress of ResumeCrawlPages through the pages of a crawl
+	JobCrawlPages JobKind = "crawl-pages"
+)
+
+// JobRecord describes a submitted asynchronous job so that it can be resumed after a restart
+type JobRecord struct {
+	Id   string  `json:"id"`
+	Kind JobKind `json:"kind"`
+	// JobIds lists the jobs of a batch that was split into several jobs
+	JobIds []string `json:"jobIds,omitempty"`
+	// Cursor is the position of the next page to fetch from the results of the job
+	Cursor    string    `json:"cursor,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// BatchJob returns the batch job described by a record of kind JobBatch, submitted when the record was created
+func (r JobRecord) BatchJob() *YouTubeBatchJob {
+	return &YouTubeBatchJob{JobId: r.Id, JobIds: r.JobIds, SubmittedAt: r.CreatedAt}
+}
+
+// JobStore persists the records of submitted jobs
+type JobStore interface {
+	Save(record JobRecord) error
+	// Load returns ErrJobNotFound when no record exists for id
+	Load(id string) (JobRecord, error)
+	Delete(id string) error
+	// List returns every record, oldest first
+	List() ([]JobRecord, error)
+}
+
+// WithJobStore registers every asynchronous job submitted by the client in store, and removes it once a wait helper
+// has observed its completion. When a record cannot be saved, the submitted job is returned along with the error.
+func WithJobStore(store JobStore) ConfigOption {
+	return func(config *Config) {
+		config.jobStore = store
+	}
+}
+
+// registerJob adds a submitted job to the job registry, and saves its record when a job store is configured
+func (s *Supadata) registerJob(kind JobKind, id, endpoint string, jobIds []string, params any) error {
+	return errors.Join(
+		s.recordStartedJob(kind, id, endpoint, params),
+		s.registerJobRecord(JobRecord{Id: id, Kind: kind, JobIds: jobIds}),
+	)
+}
+
+// registerJobRecord saves record, stamped with the current time, when a job store is configured
+func (s *Supadata) registerJobRecord(record JobRecord) error {
+	if s.config.jobStore == nil {
+		return nil
+	}
+	record.CreatedAt = time.Now().UTC()
+	if err := s.config.jobStore.Save(record); err != nil {
+		return fmt.Errorf("registering %s job %s: %w", record.Kind, record.Id, err)
+	}
+	return nil
+}
+
+// forgetJob removes the record of a finished job when a job store is configured
+func (s *Supadata) forgetJob(id string) error {
+	if s.config.jobStore == nil {
+		return nil
+	}
+	err := s.config.jobStore.Delete(id)
+	if err != nil && !errors.Is(err, ErrJobNotFound) {
+		return fmt.Errorf("removing job %s: %w", id, err)
+	}
+	return nil
+}
+
+// MemoryJobStore is a JobStore keeping records in memory, mostly useful for tests
+type MemoryJobStore struct {
+	mu      sync.Mutex
+	records map[string]JobRecord
+}
+
+// NewMemoryJobStore creates an empty MemoryJobStore
+func NewMemoryJobStore() *MemoryJobStore {
+	return &MemoryJobStore{records: make(map[string]JobRecord)}
+}
+
+func (m *MemoryJobStore) Save(record JobRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records[record.Id] = record
+	return nil
+}
+
+func (m *MemoryJobStore) Load(id string) (JobRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	record, ok := m.records[id]
+	if !ok {
+		return JobRecord{}, ErrJobNotFound
+	}
+	return record, nil
+}
+
+func (m *MemoryJobStore) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records, id)
+	return nil
+}
+
+func (m *MemoryJobStore) List() ([]JobRecord, error) {
+	m.mu.Lock()
+	records := make([]JobRecord, 0, len(m.records))
+	for _, record := range m.records {
+		records = append(records, record)
+	}
+	m.mu.Unlock()
+	sortJobRecords(records)
+	return records, nil
+}
+
+// FileJobStore is a JobStore keeping one JSON file per record in a directory
+type FileJobStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileJobStore creates a FileJobStore in dir, creating the directory when needed
+func NewFileJobStore(dir string) (*FileJobStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &FileJobStore{dir: dir}, nil
+}
+
+// path names the file of a record after the SHA-256 of its ID, which is safe on every file system whatever the
+// characters of the ID, e.g. the ":" of "channel:…" that Windows rejects
+func (f *FileJobStore) path(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Save writes the record to a temporary file before renaming it, so that a crash never leaves a truncated record
+func (f *FileJobStore) Save(record JobRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	path := f.path(record.Id)
+	if err := writeFile(path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, data); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (f *FileJobStore) Load(id string) (JobRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.load(f.path(id))
+}
+
+func (f *FileJobStore) load(path string) (JobRecord, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return JobRecord{}, ErrJobNotFound
+	}
+	if err != nil {
+		return JobRecord{}, err
+	}
+
+	var record JobRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return JobRecord{}, fmt.Errorf("reading job record %s: %w", path, err)
+	}
+	return record, nil
+}
+
+func (f *FileJobStore) Delete(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	err := os.Remove(f.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (f *FileJobStore) List() ([]JobRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []JobRecord
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		record, err := f.load(filepath.Join(f.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	sortJobRecords(records)
+	return records, nil
+}
+
+// sortJobRecords orders records by creation time, then by ID
+func sortJobRecords(records []JobRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].CreatedAt.Equal(records[j].CreatedAt) {
+			return records[i].CreatedAt.Before(records[j].CreatedAt)
+		}
+		return records[i].Id < records[j].Id
+	})
+}
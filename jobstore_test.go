@@ -0,0 +1,179 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testJobStore(t *testing.T, store JobStore) {
+	t.Helper()
+	older := JobRecord{Id: "job/1", Kind: JobCrawl, CreatedAt: time.Unix(100, 0).UTC()}
+	newer := JobRecord{Id: "job-2", Kind: JobBatch, JobIds: []string{"a", "b"}, CreatedAt: time.Unix(200, 0).UTC()}
+	for _, record := range []JobRecord{newer, older} {
+		if err := store.Save(record); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	record, err := store.Load("job-2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record.Kind != JobBatch || len(record.JobIds) != 2 || !record.CreatedAt.Equal(newer.CreatedAt) {
+		t.Errorf("unexpected record %+v", record)
+	}
+
+	records, err := store.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 2 || records[0].Id != "job/1" || records[1].Id != "job-2" {
+		t.Errorf("expected records oldest first, got %+v", records)
+	}
+
+	if err := store.Delete("job/1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.Delete("job/1"); err != nil {
+		t.Errorf("expected deleting a missing record to succeed, got %v", err)
+	}
+	if _, err := store.Load("job/1"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func TestMemoryJobStore(t *testing.T) {
+	testJobStore(t, NewMemoryJobStore())
+}
+
+func TestFileJobStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "jobs")
+	store, err := NewFileJobStore(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testJobStore(t, store)
+
+	// A new store on the same directory sees the remaining record
+	reopened, err := NewFileJobStore(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := reopened.Load("job-2"); err != nil {
+		t.Errorf("expected record to be persisted, got %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected a single file, got %d", len(entries))
+	}
+}
+
+func TestFileJobStore_PortableFileNames(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileJobStore(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ids := []string{"channel:@creator", "crawl-pages:job-1", "a/b\\c?*"}
+	for _, id := range ids {
+		if err := store.Save(JobRecord{Id: id, Kind: JobCrawl}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if strings.ContainsAny(entry.Name(), `:/\?*<>|"`) {
+			t.Errorf("expected a portable file name, got %q", entry.Name())
+		}
+	}
+	for _, id := range ids {
+		if record, err := store.Load(id); err != nil || record.Id != id {
+			t.Errorf("expected record %q, got %+v (%v)", id, record, err)
+		}
+	}
+	if records, err := store.List(); err != nil || len(records) != len(ids) {
+		t.Errorf("expected %d records, got %+v (%v)", len(ids), records, err)
+	}
+}
+
+func TestWithJobStore_RegistersAndForgetsJobs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/web/crawl":
+			jsonResponse(w, http.StatusOK, map[string]any{"jobId": "crawl-1"})
+		case "/web/crawl/crawl-1":
+			jsonResponse(w, http.StatusOK, map[string]any{"status": "completed"})
+		case "/transcript":
+			jsonResponse(w, http.StatusAccepted, map[string]any{"jobId": "transcript-1"})
+		case "/youtube/video/batch":
+			jsonResponse(w, http.StatusOK, map[string]any{"jobId": "batch-1"})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	store := NewMemoryJobStore()
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithJobStore(store))
+
+	if _, err := client.Crawl(&CrawlBody{Url: "https://example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Transcript(&TranscriptParams{Url: "https://example.com/video"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.YouTubeVideoBatch(&YouTubeVideoBatchParams{VideoIds: []string{"a"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, _ := store.List()
+	kinds := make(map[string]JobKind)
+	for _, record := range records {
+		kinds[record.Id] = record.Kind
+	}
+	if len(kinds) != 3 || kinds["crawl-1"] != JobCrawl || kinds["transcript-1"] != JobTranscript || kinds["batch-1"] != JobBatch {
+		t.Fatalf("unexpected records %+v", records)
+	}
+
+	if _, err := client.WaitForCrawl(context.Background(), "crawl-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Load("crawl-1"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("expected completed crawl to be removed, got %v", err)
+	}
+}
+
+func TestWithJobStore_SplitBatchRegisteredOnce(t *testing.T) {
+	submitted := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		submitted++
+		jsonResponse(w, http.StatusOK, map[string]any{"jobId": fmt.Sprintf("batch-%d", submitted)})
+	}))
+	defer server.Close()
+
+	store := NewMemoryJobStore()
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithJobStore(store))
+
+	ids := make([]string, MaxBatchVideoIds+1)
+	for i := range ids {
+		ids[i] = "video"
+	}
+	job, err := client.YouTubeTranscriptBatch(&YouTubeTranscriptBatchParams{VideoIds: ids})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, _ := store.List()
+	if len(records) != 1 || records[0].Id != job.JobId || len(records[0].BatchJob().JobIds) != 2 {
+		t.Errorf("expected a single composite record, got %+v", records)
+	}
+}
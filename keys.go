@@ -0,0 +1,138 @@
+package supadata
+
+import (
+	"net/http"
+	"sync"
+)
+
+// WithAPIKeys spreads the calls of the client over several API keys, e.g. the keys of several organizations.
+// Calls use the active key until the API rejects it as unauthorized (401) or over its limit (429); the call is then
+// retried with the next key, which becomes the active one. The response of the last key is returned when every key
+// is rejected. The usage of each key is reported by KeyUsage.
+func WithAPIKeys(keys ...string) ConfigOption {
+	return func(config *Config) {
+		if len(keys) == 0 {
+			return
+		}
+		config.apiKey = keys[0]
+		config.apiKeys = append([]string(nil), keys...)
+	}
+}
+
+// KeyUsage describes how a key of WithAPIKeys has been used
+type KeyUsage struct {
+	// Key is the key masked to its last four characters
+	Key          string
+	Requests     int64
+	RateLimited  int64
+	Unauthorized int64
+	// CreditsUsed is the sum of the credits the API reported having charged to the key
+	CreditsUsed int64
+}
+
+// KeyUsage returns the usage of every key of WithAPIKeys, in the order they were given, or nil without WithAPIKeys
+func (s *Supadata) KeyUsage() []KeyUsage {
+	if s.keys == nil {
+		return nil
+	}
+	return s.keys.snapshot()
+}
+
+// keyPool tracks the active key of WithAPIKeys and the usage of every key
+type keyPool struct {
+	mu      sync.Mutex
+	keys    []string
+	current int
+	usage   []KeyUsage
+}
+
+func newKeyPool(keys []string) *keyPool {
+	usage := make([]KeyUsage, len(keys))
+	for i, key := range keys {
+		usage[i].Key = maskKey(key)
+	}
+	return &keyPool{keys: keys, usage: usage}
+}
+
+// maskKey hides all but the last four characters of key
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
+
+func (p *keyPool) active() (int, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.current, p.keys[p.current]
+}
+
+// record counts a response received with the key at index i
+func (p *keyPool) record(i int, resp *http.Response) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	usage := &p.usage[i]
+	usage.Requests++
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		usage.RateLimited++
+	case http.StatusUnauthorized:
+		usage.Unauthorized++
+	}
+	if credits, ok := creditsUsed(resp); ok {
+		usage.CreditsUsed += int64(credits)
+	}
+}
+
+// rotate makes the key following the one at index i active, unless another call already rotated away from it
+func (p *keyPool) rotate(i int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.current == i {
+		p.current = (i + 1) % len(p.keys)
+	}
+}
+
+func (p *keyPool) snapshot() []KeyUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]KeyUsage(nil), p.usage...)
+}
+
+// keyRejected reports whether a response asks for the call to be retried with another key
+func keyRejected(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusTooManyRequests
+}
+
+// doKeyRotation sends req with the active key, retrying it with the next keys of WithAPIKeys when it is rejected
+func (s *Supadata) doKeyRotation(req *http.Request) (*http.Response, error) {
+	if s.keys == nil {
+		return s.doFailover(req)
+	}
+
+	for attempt := range len(s.keys.keys) {
+		i, key := s.keys.active()
+		keyed := req.Clone(req.Context())
+		keyed.Header.Set("x-api-key", key)
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			keyed.Body = body
+		}
+
+		resp, err := s.doFailover(keyed)
+		if err != nil {
+			return nil, err
+		}
+		s.keys.record(i, resp)
+		if !keyRejected(resp) || attempt == len(s.keys.keys)-1 {
+			return resp, nil
+		}
+		closeBody(resp.Body)
+		s.keys.rotate(i)
+	}
+	panic("unreachable")
+}
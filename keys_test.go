@@ -0,0 +1,64 @@
+package supadata
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithAPIKeys_RotatesOnRejectedKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("x-api-key")
+		keys = append(keys, key)
+		switch key {
+		case "key-0001":
+			errorResponse(w, http.StatusTooManyRequests, LimitExceeded, "Limit exceeded", "")
+		case "key-0002":
+			errorResponse(w, http.StatusUnauthorized, Unauthorized, "Unauthorized", "")
+		default:
+			w.Header().Set("X-Credits-Used", "2")
+			jsonResponse(w, http.StatusOK, map[string]any{"lang": "en", "content": []map[string]any{{"text": "ok"}}})
+		}
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKeys("key-0001", "key-0002", "key-0003"), WithBaseURL(server.URL))
+	for range 2 {
+		if _, err := client.Transcript(&TranscriptParams{Url: "https://youtu.be/x"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if want := []string{"key-0001", "key-0002", "key-0003", "key-0003"}; len(keys) != len(want) || keys[0] != want[0] || keys[2] != want[2] || keys[3] != want[3] {
+		t.Errorf("expected keys %v, got %v", want, keys)
+	}
+
+	usage := client.KeyUsage()
+	if len(usage) != 3 {
+		t.Fatalf("expected the usage of 3 keys, got %+v", usage)
+	}
+	if usage[0].Key != "****0001" || usage[0].RateLimited != 1 || usage[1].Unauthorized != 1 {
+		t.Errorf("unexpected usage of the rejected keys %+v", usage[:2])
+	}
+	if usage[2].Requests != 2 || usage[2].CreditsUsed != 4 {
+		t.Errorf("unexpected usage of the active key %+v", usage[2])
+	}
+}
+
+func TestWithAPIKeys_AllKeysRejected(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		errorResponse(w, http.StatusTooManyRequests, LimitExceeded, "Limit exceeded", "")
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKeys("key-0001", "key-0002"), WithBaseURL(server.URL))
+	_, err := client.Transcript(&TranscriptParams{Url: "https://youtu.be/x"})
+	if !IsRateLimited(err) {
+		t.Errorf("expected the last rejection to be returned, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected every key to be tried once, got %d requests", requests)
+	}
+}
@@ -0,0 +1,113 @@
+package supadata
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidLang is returned when a language is not an ISO 639-1 code
+var ErrInvalidLang = errors.New("invalid language code")
+
+// Lang is an ISO 639-1 language code, e.g. "en", optionally followed by script and region subtags, e.g. "zh-Hant"
+// or "pt-BR". The empty Lang leaves the language to the API.
+type Lang string
+
+const (
+	LangArabic     Lang = "ar"
+	LangBengali    Lang = "bn"
+	LangChinese    Lang = "zh"
+	LangCzech      Lang = "cs"
+	LangDanish     Lang = "da"
+	LangDutch      Lang = "nl"
+	LangEnglish    Lang = "en"
+	LangFinnish    Lang = "fi"
+	LangFrench     Lang = "fr"
+	LangGerman     Lang = "de"
+	LangGreek      Lang = "el"
+	LangHebrew     Lang = "he"
+	LangHindi      Lang = "hi"
+	LangHungarian  Lang = "hu"
+	LangIndonesian Lang = "id"
+	LangItalian    Lang = "it"
+	LangJapanese   Lang = "ja"
+	LangKorean     Lang = "ko"
+	LangMalay      Lang = "ms"
+	LangNorwegian  Lang = "no"
+	LangPersian    Lang = "fa"
+	LangPolish     Lang = "pl"
+	LangPortuguese Lang = "pt"
+	LangRomanian   Lang = "ro"
+	LangRussian    Lang = "ru"
+	LangSpanish    Lang = "es"
+	LangSwedish    Lang = "sv"
+	LangTagalog    Lang = "tl"
+	LangThai       Lang = "th"
+	LangTurkish    Lang = "tr"
+	LangUkrainian  Lang = "uk"
+	LangUrdu       Lang = "ur"
+	LangVietnamese Lang = "vi"
+)
+
+// langCodes are the ISO 639-1 codes, plus the deprecated codes and the few ISO 639-2 codes YouTube uses for
+// languages without an ISO 639-1 code
+var langCodes = func() map[string]bool {
+	codes := map[string]bool{}
+	for _, code := range strings.Fields(`
+		aa ab ae af ak am an ar as av ay az ba be bg bh bi bm bn bo br bs ca ce ch co cr cs cu cv cy da de dv dz
+		ee el en eo es et eu fa ff fi fj fo fr fy ga gd gl gn gu gv ha he hi ho hr ht hu hy hz ia id ie ig ii ik
+		io is it iu ja jv ka kg ki kj kk kl km kn ko kr ks ku kv kw ky la lb lg li ln lo lt lu lv mg mh mi mk ml
+		mn mr ms mt my na nb nd ne ng nl nn no nr nv ny oc oj om or os pa pi pl ps pt qu rm rn ro ru rw sa sc sd
+		se sg si sk sl sm sn so sq sr ss st su sv sw ta te tg th ti tk tl tn to tr ts tt tw ty ug uk ur uz ve vi
+		vo wa wo xh yi yo za zh zu
+		in iw ji
+		ceb fil haw hmn`) {
+		codes[code] = true
+	}
+	return codes
+}()
+
+// ParseLang parses a language code, accepting any case and "_" as the subtag separator, and returns it in its
+// canonical form, e.g. "pt_br" as "pt-BR"
+func ParseLang(s string) (Lang, error) {
+	subtags := strings.Split(strings.ReplaceAll(strings.TrimSpace(s), "_", "-"), "-")
+	if !langCodes[strings.ToLower(subtags[0])] {
+		return "", fmt.Errorf("%w: %q", ErrInvalidLang, s)
+	}
+
+	subtags[0] = strings.ToLower(subtags[0])
+	for i, subtag := range subtags[1:] {
+		if len(subtag) < 2 || len(subtag) > 8 || !isAlphanumeric(subtag) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidLang, s)
+		}
+		switch len(subtag) {
+		case 2:
+			subtag = strings.ToUpper(subtag)
+		case 4:
+			subtag = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		default:
+			subtag = strings.ToLower(subtag)
+		}
+		subtags[i+1] = subtag
+	}
+	return Lang(strings.Join(subtags, "-")), nil
+}
+
+// Validate returns an error wrapping ErrInvalidLang when l is set to an unknown language
+func (l Lang) Validate() error {
+	if l == "" {
+		return nil
+	}
+	_, err := ParseLang(string(l))
+	return err
+}
+
+// isAlphanumeric reports whether s only holds ASCII letters and digits
+func isAlphanumeric(s string) bool {
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
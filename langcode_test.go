@@ -0,0 +1,57 @@
+package supadata
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseLang(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Lang
+	}{
+		{"en", LangEnglish},
+		{" FR ", LangFrench},
+		{"pt_br", "pt-BR"},
+		{"zh-hant", "zh-Hant"},
+		{"zh-Hant-TW", "zh-Hant-TW"},
+		{"es-419", "es-419"},
+		{"fil", "fil"},
+	}
+	for _, tt := range tests {
+		got, err := ParseLang(tt.input)
+		if err != nil {
+			t.Errorf("ParseLang(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ParseLang(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+
+	for _, input := range []string{"", "eng", "english", "xx", "en-", "en-a", "en-US!"} {
+		if _, err := ParseLang(input); !errors.Is(err, ErrInvalidLang) {
+			t.Errorf("ParseLang(%q) = %v, expected ErrInvalidLang", input, err)
+		}
+	}
+}
+
+func TestLang_FailsFast(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, err := client.Transcript(&TranscriptParams{Url: "https://youtu.be/dQw4w9WgXcQ", Lang: "eng"}); !errors.Is(err, ErrInvalidLang) {
+		t.Errorf("expected ErrInvalidLang, got %v", err)
+	}
+	if _, err := client.Scrape(&ScrapeParams{Url: "https://example.com", Lang: "eng"}); !errors.Is(err, ErrInvalidLang) {
+		t.Errorf("expected ErrInvalidLang, got %v", err)
+	}
+	if _, err := client.YouTubeTranscriptTranslate(&YouTubeTranscriptTranslateParams{VideoId: "dQw4w9WgXcQ", Lang: "eng"}); !errors.Is(err, ErrInvalidLang) {
+		t.Errorf("expected ErrInvalidLang, got %v", err)
+	}
+}
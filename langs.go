@@ -0,0 +1,104 @@
+package supadata
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// maxLangConcurrency bounds the number of languages fetched at the same time by TranscriptAllLangs
+const maxLangConcurrency = 5
+
+// LangTranscript is the outcome of fetching a transcript in a single language
+type LangTranscript struct {
+	Lang       string
+	Transcript *YouTubeTranscriptResult
+	Err        error
+}
+
+// TranscriptAllLangs fetches the transcript of a YouTube video in every available language concurrently.
+// Failures for individual languages are reported on their LangTranscript; an error is only returned when
+// the available languages cannot be determined. The transcript fetched to list the languages is reused for the
+// language it is in, and appended to the results when that language is missing from the list.
+func (s *Supadata) TranscriptAllLangs(ctx context.Context, videoId string) ([]LangTranscript, error) {
+	ctx = ensureLineage(ctx)
+	first, err := s.YouTubeTranscript(&YouTubeTranscriptParams{VideoId: videoId}, WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	langs := first.AvailableLangs
+	if len(langs) == 0 {
+		langs = []string{first.Lang}
+	}
+
+	results := make([]LangTranscript, len(langs))
+	firstIndex := matchLang(first.Lang, langs)
+	if firstIndex < 0 {
+		results = append(results, LangTranscript{Lang: first.Lang, Transcript: first})
+	}
+	sem := make(chan struct{}, maxLangConcurrency)
+	var wg sync.WaitGroup
+	for i, lang := range langs {
+		results[i].Lang = lang
+		if i == firstIndex {
+			results[i].Transcript = first
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, lang string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results[i].Err = ctx.Err()
+				return
+			}
+			results[i].Transcript, results[i].Err = s.YouTubeTranscript(
+				&YouTubeTranscriptParams{VideoId: videoId, Lang: Lang(lang)},
+				WithContext(ctx),
+			)
+		}(i, lang)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// matchLang returns the index of the entry of langs naming the same language as lang, comparing their canonical
+// forms, then their primary language when a single entry shares it, e.g. "en" and "en-US". It returns -1 when no
+// entry matches.
+func matchLang(lang string, langs []string) int {
+	canonical := canonicalLang(lang)
+	for i, candidate := range langs {
+		if canonicalLang(candidate) == canonical {
+			return i
+		}
+	}
+	match := -1
+	for i, candidate := range langs {
+		if primaryLang(candidate) == primaryLang(lang) {
+			if match >= 0 {
+				return -1
+			}
+			match = i
+		}
+	}
+	return match
+}
+
+// canonicalLang returns the canonical form of a language code, or the code lowercased when it cannot be parsed
+func canonicalLang(lang string) string {
+	if parsed, err := ParseLang(lang); err == nil {
+		return string(parsed)
+	}
+	return strings.ToLower(strings.TrimSpace(lang))
+}
+
+// primaryLang returns the primary language subtag of a language code, e.g. "pt" for "pt-BR"
+func primaryLang(lang string) string {
+	primary, _, _ := strings.Cut(canonicalLang(lang), "-")
+	return primary
+}
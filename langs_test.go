@@ -0,0 +1,123 @@
+package supadata
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestTranscriptAllLangs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("videoId"); got != "abc" {
+			t.Errorf("expected videoId %q, got %q", "abc", got)
+		}
+		lang := r.URL.Query().Get("lang")
+		switch lang {
+		case "", "es":
+			if lang == "" {
+				lang = "en"
+			}
+			jsonResponse(w, http.StatusOK, map[string]any{
+				"content":        []map[string]any{{"text": "text-" + lang}},
+				"lang":           lang,
+				"availableLangs": []string{"en", "es", "fr"},
+			})
+		default:
+			errorResponse(w, http.StatusNotFound, TranscriptUnavailable, "No transcript", "")
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	results, err := client.TranscriptAllLangs(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	for i, lang := range []string{"en", "es", "fr"} {
+		if results[i].Lang != lang {
+			t.Errorf("expected lang %q at %d, got %q", lang, i, results[i].Lang)
+		}
+	}
+	if results[0].Err != nil || results[0].Transcript.Content[0].Text != "text-en" {
+		t.Errorf("unexpected en result: %+v", results[0])
+	}
+	if results[1].Err != nil || results[1].Transcript.Content[0].Text != "text-es" {
+		t.Errorf("unexpected es result: %+v", results[1])
+	}
+	if results[2].Err == nil {
+		t.Error("expected error for fr")
+	}
+}
+
+func TestTranscriptAllLangs_InitialError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorResponse(w, http.StatusNotFound, NotFound, "Video not found", "")
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, err := client.TranscriptAllLangs(context.Background(), "abc"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestTranscriptAllLangs_ReusesFirstTranscript(t *testing.T) {
+	tests := []struct {
+		name      string
+		firstLang string
+		available []string
+		want      []string
+		fetched   []string
+	}{
+		{name: "region", firstLang: "en", available: []string{"en-US", "es"}, want: []string{"en-US", "es"}, fetched: []string{"es"}},
+		{name: "case and separator", firstLang: "pt_br", available: []string{"pt-BR", "es"}, want: []string{"pt-BR", "es"}, fetched: []string{"es"}},
+		{name: "missing", firstLang: "de", available: []string{"en", "es"}, want: []string{"en", "es", "de"}, fetched: []string{"en", "es"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var fetched []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				lang := r.URL.Query().Get("lang")
+				if lang == "" {
+					lang = tt.firstLang
+				} else {
+					mu.Lock()
+					fetched = append(fetched, lang)
+					mu.Unlock()
+				}
+				jsonResponse(w, http.StatusOK, map[string]any{
+					"content":        []map[string]any{{"text": "text-" + lang}},
+					"lang":           lang,
+					"availableLangs": tt.available,
+				})
+			}))
+			defer server.Close()
+
+			client := newTestClient(server)
+			results, err := client.TranscriptAllLangs(context.Background(), "abc")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			slices.Sort(fetched)
+			if !slices.Equal(fetched, tt.fetched) {
+				t.Errorf("expected %v to be fetched, got %v", tt.fetched, fetched)
+			}
+			if len(results) != len(tt.want) {
+				t.Fatalf("expected %d results, got %+v", len(tt.want), results)
+			}
+			for i, lang := range tt.want {
+				if results[i].Lang != lang || results[i].Err != nil || results[i].Transcript == nil {
+					t.Errorf("unexpected result %d: %+v", i, results[i])
+				}
+			}
+		})
+	}
+}
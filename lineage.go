@@ -0,0 +1,35 @@
+package supadata
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type lineageKey struct{}
+
+// WithLineage returns a copy of ctx carrying a lineage ID, grouping every request made with it under a single
+// originating operation in provenance records and logs
+func WithLineage(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, lineageKey{}, id)
+}
+
+// LineageFromContext returns the lineage ID carried by ctx
+func LineageFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(lineageKey{}).(string)
+	return id, ok && id != ""
+}
+
+// ensureLineage returns ctx unchanged when it carries a lineage ID, otherwise starts a new lineage
+func ensureLineage(ctx context.Context) context.Context {
+	if _, ok := LineageFromContext(ctx); ok {
+		return ctx
+	}
+	return WithLineage(ctx, newLineageId())
+}
+
+func newLineageId() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,103 @@
+package supadata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestLineage_GroupsHelperRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := r.URL.Query().Get("lang")
+		if lang == "" {
+			lang = "en"
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"lang": lang, "availableLangs": []string{"en", "es", "fr"}})
+	}))
+	defer server.Close()
+
+	var logs syncBuffer
+	client := NewSupadata(
+		WithAPIKey("test-api-key"),
+		WithBaseURL(server.URL),
+		WithLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+	)
+
+	if _, err := client.TranscriptAllLangs(context.Background(), "abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 log lines, got %d", len(lines))
+	}
+	lineages := make(map[string]bool)
+	for _, line := range lines {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("failed to decode log line: %v", err)
+		}
+		if entry["endpoint"] != "/youtube/transcript" || entry["status"] != float64(200) {
+			t.Errorf("unexpected log entry %v", entry)
+		}
+		lineage, _ := entry["lineage"].(string)
+		lineages[lineage] = true
+	}
+	if len(lineages) != 1 || lineages[""] {
+		t.Errorf("expected a single non-empty lineage, got %v", lineages)
+	}
+}
+
+func TestLineage_ProvidedByCaller(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{"plan": "free"})
+	}))
+	defer server.Close()
+
+	ctx := WithLineage(context.Background(), "nightly-harvest")
+	if got := ensureLineage(ctx); got != ctx {
+		t.Error("expected existing lineage to be kept")
+	}
+
+	client := newTestClient(server)
+	var p Provenance
+	if _, err := client.Me(WithContext(ctx), WithProvenance(&p)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.LineageId != "nightly-harvest" {
+		t.Errorf("expected lineage %q, got %q", "nightly-harvest", p.LineageId)
+	}
+}
+
+func TestLineageFromContext_Missing(t *testing.T) {
+	if _, ok := LineageFromContext(context.Background()); ok {
+		t.Error("expected no lineage")
+	}
+	if id, ok := LineageFromContext(ensureLineage(context.Background())); !ok || len(id) != 16 {
+		t.Errorf("expected a generated lineage, got %q", id)
+	}
+}
@@ -0,0 +1,235 @@
+// Package linkgraph builds the directed graph of links between web pages from scrape and crawl results, and exports
+// it as Graphviz DOT or JSON for SEO audits.
+package linkgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/petros0/supadata-go"
+)
+
+// markdownLinkPattern matches the target of inline markdown links that are not images, and autolinks
+var markdownLinkPattern = regexp.MustCompile(`(?:^|[^!])\[[^\]]*\]\(<?([^)\s>]+)>?(?:\s+"[^"]*")?\)|<(https?://[^>\s]+)>`)
+
+// Node is a page of the graph
+type Node struct {
+	Url   string `json:"url"`
+	Title string `json:"title,omitempty"`
+	// Crawled is false for pages that are only known as link targets
+	Crawled bool `json:"crawled"`
+}
+
+// Edge is a link from one page to another
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Graph is a directed graph of links between pages, keyed by normalized URL
+type Graph struct {
+	nodes map[string]*Node
+	edges map[string]map[string]bool
+}
+
+// New creates an empty graph
+func New() *Graph {
+	return &Graph{
+		nodes: make(map[string]*Node),
+		edges: make(map[string]map[string]bool),
+	}
+}
+
+// FromCrawl builds the graph of the pages of a completed crawl job
+func FromCrawl(ctx context.Context, client *supadata.Supadata, jobId string) (*Graph, error) {
+	g := New()
+	for page, err := range client.CrawlPages(ctx, jobId) {
+		if err != nil {
+			return nil, err
+		}
+		g.AddCrawlPage(page)
+	}
+	return g, nil
+}
+
+// AddScrape adds a scraped page along with the links it contains
+func (g *Graph) AddScrape(result *supadata.ScrapeResult) {
+	g.AddPage(result.Url, result.Name, result.Urls)
+}
+
+// AddCrawlPage adds a crawled page along with the links found in its markdown content
+func (g *Graph) AddCrawlPage(page supadata.CrawlPage) {
+	g.AddPage(page.Url, page.Name, MarkdownLinks(page.Content))
+}
+
+// AddPage adds a page and an edge to every link. Relative links are resolved against pageUrl; links that are not
+// http or https, and links from the page to itself, are ignored.
+func (g *Graph) AddPage(pageUrl, title string, links []string) {
+	from, base := Normalize(pageUrl, nil)
+	if from == "" {
+		return
+	}
+	node := g.node(from)
+	node.Crawled = true
+	if title != "" {
+		node.Title = title
+	}
+
+	for _, link := range links {
+		to, _ := Normalize(link, base)
+		if to == "" || to == from {
+			continue
+		}
+		g.node(to)
+		if g.edges[from] == nil {
+			g.edges[from] = make(map[string]bool)
+		}
+		g.edges[from][to] = true
+	}
+}
+
+func (g *Graph) node(key string) *Node {
+	node, ok := g.nodes[key]
+	if !ok {
+		node = &Node{Url: key}
+		g.nodes[key] = node
+	}
+	return node
+}
+
+// Nodes returns the pages of the graph sorted by URL
+func (g *Graph) Nodes() []Node {
+	nodes := make([]Node, 0, len(g.nodes))
+	for _, node := range g.nodes {
+		nodes = append(nodes, *node)
+	}
+	slices.SortFunc(nodes, func(a, b Node) int { return strings.Compare(a.Url, b.Url) })
+	return nodes
+}
+
+// Edges returns the links of the graph sorted by source, then target URL
+func (g *Graph) Edges() []Edge {
+	var edges []Edge
+	for from, targets := range g.edges {
+		for to := range targets {
+			edges = append(edges, Edge{From: from, To: to})
+		}
+	}
+	slices.SortFunc(edges, func(a, b Edge) int {
+		if c := strings.Compare(a.From, b.From); c != 0 {
+			return c
+		}
+		return strings.Compare(a.To, b.To)
+	})
+	return edges
+}
+
+// Outlinks returns the targets of the links of a page, sorted
+func (g *Graph) Outlinks(pageUrl string) []string {
+	key, _ := Normalize(pageUrl, nil)
+	targets := make([]string, 0, len(g.edges[key]))
+	for to := range g.edges[key] {
+		targets = append(targets, to)
+	}
+	slices.Sort(targets)
+	return targets
+}
+
+// Inlinks returns the pages linking to a page, sorted
+func (g *Graph) Inlinks(pageUrl string) []string {
+	key, _ := Normalize(pageUrl, nil)
+	var sources []string
+	for from, targets := range g.edges {
+		if targets[key] {
+			sources = append(sources, from)
+		}
+	}
+	slices.Sort(sources)
+	return sources
+}
+
+// MarshalJSON encodes the graph as {"nodes": [...], "edges": [...]}
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	edges := g.Edges()
+	if edges == nil {
+		edges = []Edge{}
+	}
+	return json.Marshal(struct {
+		Nodes []Node `json:"nodes"`
+		Edges []Edge `json:"edges"`
+	}{g.Nodes(), edges})
+}
+
+// WriteJSON writes the graph to w as JSON
+func (g *Graph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g)
+}
+
+// WriteDOT writes the graph to w in the Graphviz DOT language. Pages that were not crawled are drawn dashed.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph links {\n")
+	for _, node := range g.Nodes() {
+		label := node.Url
+		if node.Title != "" {
+			label = node.Title
+		}
+		fmt.Fprintf(&b, "  %s [label=%s", strconv.Quote(node.Url), strconv.Quote(label))
+		if !node.Crawled {
+			b.WriteString(", style=dashed")
+		}
+		b.WriteString("];\n")
+	}
+	for _, edge := range g.Edges() {
+		fmt.Fprintf(&b, "  %s -> %s;\n", strconv.Quote(edge.From), strconv.Quote(edge.To))
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// MarkdownLinks returns the targets of the links of a markdown document, excluding images
+func MarkdownLinks(markdown string) []string {
+	var links []string
+	for _, match := range markdownLinkPattern.FindAllStringSubmatch(markdown, -1) {
+		if match[1] != "" {
+			links = append(links, match[1])
+		} else {
+			links = append(links, match[2])
+		}
+	}
+	return links
+}
+
+// Normalize resolves link against base, when not nil, and returns it without its fragment and with a lowercase
+// scheme and host, along with the parsed URL. It returns an empty string for links that are not http or https.
+func Normalize(link string, base *url.URL) (string, *url.URL) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return "", nil
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", nil
+	}
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+	u.RawFragment = ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u.String(), u
+}
@@ -0,0 +1,113 @@
+package linkgraph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/petros0/supadata-go"
+)
+
+func TestMarkdownLinks(t *testing.T) {
+	markdown := "See [docs](/docs \"Docs\") and ![logo](/logo.png), [home](<https://example.com/>) or <https://other.com/x>."
+
+	want := []string{"/docs", "https://example.com/", "https://other.com/x"}
+	if got := MarkdownLinks(markdown); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestGraph_AddPages(t *testing.T) {
+	g := New()
+	g.AddScrape(&supadata.ScrapeResult{
+		Url:  "https://Example.com",
+		Name: "Home",
+		Urls: []string{"/about", "https://example.com/#top", "mailto:hi@example.com", "https://other.com/page#x"},
+	})
+	g.AddCrawlPage(supadata.CrawlPage{
+		Url:     "https://example.com/about",
+		Name:    "About",
+		Content: "Back [home](/) or read the [blog](blog/post).",
+	})
+
+	nodes := g.Nodes()
+	var urls []string
+	for _, node := range nodes {
+		urls = append(urls, node.Url)
+	}
+	wantUrls := []string{"https://example.com/", "https://example.com/about", "https://example.com/blog/post", "https://other.com/page"}
+	if !slices.Equal(urls, wantUrls) {
+		t.Errorf("expected nodes %v, got %v", wantUrls, urls)
+	}
+	if !nodes[0].Crawled || nodes[0].Title != "Home" || nodes[3].Crawled {
+		t.Errorf("unexpected nodes %+v", nodes)
+	}
+
+	if out := g.Outlinks("https://example.com"); !slices.Equal(out, []string{"https://example.com/about", "https://other.com/page"}) {
+		t.Errorf("unexpected outlinks %v", out)
+	}
+	if in := g.Inlinks("https://example.com/"); !slices.Equal(in, []string{"https://example.com/about"}) {
+		t.Errorf("unexpected inlinks %v", in)
+	}
+}
+
+func TestGraph_Export(t *testing.T) {
+	g := New()
+	g.AddPage("https://example.com/", "Home", []string{"/about"})
+
+	var dot bytes.Buffer
+	if err := g.WriteDOT(&dot); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantDOT := "digraph links {\n" +
+		"  \"https://example.com/\" [label=\"Home\"];\n" +
+		"  \"https://example.com/about\" [label=\"https://example.com/about\", style=dashed];\n" +
+		"  \"https://example.com/\" -> \"https://example.com/about\";\n" +
+		"}\n"
+	if dot.String() != wantDOT {
+		t.Errorf("unexpected DOT:\n%s", dot.String())
+	}
+
+	var buf bytes.Buffer
+	if err := g.WriteJSON(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded struct {
+		Nodes []Node `json:"nodes"`
+		Edges []Edge `json:"edges"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(decoded.Nodes) != 2 || len(decoded.Edges) != 1 || decoded.Edges[0].To != "https://example.com/about" {
+		t.Errorf("unexpected JSON %s", buf.String())
+	}
+}
+
+func TestFromCrawl(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status": "completed",
+			"pages": []map[string]any{
+				{"url": "https://example.com/", "content": "[a](/a)"},
+				{"url": "https://example.com/a", "content": "[home](/)"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := supadata.NewSupadata(supadata.WithAPIKey("test-api-key"), supadata.WithBaseURL(server.URL))
+	g, err := FromCrawl(context.Background(), client, "job-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if edges := g.Edges(); len(edges) != 2 || !strings.HasSuffix(edges[0].To, "/a") {
+		t.Errorf("unexpected edges %+v", edges)
+	}
+}
@@ -0,0 +1,195 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+)
+
+// ErrNoNextPage is returned by NextPage when the page is the last one
+var ErrNoNextPage = errors.New("no next page")
+
+// Paginator is implemented by every Page, whatever its item type, so that NextPage accepts all of them
+type Paginator interface {
+	HasNext() bool
+	advance(ctx context.Context, s *Supadata) error
+}
+
+// Page is a page of results of a paginated endpoint. NextCursor is empty on the last page.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+
+	fetch func(ctx context.Context, s *Supadata, cursor string) (*Page[T], error)
+}
+
+// HasNext reports whether a page follows this one
+func (p *Page[T]) HasNext() bool {
+	return p.NextCursor != "" && p.fetch != nil
+}
+
+func (p *Page[T]) advance(ctx context.Context, s *Supadata) error {
+	if !p.HasNext() {
+		return ErrNoNextPage
+	}
+	next, err := p.fetch(ensureLineage(ctx), s, p.NextCursor)
+	if err != nil {
+		return err
+	}
+	*p = *next
+	return nil
+}
+
+// NextPage replaces the content of page with the page following it, fetched with the parameters of the first page.
+// It returns ErrNoNextPage when page is the last one.
+func (s *Supadata) NextPage(ctx context.Context, page Paginator) error {
+	return page.advance(ctx, s)
+}
+
+// pageItems iterates over the items of first and of every page following it.
+// Iteration stops after the first error.
+func pageItems[T any](ctx context.Context, s *Supadata, first func(ctx context.Context) (*Page[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx := ensureLineage(ctx)
+		var zero T
+		page, err := first(ctx)
+		for {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !page.HasNext() {
+				return
+			}
+			err = s.NextPage(ctx, page)
+		}
+	}
+}
+
+// nextCursor returns the cursor of the page following the one fetched with cursor, or an empty cursor when the API
+// returned the same cursor again
+func nextCursor(cursor, next string) string {
+	if next == cursor {
+		return ""
+	}
+	return next
+}
+
+// YouTubeSearchPage returns the first page of results of a YouTube search, starting at params.NextPageToken when set
+func (s *Supadata) YouTubeSearchPage(ctx context.Context, params *YouTubeSearchParams) (*Page[YouTubeSearchResultItem], error) {
+	pageParams := *params
+	return fetchYouTubeSearchPage(ensureLineage(ctx), s, pageParams.NextPageToken, &pageParams)
+}
+
+func fetchYouTubeSearchPage(ctx context.Context, s *Supadata, cursor string, params *YouTubeSearchParams) (*Page[YouTubeSearchResultItem], error) {
+	pageParams := *params
+	pageParams.NextPageToken = cursor
+	result, err := s.YouTubeSearch(&pageParams, WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &Page[YouTubeSearchResultItem]{
+		Items:      result.Results,
+		NextCursor: nextCursor(cursor, result.NextPageToken),
+		fetch: func(ctx context.Context, s *Supadata, cursor string) (*Page[YouTubeSearchResultItem], error) {
+			return fetchYouTubeSearchPage(ctx, s, cursor, params)
+		},
+	}, nil
+}
+
+// YouTubeChannelVideosPage returns the first page of the IDs of the videos, shorts and live streams of a YouTube
+// channel, starting at params.NextPageToken when set
+func (s *Supadata) YouTubeChannelVideosPage(ctx context.Context, params *YouTubeChannelVideosParams) (*Page[string], error) {
+	pageParams := *params
+	return fetchYouTubeChannelVideosPage(ensureLineage(ctx), s, pageParams.NextPageToken, &pageParams)
+}
+
+func fetchYouTubeChannelVideosPage(ctx context.Context, s *Supadata, cursor string, params *YouTubeChannelVideosParams) (*Page[string], error) {
+	pageParams := *params
+	pageParams.NextPageToken = cursor
+	result, err := s.YouTubeChannelVideos(&pageParams, WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &Page[string]{
+		Items:      concatIds(result.VideoIds, result.ShortIds, result.LiveIds),
+		NextCursor: nextCursor(cursor, result.NextPageToken),
+		fetch: func(ctx context.Context, s *Supadata, cursor string) (*Page[string], error) {
+			return fetchYouTubeChannelVideosPage(ctx, s, cursor, params)
+		},
+	}, nil
+}
+
+// YouTubePlaylistVideosPage returns the first page of the IDs of the videos, shorts and live streams of a YouTube
+// playlist, starting at params.NextPageToken when set
+func (s *Supadata) YouTubePlaylistVideosPage(ctx context.Context, params *YouTubePlaylistVideosParams) (*Page[string], error) {
+	pageParams := *params
+	return fetchYouTubePlaylistVideosPage(ensureLineage(ctx), s, pageParams.NextPageToken, &pageParams)
+}
+
+func fetchYouTubePlaylistVideosPage(ctx context.Context, s *Supadata, cursor string, params *YouTubePlaylistVideosParams) (*Page[string], error) {
+	pageParams := *params
+	pageParams.NextPageToken = cursor
+	result, err := s.YouTubePlaylistVideos(&pageParams, WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &Page[string]{
+		Items:      concatIds(result.VideoIds, result.ShortIds, result.LiveIds),
+		NextCursor: nextCursor(cursor, result.NextPageToken),
+		fetch: func(ctx context.Context, s *Supadata, cursor string) (*Page[string], error) {
+			return fetchYouTubePlaylistVideosPage(ctx, s, cursor, params)
+		},
+	}, nil
+}
+
+// CrawlResultPage returns the first page of the pages of a completed crawl job. Its cursor is the skip offset of
+// the next page.
+func (s *Supadata) CrawlResultPage(ctx context.Context, jobId string) (*Page[CrawlPage], error) {
+	return fetchCrawlResultPage(ensureLineage(ctx), s, "", jobId)
+}
+
+func fetchCrawlResultPage(ctx context.Context, s *Supadata, cursor, jobId string) (*Page[CrawlPage], error) {
+	skip := 0
+	if cursor != "" {
+		var err error
+		if skip, err = strconv.Atoi(cursor); err != nil {
+			return nil, fmt.Errorf("invalid crawl cursor %q: %w", cursor, err)
+		}
+	}
+
+	result, err := s.CrawlResult(jobId, skip, WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if result.Status != CrawlCompleted {
+		return nil, fmt.Errorf("%w: crawl %s is %s", ErrCrawlNotCompleted, jobId, result.Status)
+	}
+
+	page := &Page[CrawlPage]{
+		Items: result.Pages,
+		fetch: func(ctx context.Context, s *Supadata, cursor string) (*Page[CrawlPage], error) {
+			return fetchCrawlResultPage(ctx, s, cursor, jobId)
+		},
+	}
+	if next := nextSkip(result.Next, skip+len(result.Pages)); result.Next != "" && next > skip {
+		page.NextCursor = strconv.Itoa(next)
+	}
+	return page, nil
+}
+
+// concatIds returns the IDs of every list in order
+func concatIds(lists ...[]string) []string {
+	var ids []string
+	for _, list := range lists {
+		ids = append(ids, list...)
+	}
+	return ids
+}
@@ -0,0 +1,82 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNextPage_YouTubeSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("query") != "golang" {
+			t.Errorf("expected the query to be kept across pages, got %q", q.Get("query"))
+		}
+		if q.Get("nextPageToken") == "" {
+			jsonResponse(w, http.StatusOK, map[string]any{
+				"results":       []map[string]any{{"id": "a"}},
+				"nextPageToken": "page-2",
+			})
+			return
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"results": []map[string]any{{"id": "b"}}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	ctx := context.Background()
+	page, err := client.YouTubeSearchPage(ctx, &YouTubeSearchParams{Query: "golang"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Id != "a" || page.NextCursor != "page-2" || !page.HasNext() {
+		t.Fatalf("unexpected first page %+v", page)
+	}
+
+	if err := client.NextPage(ctx, page); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Id != "b" || page.HasNext() {
+		t.Fatalf("unexpected last page %+v", page)
+	}
+
+	if err := client.NextPage(ctx, page); !errors.Is(err, ErrNoNextPage) {
+		t.Errorf("expected ErrNoNextPage, got %v", err)
+	}
+}
+
+func TestNextPage_CrawlResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("skip") == "" {
+			jsonResponse(w, http.StatusOK, map[string]any{
+				"status": "completed",
+				"pages":  []map[string]any{{"url": "https://example.com/1"}, {"url": "https://example.com/2"}},
+				"next":   "https://api.supadata.ai/v1/web/crawl/job-1?skip=2",
+			})
+			return
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"status": "completed",
+			"pages":  []map[string]any{{"url": "https://example.com/3"}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	ctx := context.Background()
+	page, err := client.CrawlResultPage(ctx, "job-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 2 || page.NextCursor != "2" {
+		t.Fatalf("unexpected first page %+v", page)
+	}
+	if err := client.NextPage(ctx, page); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Url != "https://example.com/3" || page.HasNext() {
+		t.Errorf("unexpected last page %+v", page)
+	}
+}
@@ -0,0 +1,70 @@
+package supadata
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// Bool returns a pointer to v, to set the optional boolean parameters. A nil parameter is not sent, leaving the
+// API default in place, while Bool(false) is sent explicitly.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// isTrue reports whether an optional boolean parameter is set to true
+func isTrue(v *bool) bool {
+	return v != nil && *v
+}
+
+// setBool sets key in q when the optional boolean parameter v is set
+func setBool(q url.Values, key string, v *bool) {
+	if v != nil {
+		q.Set(key, strconv.FormatBool(*v))
+	}
+}
+
+// ExtraParams holds query parameters the client does not model yet, e.g. ones added to the API after this version
+// of the client. Set through the Extra field of the params structs, they are sent as is and replace the modeled
+// parameters of the same name.
+type ExtraParams = url.Values
+
+// ExtraFields holds JSON body fields the client does not model yet. Set through the Extra field of the request
+// bodies, they are sent as is and replace the modeled fields of the same name.
+type ExtraFields = map[string]any
+
+// setExtra sets the extra query parameters in q, replacing the values set for the same keys from the modeled fields
+func setExtra(q url.Values, extra ExtraParams) {
+	for key, values := range extra {
+		q[key] = append([]string(nil), values...)
+	}
+}
+
+// extraBody is implemented by the request bodies carrying extra fields
+type extraBody interface {
+	extraFields() ExtraFields
+}
+
+func (b *CrawlBody) extraFields() ExtraFields                    { return b.Extra }
+func (p *YouTubeVideoBatchParams) extraFields() ExtraFields      { return p.Extra }
+func (p *YouTubeTranscriptBatchParams) extraFields() ExtraFields { return p.Extra }
+
+// mergeExtra sets the extra fields of payload in its JSON encoding data, replacing the modeled fields of the same name
+func (s *Supadata) mergeExtra(data []byte, payload any) ([]byte, error) {
+	body, ok := payload.(extraBody)
+	if !ok || len(body.extraFields()) == 0 {
+		return data, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := s.config.jsonUnmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range body.extraFields() {
+		encoded, err := s.config.jsonMarshal(value)
+		if err != nil {
+			return nil, err
+		}
+		fields[key] = encoded
+	}
+	return s.config.jsonMarshal(fields)
+}
@@ -0,0 +1,111 @@
+package supadata
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestOptionalBool_QueryParams(t *testing.T) {
+	var noLinks []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		noLinks = append(noLinks, r.URL.Query()["noLinks"]...)
+		if len(r.URL.Query()["noLinks"]) == 0 {
+			noLinks = append(noLinks, "<unset>")
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"url": "https://example.com"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	for _, v := range []*bool{nil, Bool(false), Bool(true)} {
+		if _, err := client.Scrape(&ScrapeParams{Url: "https://example.com", NoLinks: v}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	expected := []string{"<unset>", "false", "true"}
+	for i := range expected {
+		if noLinks[i] != expected[i] {
+			t.Errorf("expected noLinks %v, got %v", expected, noLinks)
+			break
+		}
+	}
+}
+
+func TestOptionalBool_JSONBody(t *testing.T) {
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		jsonResponse(w, http.StatusOK, map[string]any{"jobId": "job-1"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	for _, v := range []*bool{nil, Bool(false)} {
+		if _, err := client.Crawl(&CrawlBody{Url: "https://example.com", RenderJs: v}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if _, ok := bodies[0]["renderJs"]; ok {
+		t.Errorf("expected an unset renderJs to be omitted, got %v", bodies[0])
+	}
+	if v, ok := bodies[1]["renderJs"]; !ok || v != false {
+		t.Errorf("expected renderJs to be sent as false, got %v", bodies[1])
+	}
+}
+
+func TestExtra_QueryParams(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		jsonResponse(w, http.StatusOK, map[string]any{"results": []any{}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.YouTubeSearch(&YouTubeSearchParams{
+		Query: "golang",
+		Limit: 10,
+		Extra: url.Values{"newFilter": {"a", "b"}, "limit": {"20"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := query["newFilter"]; len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("expected the extra parameter to be sent, got %v", query)
+	}
+	if query.Get("limit") != "20" || query.Get("query") != "golang" {
+		t.Errorf("expected extra parameters to replace modeled ones only, got %v", query)
+	}
+}
+
+func TestExtra_JSONBody(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		jsonResponse(w, http.StatusOK, map[string]any{"jobId": "job-1"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.Crawl(&CrawlBody{
+		Url:   "https://example.com",
+		Limit: 5,
+		Extra: map[string]any{"maxAge": 3600, "limit": 50},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["url"] != "https://example.com" || body["maxAge"] != float64(3600) || body["limit"] != float64(50) {
+		t.Errorf("expected the extra fields to be merged into the body, got %v", body)
+	}
+	if _, ok := body["Extra"]; ok {
+		t.Errorf("expected the extra fields not to be nested, got %v", body)
+	}
+}
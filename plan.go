@@ -0,0 +1,70 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultAccountCacheTTL is the account cache TTL used by WithPlanChecks when WithAccountCacheTTL is not set
+const DefaultAccountCacheTTL = 5 * time.Minute
+
+// freePlan is the name of the plan that cannot use paid-only endpoints
+const freePlan = "free"
+
+// paidEndpoints maps the endpoints requiring a paid plan to the name of the method calling them
+var paidEndpoints = map[string]string{
+	"/web/crawl":                "Crawl",
+	"/youtube/video/batch":      "YouTubeVideoBatch",
+	"/youtube/transcript/batch": "YouTubeTranscriptBatch",
+}
+
+// ErrPlanRequired is matched by errors.Is when a call requires a plan the account is not on
+var ErrPlanRequired = errors.New("paid plan required")
+
+// PlanRequiredError is returned, without sending the request, when a free plan account calls a paid-only endpoint
+type PlanRequiredError struct {
+	Method string
+	// Plan is the current plan of the account
+	Plan string
+}
+
+func (e *PlanRequiredError) Error() string {
+	return fmt.Sprintf("%s requires a paid plan; current plan: %s", e.Method, e.Plan)
+}
+
+func (e *PlanRequiredError) Is(target error) bool {
+	return target == ErrPlanRequired
+}
+
+// WithPlanChecks fails calls to paid-only endpoints, such as Crawl and the batch endpoints, with a
+// *PlanRequiredError when the account is on the free plan. The plan is read from Me, cached for
+// DefaultAccountCacheTTL unless WithAccountCacheTTL is set. Calls are not blocked when the plan cannot be determined.
+func WithPlanChecks() ConfigOption {
+	return func(config *Config) {
+		config.planChecks = true
+	}
+}
+
+// checkPlan fails calls to paid-only endpoints when plan checks are enabled and the account is on the free plan
+func (s *Supadata) checkPlan(ctx context.Context, endpoint string) error {
+	method, paid := paidEndpoints[endpoint]
+	if !s.config.planChecks || !paid {
+		return nil
+	}
+
+	var opts []RequestOption
+	if ctx != nil {
+		opts = append(opts, WithContext(ctx))
+	}
+	account, err := s.Me(opts...)
+	if err != nil {
+		return nil
+	}
+	if strings.EqualFold(account.Plan, freePlan) {
+		return &PlanRequiredError{Method: method, Plan: account.Plan}
+	}
+	return nil
+}
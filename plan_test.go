@@ -0,0 +1,79 @@
+package supadata
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func planServer(t *testing.T, plan string, requests map[string]int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		if r.URL.Path == "/me" {
+			jsonResponse(w, http.StatusOK, map[string]any{"plan": plan})
+			return
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"jobId": "job-1"})
+	}))
+}
+
+func TestWithPlanChecks_FreePlan(t *testing.T) {
+	requests := make(map[string]int)
+	server := planServer(t, "Free", requests)
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithPlanChecks())
+
+	_, err := client.Crawl(&CrawlBody{Url: "https://example.com"})
+	var planErr *PlanRequiredError
+	if !errors.Is(err, ErrPlanRequired) || !errors.As(err, &planErr) {
+		t.Fatalf("expected *PlanRequiredError, got %v", err)
+	}
+	if planErr.Error() != "Crawl requires a paid plan; current plan: Free" {
+		t.Errorf("unexpected message %q", planErr.Error())
+	}
+
+	if _, err := client.YouTubeTranscriptBatch(&YouTubeTranscriptBatchParams{VideoIds: []string{"a"}}); !errors.Is(err, ErrPlanRequired) {
+		t.Errorf("expected ErrPlanRequired, got %v", err)
+	}
+	if _, err := client.YouTubeBatchResult("job-1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if requests["/web/crawl"] != 0 || requests["/youtube/transcript/batch"] != 0 {
+		t.Errorf("expected paid-only endpoints not to be called, got %v", requests)
+	}
+	if requests["/me"] != 1 {
+		t.Errorf("expected the plan to be cached, got %d calls to /me", requests["/me"])
+	}
+}
+
+func TestWithPlanChecks_PaidPlan(t *testing.T) {
+	requests := make(map[string]int)
+	server := planServer(t, "Pro", requests)
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithPlanChecks())
+	if _, err := client.Crawl(&CrawlBody{Url: "https://example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests["/web/crawl"] != 1 {
+		t.Errorf("expected crawl to be called once, got %d", requests["/web/crawl"])
+	}
+}
+
+func TestWithPlanChecks_Disabled(t *testing.T) {
+	requests := make(map[string]int)
+	server := planServer(t, "Free", requests)
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, err := client.Crawl(&CrawlBody{Url: "https://example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests["/me"] != 0 {
+		t.Errorf("expected no plan lookup, got %d", requests["/me"])
+	}
+}
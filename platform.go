@@ -0,0 +1,74 @@
+package supadata
+
+import (
+	"strings"
+)
+
+// platformDomains maps the domains of the supported platforms, subdomains included, to their platform
+var platformDomains = map[string]MetadataPlatform{
+	"youtube.com":          YouTube,
+	"youtu.be":             YouTube,
+	"youtube-nocookie.com": YouTube,
+	"tiktok.com":           TikTok,
+	"instagram.com":        Instagram,
+	"instagr.am":           Instagram,
+	"twitter.com":          Twitter,
+	"x.com":                Twitter,
+	"facebook.com":         Facebook,
+	"fb.com":               Facebook,
+	"fb.watch":             Facebook,
+}
+
+// trackingParams are the query parameters removed by NormalizeURL, besides the utm_ ones
+var trackingParams = map[string]bool{
+	"si":     true,
+	"fbclid": true,
+	"igsh":   true,
+	"igshid": true,
+}
+
+// DetectPlatform returns the platform hosting the content at rawURL, reporting false when it is not one supported
+// by Metadata
+func DetectPlatform(rawURL string) (MetadataPlatform, bool) {
+	u, ok := parseURL(strings.TrimSpace(rawURL))
+	if !ok {
+		return "", false
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	for {
+		if platform, ok := platformDomains[host]; ok {
+			return platform, true
+		}
+		_, parent, found := strings.Cut(host, ".")
+		if !found {
+			return "", false
+		}
+		host = parent
+	}
+}
+
+// NormalizeURL removes the tracking parameters from rawURL, i.e. the utm_ ones, si, fbclid and the Instagram share
+// parameters, keeping the other parameters in their order. URLs which cannot be parsed are returned unchanged.
+func NormalizeURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	u, ok := parseURL(rawURL)
+	if !ok || u.RawQuery == "" {
+		return rawURL
+	}
+
+	params := strings.Split(u.RawQuery, "&")
+	kept := params[:0]
+	for _, param := range params {
+		key, _, _ := strings.Cut(param, "=")
+		key = strings.ToLower(key)
+		if param == "" || trackingParams[key] || strings.HasPrefix(key, "utm_") {
+			continue
+		}
+		kept = append(kept, param)
+	}
+	u.RawQuery = strings.Join(kept, "&")
+	if !strings.Contains(rawURL, "://") {
+		return strings.TrimPrefix(u.String(), "https://")
+	}
+	return u.String()
+}
@@ -0,0 +1,70 @@
+package supadata
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDetectPlatform(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected MetadataPlatform
+	}{
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", YouTube},
+		{"youtu.be/dQw4w9WgXcQ", YouTube},
+		{"https://vm.tiktok.com/ZMabcdef/", TikTok},
+		{"https://www.instagram.com/p/abc123/", Instagram},
+		{"https://x.com/user/status/123", Twitter},
+		{"https://mobile.twitter.com/user/status/123", Twitter},
+		{"https://fb.watch/abc123/", Facebook},
+		{"https://m.facebook.com/watch/?v=123", Facebook},
+	}
+	for _, tt := range tests {
+		platform, ok := DetectPlatform(tt.url)
+		if !ok || platform != tt.expected {
+			t.Errorf("DetectPlatform(%q) = %q, %v, expected %q", tt.url, platform, ok, tt.expected)
+		}
+	}
+
+	for _, url := range []string{"", "https://example.com/video", "https://notyoutube.com/watch", "not a url"} {
+		if platform, ok := DetectPlatform(url); ok {
+			t.Errorf("DetectPlatform(%q) = %q, expected no platform", url, platform)
+		}
+	}
+}
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://youtu.be/dQw4w9WgXcQ?si=abcdef", "https://youtu.be/dQw4w9WgXcQ"},
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ&utm_source=x&t=42&UTM_Medium=y", "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42"},
+		{"https://www.facebook.com/watch/?v=123&fbclid=IwAR0", "https://www.facebook.com/watch/?v=123"},
+		{"https://www.instagram.com/reel/abc/?igsh=MWQ1", "https://www.instagram.com/reel/abc/"},
+		{"youtube.com/watch?v=dQw4w9WgXcQ&si=abc", "youtube.com/watch?v=dQw4w9WgXcQ"},
+		{"https://example.com/page?a=1&b=2", "https://example.com/page?a=1&b=2"},
+		{"https://example.com/page", "https://example.com/page"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeURL(tt.url); got != tt.expected {
+			t.Errorf("NormalizeURL(%q) = %q, expected %q", tt.url, got, tt.expected)
+		}
+	}
+}
+
+func TestMetadata_NormalizesURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("url"); got != "https://www.tiktok.com/@user/video/123" {
+			t.Errorf("expected the tracking parameters to be removed, got %q", got)
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"platform": "tiktok"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, err := client.Metadata("https://www.tiktok.com/@user/video/123?utm_campaign=share&si=xyz"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package supadata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	headerRequestId   = "X-Request-Id"
+	headerCreditsUsed = "X-Credits-Used"
+)
+
+// Provenance describes where and when a result was fetched, for reproducibility and audits
+type Provenance struct {
+	Endpoint   string    `json:"endpoint"`
+	ParamsHash string    `json:"paramsHash"`
+	APIVersion string    `json:"apiVersion"`
+	SDKVersion string    `json:"sdkVersion"`
+	RequestId  string    `json:"requestId,omitempty"`
+	LineageId  string    `json:"lineageId,omitempty"`
+	FetchedAt  time.Time `json:"fetchedAt"`
+	// Credits is the cost of the call reported by the API, nil when it was not reported or served from the cache
+	Credits *int `json:"credits,omitempty"`
+}
+
+// Envelope pairs a fetched result with its provenance so both can be persisted together
+type Envelope[T any] struct {
+	Provenance Provenance `json:"provenance"`
+	Data       *T         `json:"data"`
+}
+
+// NewEnvelope wraps data with the provenance captured through WithProvenance
+func NewEnvelope[T any](data *T, p Provenance) *Envelope[T] {
+	return &Envelope[T]{Provenance: p, Data: data}
+}
+
+// newProvenance builds the provenance of a request from the request and its response
+func (s *Supadata) newProvenance(req *http.Request, resp *http.Response) Provenance {
+	p := Provenance{
+		Endpoint:   req.Method + " " + s.endpointPath(req),
+		ParamsHash: paramsHash(req),
+		APIVersion: APIVersion,
+		SDKVersion: sdkVersion(),
+		RequestId:  resp.Header.Get(headerRequestId),
+		FetchedAt:  time.Now().UTC(),
+	}
+	if lineage, ok := LineageFromContext(req.Context()); ok {
+		p.LineageId = lineage
+	}
+	if credits, ok := creditsUsed(resp); ok {
+		p.Credits = &credits
+	}
+	return p
+}
+
+// creditsUsed returns the credits charged for a response, when the API reported them
+func creditsUsed(resp *http.Response) (int, bool) {
+	credits, err := strconv.Atoi(resp.Header.Get(headerCreditsUsed))
+	return credits, err == nil
+}
+
+// paramsHash returns a stable SHA-256 of the query string and body of the request
+func paramsHash(req *http.Request) string {
+	h := sha256.New()
+	h.Write([]byte(req.URL.Query().Encode()))
+	h.Write([]byte{'\n'})
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			_, _ = io.Copy(h, body)
+			_ = body.Close()
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
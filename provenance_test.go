@@ -0,0 +1,78 @@
+package supadata
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithProvenance_CapturesResponseDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.Header().Set("X-Credits-Used", "2")
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "abc", "title": "Video"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	var p Provenance
+	video, err := client.YouTubeVideo("abc", WithProvenance(&p))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if p.Endpoint != "GET /youtube/video" {
+		t.Errorf("expected endpoint %q, got %q", "GET /youtube/video", p.Endpoint)
+	}
+	if p.RequestId != "req-123" {
+		t.Errorf("expected requestId %q, got %q", "req-123", p.RequestId)
+	}
+	if p.Credits == nil || *p.Credits != 2 {
+		t.Errorf("expected credits 2, got %v", p.Credits)
+	}
+	if p.APIVersion != APIVersion || p.SDKVersion != Version {
+		t.Errorf("unexpected versions %q/%q", p.APIVersion, p.SDKVersion)
+	}
+	if p.FetchedAt.IsZero() {
+		t.Error("expected fetchedAt to be set")
+	}
+	if p.ParamsHash == "" {
+		t.Error("expected paramsHash to be set")
+	}
+
+	data, err := json.Marshal(NewEnvelope(video, p))
+	if err != nil {
+		t.Fatalf("failed to marshal envelope: %v", err)
+	}
+	var decoded Envelope[YouTubeVideo]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal envelope: %v", err)
+	}
+	if decoded.Data.Id != "abc" || decoded.Provenance.RequestId != "req-123" {
+		t.Errorf("unexpected round-tripped envelope: %+v", decoded)
+	}
+}
+
+func TestWithProvenance_ParamsHash(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{"jobId": "job-1"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	var first, second, other Provenance
+	_, _ = client.Crawl(&CrawlBody{Url: "https://example.com", Limit: 10}, WithProvenance(&first))
+	_, _ = client.Crawl(&CrawlBody{Url: "https://example.com", Limit: 10}, WithProvenance(&second))
+	_, _ = client.Crawl(&CrawlBody{Url: "https://example.com", Limit: 20}, WithProvenance(&other))
+
+	if first.ParamsHash != second.ParamsHash {
+		t.Error("expected identical params to produce the same hash")
+	}
+	if first.ParamsHash == other.ParamsHash {
+		t.Error("expected different params to produce different hashes")
+	}
+	if first.Credits != nil {
+		t.Errorf("expected no credits without header, got %v", *first.Credits)
+	}
+}
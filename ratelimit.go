@@ -0,0 +1,183 @@
+package supadata
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Rate limit headers returned by the API
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// adaptivePacingThreshold is the fraction of the quota below which WithAdaptiveRateLimit starts spacing requests
+const adaptivePacingThreshold = 0.2
+
+// WithRateLimit limits the requests sent to the API to requestsPerSecond, allowing bursts of up to burst requests.
+// Requests served from the cache are not limited. A non-positive rate or burst removes the limit.
+func WithRateLimit(requestsPerSecond float64, burst int) ConfigOption {
+	return func(config *Config) {
+		if !(requestsPerSecond > 0) || burst < 1 {
+			config.limiter = nil
+			return
+		}
+		config.limiter = newRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// rateLimiter is a token bucket shared by every request of a client
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / requestsPerSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// wait reserves a token, blocking until it is available or ctx is done, in which case the token is given back
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+float64(now.Sub(l.last))/float64(l.interval))
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens * float64(l.interval))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	if err := sleepContext(ctx, delay); err != nil {
+		l.mu.Lock()
+		l.tokens = min(l.burst, l.tokens+1)
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// RateLimitInfo is the request quota reported by the API in the rate limit headers of its responses
+type RateLimitInfo struct {
+	// Limit is the number of requests allowed in the current window, zero when not reported
+	Limit     int
+	Remaining int
+	// Reset is when the quota is replenished, zero when not reported
+	Reset time.Time
+}
+
+// WithAdaptiveRateLimit spaces out requests as the quota reported by the API runs low, spreading the remaining
+// requests evenly until the quota resets instead of sending them in a burst that ends in 429 responses.
+// Requests are paced once fewer than a fifth of the requests of the window remain.
+func WithAdaptiveRateLimit() ConfigOption {
+	return func(config *Config) {
+		config.adaptivePacing = true
+	}
+}
+
+// RateLimit returns the quota reported by the last API response carrying rate limit headers, and false when no
+// response carried them yet
+func (s *Supadata) RateLimit() (RateLimitInfo, bool) {
+	return s.quota.get()
+}
+
+// quota tracks the rate limit quota last reported by the API
+type quota struct {
+	mu    sync.Mutex
+	info  RateLimitInfo
+	known bool
+}
+
+func (q *quota) get() (RateLimitInfo, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.info, q.known
+}
+
+// update records the quota reported by the headers of resp, if any
+func (q *quota) update(resp *http.Response) {
+	remaining, err := strconv.Atoi(resp.Header.Get(headerRateLimitRemaining))
+	if err != nil {
+		return
+	}
+	info := RateLimitInfo{Remaining: remaining}
+	if limit, err := strconv.Atoi(resp.Header.Get(headerRateLimitLimit)); err == nil {
+		info.Limit = limit
+	}
+	info.Reset = parseRateLimitReset(resp.Header.Get(headerRateLimitReset), time.Now())
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.info = info
+	q.known = true
+}
+
+// parseRateLimitReset parses a reset header given either as seconds until the reset or as a Unix timestamp
+func parseRateLimitReset(value string, now time.Time) time.Time {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return time.Time{}
+	}
+	// Values larger than a year are Unix timestamps rather than delays
+	if seconds > 365*24*60*60 {
+		return time.Unix(seconds, 0)
+	}
+	return now.Add(time.Duration(seconds) * time.Second)
+}
+
+// pace reserves one request of the quota and returns how long to wait before sending it, so that the remaining
+// requests are spread evenly until the reset once the quota runs low
+func (q *quota) pace(now time.Time) time.Duration {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.known || q.info.Reset.IsZero() || !now.Before(q.info.Reset) {
+		return 0
+	}
+	if q.info.Limit > 0 && float64(q.info.Remaining) >= float64(q.info.Limit)*adaptivePacingThreshold {
+		q.info.Remaining--
+		return 0
+	}
+
+	untilReset := q.info.Reset.Sub(now)
+	if q.info.Remaining <= 0 {
+		return untilReset
+	}
+	delay := untilReset / time.Duration(q.info.Remaining+1)
+	q.info.Remaining--
+	return delay
+}
+
+// send sends req over the network once the rate limiter and the adaptive pacing, if any, allow it
+func (s *Supadata) send(req *http.Request) (*http.Response, error) {
+	if s.config.limiter != nil {
+		if err := s.config.limiter.wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+	if s.config.adaptivePacing {
+		if delay := s.quota.pace(time.Now()); delay > 0 {
+			if err := sleepContext(req.Context(), delay); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	resp, err := s.doKeyRotation(req)
+	if err == nil {
+		s.quota.update(resp)
+	}
+	return resp, err
+}
@@ -0,0 +1,161 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Burst(t *testing.T) {
+	limiter := newRateLimiter(20, 2)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := limiter.wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The first two requests use the burst, the next two wait 50ms each
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected requests to be paced, took %v", elapsed)
+	}
+}
+
+func TestRateLimiter_ContextCancelled(t *testing.T) {
+	limiter := newRateLimiter(0.1, 1)
+	_ = limiter.wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestRateLimiter_CancelledWaitGivesTokenBack(t *testing.T) {
+	limiter := newRateLimiter(10, 1)
+	_ = limiter.wait(context.Background())
+
+	for i := 0; i < 5; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_ = limiter.wait(ctx)
+	}
+
+	// Only the token of the first request is missing, replenished within 100ms
+	start := time.Now()
+	if err := limiter.wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("expected the cancelled waits to give their tokens back, waited %v", elapsed)
+	}
+}
+
+func TestWithRateLimit_NonPositiveDisablesLimit(t *testing.T) {
+	tests := []struct {
+		requestsPerSecond float64
+		burst             int
+	}{
+		{0, 1},
+		{-5, 1},
+		{10, 0},
+		{10, -1},
+	}
+	for _, tt := range tests {
+		client := NewSupadata(WithAPIKey("test-api-key"), WithRateLimit(1, 1), WithRateLimit(tt.requestsPerSecond, tt.burst))
+		if client.config.limiter != nil {
+			t.Errorf("WithRateLimit(%v, %d): expected no limiter", tt.requestsPerSecond, tt.burst)
+		}
+	}
+}
+
+func TestWithRateLimit_SkipsCacheHits(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "abc"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(
+		WithAPIKey("test-api-key"),
+		WithBaseURL(server.URL),
+		WithCache(NewMemoryCache(), time.Minute),
+		WithRateLimit(0.1, 1),
+	)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.YouTubeVideo("abc"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if requests != 1 || time.Since(start) > time.Second {
+		t.Errorf("expected a single unthrottled request, got %d in %v", requests, time.Since(start))
+	}
+}
+
+func TestRateLimit_Headers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "30")
+		jsonResponse(w, http.StatusOK, map[string]any{"plan": "Pro"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, ok := client.RateLimit(); ok {
+		t.Fatal("expected no rate limit before the first response")
+	}
+	if _, err := client.Me(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info, ok := client.RateLimit()
+	if !ok || info.Limit != 100 || info.Remaining != 42 {
+		t.Fatalf("unexpected rate limit %+v", info)
+	}
+	if until := time.Until(info.Reset); until <= 25*time.Second || until > 30*time.Second {
+		t.Errorf("expected a reset in 30s, got %v", until)
+	}
+}
+
+func TestParseRateLimitReset(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	if got := parseRateLimitReset("60", now); !got.Equal(now.Add(time.Minute)) {
+		t.Errorf("expected a delay in seconds, got %v", got)
+	}
+	if got := parseRateLimitReset("1700000100", now); !got.Equal(time.Unix(1_700_000_100, 0)) {
+		t.Errorf("expected a Unix timestamp, got %v", got)
+	}
+	if got := parseRateLimitReset("", now); !got.IsZero() {
+		t.Errorf("expected no reset, got %v", got)
+	}
+}
+
+func TestQuota_Pace(t *testing.T) {
+	now := time.Now()
+	q := quota{known: true, info: RateLimitInfo{Limit: 100, Remaining: 50, Reset: now.Add(10 * time.Second)}}
+	if delay := q.pace(now); delay != 0 {
+		t.Errorf("expected no pacing with a healthy quota, got %v", delay)
+	}
+
+	q.info.Remaining = 4
+	if delay := q.pace(now); delay != 2*time.Second {
+		t.Errorf("expected the remaining requests to be spread until the reset, got %v", delay)
+	}
+	if q.info.Remaining != 3 {
+		t.Errorf("expected the request to be reserved, got %d remaining", q.info.Remaining)
+	}
+
+	q.info.Remaining = 0
+	if delay := q.pace(now); delay != 10*time.Second {
+		t.Errorf("expected to wait for the reset once exhausted, got %v", delay)
+	}
+}
@@ -0,0 +1,133 @@
+package supadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// redactedValue replaces the values of sensitive headers and query parameters
+const redactedValue = "REDACTED"
+
+// redactedHeaders are the headers whose value is hidden on every debug surface
+var redactedHeaders = []string{"x-api-key", "Authorization", "Cookie", "Set-Cookie"}
+
+// redactedParams are the query parameters whose value may carry credentials: the headers and cookies the API
+// forwards to scraped pages, and the credentials of webhook URLs and third-party services
+var redactedParams = []string{"headers", "cookies", "key", "apiKey", "api_key", "token", "access_token", "secret"}
+
+// Redact masks the API keys of the client in text, keeping their last four characters. The log records, HTTP dumps
+// and errors of the client are redacted this way, on top of hiding the x-api-key header and the sensitive query
+// parameters; Redact lets code recording the exchanges of the client, e.g. test fixtures, do the same.
+func (s *Supadata) Redact(text string) string {
+	for _, key := range s.apiKeys() {
+		text = strings.ReplaceAll(text, key, maskKey(key))
+	}
+	return text
+}
+
+// apiKeys returns the API keys of the client, longest first so that no key is partially masked by a shorter one
+func (s *Supadata) apiKeys() []string {
+	keys := slices.DeleteFunc(append([]string{s.config.apiKey}, s.config.apiKeys...), func(key string) bool {
+		return key == ""
+	})
+	slices.SortFunc(keys, func(a, b string) int { return len(b) - len(a) })
+	return keys
+}
+
+// redactHeader returns a copy of h in which the values of the sensitive headers are replaced
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range redactedHeaders {
+		if h.Get(name) != "" {
+			h.Set(name, redactedValue)
+		}
+	}
+	return h
+}
+
+// redactURL returns a copy of u in which the values of the sensitive query parameters and the password are replaced
+func redactURL(u *url.URL) *url.URL {
+	if u == nil {
+		return nil
+	}
+	redacted := *u
+	if _, ok := redacted.User.Password(); ok {
+		redacted.User = url.UserPassword(redacted.User.Username(), redactedValue)
+	}
+	q := redacted.Query()
+	changed := false
+	for key := range q {
+		if isRedactedParam(key) {
+			q.Set(key, redactedValue)
+			changed = true
+		}
+	}
+	if changed {
+		redacted.RawQuery = q.Encode()
+	}
+	return &redacted
+}
+
+// isRedactedParam reports whether the value of the query parameter key is hidden, regardless of case
+func isRedactedParam(key string) bool {
+	return slices.ContainsFunc(redactedParams, func(param string) bool {
+		return strings.EqualFold(param, key)
+	})
+}
+
+// redactTransportError returns a copy of a transport error of the HTTP client with the sensitive parts of its URL
+// replaced
+func redactTransportError(err error) error {
+	urlErr, ok := err.(*url.Error)
+	if !ok {
+		return err
+	}
+	redacted := *urlErr
+	if u, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+		redacted.URL = redactURL(u).String()
+	}
+	return &redacted
+}
+
+// redactJSON returns a copy of the JSON document data in which the values of the sensitive fields, the sensitive
+// query parameters of URL strings and the API keys of the client are replaced
+func (s *Supadata) redactJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s.Redact(string(redacted))), nil
+}
+
+// redactValue replaces the sensitive parts of a decoded JSON value in place
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if isRedactedParam(key) {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(field)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	case string:
+		if u, err := url.Parse(v); err == nil && u.Scheme != "" && u.Host != "" {
+			return redactURL(u).String()
+		}
+	}
+	return value
+}
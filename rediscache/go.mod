@@ -0,0 +1,16 @@
+module github.com/petros0/supadata-go/rediscache
+
+go 1.23
+
+require (
+	github.com/alicebob/miniredis/v2 v2.39.0
+	github.com/petros0/supadata-go v0.1.0
+	github.com/redis/go-redis/v9 v9.18.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
+	go.uber.org/atomic v1.11.0 // indirect
+)
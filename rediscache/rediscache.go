@@ -0,0 +1,83 @@
+// Package rediscache provides a Redis-backed supadata.Cache, so that horizontally scaled services share cached
+// transcripts and metadata. It lives in its own module to keep the Redis client out of the SDK dependencies.
+package rediscache
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	supadata "github.com/petros0/supadata-go"
+)
+
+// DefaultPrefix is the prefix of the keys written by a Cache unless WithPrefix is used
+const DefaultPrefix = "supadata:"
+
+// DefaultTimeout bounds every Redis command issued by a Cache unless WithTimeout is used
+const DefaultTimeout = time.Second
+
+var _ supadata.Cache = (*Cache)(nil)
+
+// Cache is a supadata.Cache storing entries in Redis with their TTL
+type Cache struct {
+	client  redis.UniversalClient
+	prefix  string
+	timeout time.Duration
+}
+
+// Option customizes a Cache
+type Option func(*Cache)
+
+// WithPrefix sets the prefix of the keys written to Redis
+func WithPrefix(prefix string) Option {
+	return func(c *Cache) {
+		c.prefix = prefix
+	}
+}
+
+// WithTimeout bounds every Redis command, after which the command is treated as a cache miss
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Cache) {
+		c.timeout = timeout
+	}
+}
+
+// New creates a Cache using client, which may be a single node, sentinel or cluster client
+func New(client redis.UniversalClient, opts ...Option) *Cache {
+	c := &Cache{
+		client:  client,
+		prefix:  DefaultPrefix,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Get returns the cached value of key, treating Redis errors as misses
+func (c *Cache) Get(key string) ([]byte, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	value, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// Set stores value under key for ttl, or without expiry when ttl is zero. Redis errors are ignored since the cache
+// is best effort; use Ping to check connectivity.
+func (c *Cache) Set(key string, value []byte, ttl time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	_ = c.client.Set(ctx, c.prefix+key, value, max(ttl, 0)).Err()
+}
+
+// Ping returns an error when Redis cannot be reached
+func (c *Cache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
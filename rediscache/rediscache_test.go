@@ -0,0 +1,82 @@
+package rediscache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	supadata "github.com/petros0/supadata-go"
+)
+
+func newTestCache(t *testing.T, opts ...Option) (*Cache, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return New(client, opts...), server
+}
+
+func TestCache_GetSet(t *testing.T) {
+	cache, server := newTestCache(t, WithPrefix("test:"))
+
+	if _, ok := cache.Get("missing"); ok {
+		t.Error("expected miss for missing key")
+	}
+
+	cache.Set("key", []byte("value"), time.Minute)
+	value, ok := cache.Get("key")
+	if !ok || string(value) != "value" {
+		t.Errorf("expected hit with value, got %q (%v)", value, ok)
+	}
+	if !server.Exists("test:key") {
+		t.Error("expected the key to be prefixed")
+	}
+
+	server.FastForward(2 * time.Minute)
+	if _, ok := cache.Get("key"); ok {
+		t.Error("expected expired entry to be a miss")
+	}
+}
+
+func TestCache_Unreachable(t *testing.T) {
+	cache, server := newTestCache(t, WithTimeout(100*time.Millisecond))
+	server.Close()
+
+	cache.Set("key", []byte("value"), time.Minute)
+	if _, ok := cache.Get("key"); ok {
+		t.Error("expected miss when Redis is unreachable")
+	}
+	if err := cache.Ping(context.Background()); err == nil {
+		t.Error("expected an error when Redis is unreachable")
+	}
+}
+
+func TestCache_WithClient(t *testing.T) {
+	calls := 0
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"abc"}`))
+	}))
+	defer api.Close()
+
+	cache, _ := newTestCache(t)
+	client := supadata.NewSupadata(
+		supadata.WithAPIKey("test-api-key"),
+		supadata.WithBaseURL(api.URL),
+		supadata.WithCache(cache, time.Minute),
+	)
+	for i := 0; i < 2; i++ {
+		if _, err := client.YouTubeVideo("abc"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected a single API call, got %d", calls)
+	}
+}
@@ -0,0 +1,173 @@
+package supadata
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// StartedJob describes an asynchronous job started by the client
+type StartedJob struct {
+	Id   string  `json:"id"`
+	Kind JobKind `json:"kind"`
+	// Endpoint is the endpoint the job was submitted to, e.g. "/web/crawl"
+	Endpoint string `json:"endpoint"`
+	// Params holds the JSON encoding of the parameters the job was submitted with, their credentials redacted
+	Params    json.RawMessage `json:"params,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// ErrNoJobRegistry is returned by Jobs when the client was created without WithJobRegistry
+var ErrNoJobRegistry = errors.New("no job registry configured")
+
+// JobRegistry keeps the history of the jobs started by a client. Unlike a JobStore, it keeps finished jobs.
+type JobRegistry interface {
+	Add(job StartedJob) error
+	// List returns every job, oldest first
+	List() ([]StartedJob, error)
+}
+
+// JobFilter selects started jobs. Zero fields do not filter.
+type JobFilter struct {
+	Kind     JobKind
+	Endpoint string
+	// Since and Until bound the creation time of the jobs, Until being excluded
+	Since time.Time
+	Until time.Time
+}
+
+// Match reports whether job passes the filter
+func (f JobFilter) Match(job StartedJob) bool {
+	return (f.Kind == "" || job.Kind == f.Kind) &&
+		(f.Endpoint == "" || job.Endpoint == f.Endpoint) &&
+		(f.Since.IsZero() || !job.CreatedAt.Before(f.Since)) &&
+		(f.Until.IsZero() || job.CreatedAt.Before(f.Until))
+}
+
+// WithJobRegistry records the jobs started by the client in registry, e.g. in a MemoryJobRegistry, or in a
+// FileJobRegistry so that the history survives restarts. Without it, started jobs are not recorded.
+func WithJobRegistry(registry JobRegistry) ConfigOption {
+	return func(config *Config) {
+		config.jobRegistry = registry
+	}
+}
+
+// Jobs returns the jobs started by the client that match filter, oldest first, e.g. the crawls started today with
+// JobFilter{Kind: JobCrawl, Since: midnight}. It returns ErrNoJobRegistry when no registry is configured.
+func (s *Supadata) Jobs(filter JobFilter) ([]StartedJob, error) {
+	if s.config.jobRegistry == nil {
+		return nil, ErrNoJobRegistry
+	}
+	jobs, err := s.config.jobRegistry.List()
+	if err != nil {
+		return nil, err
+	}
+	var matching []StartedJob
+	for _, job := range jobs {
+		if filter.Match(job) {
+			matching = append(matching, job)
+		}
+	}
+	return matching, nil
+}
+
+// recordStartedJob adds a started job to the registry when one is configured
+func (s *Supadata) recordStartedJob(kind JobKind, id, endpoint string, params any) error {
+	if s.config.jobRegistry == nil {
+		return nil
+	}
+	encoded, err := json.Marshal(params)
+	if err == nil {
+		encoded, err = s.redactJSON(encoded)
+	}
+	if err != nil {
+		return fmt.Errorf("recording %s job %s: %w", kind, id, err)
+	}
+	job := StartedJob{Id: id, Kind: kind, Endpoint: endpoint, Params: encoded, CreatedAt: time.Now().UTC()}
+	if err := s.config.jobRegistry.Add(job); err != nil {
+		return fmt.Errorf("recording %s job %s: %w", kind, id, err)
+	}
+	return nil
+}
+
+// MemoryJobRegistry is a JobRegistry keeping jobs in memory. It grows with every started job, so it suits
+// short-lived clients; long-running ones should prefer a FileJobRegistry.
+type MemoryJobRegistry struct {
+	mu   sync.Mutex
+	jobs []StartedJob
+}
+
+// NewMemoryJobRegistry creates an empty MemoryJobRegistry
+func NewMemoryJobRegistry() *MemoryJobRegistry {
+	return &MemoryJobRegistry{}
+}
+
+func (m *MemoryJobRegistry) Add(job StartedJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs = append(m.jobs, job)
+	return nil
+}
+
+func (m *MemoryJobRegistry) List() ([]StartedJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]StartedJob(nil), m.jobs...), nil
+}
+
+// FileJobRegistry is a JobRegistry appending jobs as JSON lines to a file
+type FileJobRegistry struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileJobRegistry creates a FileJobRegistry appending to the file at path, creating its directory when needed
+func NewFileJobRegistry(path string) (*FileJobRegistry, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, err
+	}
+	return &FileJobRegistry{path: path}, nil
+}
+
+func (f *FileJobRegistry) Add(job StartedJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return writeFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, append(data, '\n'))
+}
+
+func (f *FileJobRegistry) List() ([]StartedJob, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, err := os.Open(filepath.Clean(f.path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var jobs []StartedJob
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var job StartedJob
+		if err := json.Unmarshal(scanner.Bytes(), &job); err != nil {
+			return nil, fmt.Errorf("reading job registry %s: %w", f.path, err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, scanner.Err()
+}
@@ -0,0 +1,128 @@
+package supadata
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func jobsServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/web/crawl":
+			jsonResponse(w, http.StatusOK, map[string]any{"jobId": "crawl-1"})
+		case "/youtube/transcript/batch":
+			jsonResponse(w, http.StatusOK, map[string]any{"jobId": "batch-1"})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestJobs(t *testing.T) {
+	server := jobsServer(t)
+	defer server.Close()
+
+	registry, err := NewFileJobRegistry(filepath.Join(t.TempDir(), "jobs", "registry.jsonl"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithJobRegistry(registry))
+	start := time.Now().Add(-time.Second)
+	if _, err := client.Crawl(&CrawlBody{Url: "https://example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.YouTubeTranscriptBatch(&YouTubeTranscriptBatchParams{VideoIds: []string{"a"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	all, err := client.Jobs(JobFilter{Since: start})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != 2 || all[0].Id != "crawl-1" || all[1].Endpoint != "/youtube/transcript/batch" {
+		t.Fatalf("unexpected jobs %+v", all)
+	}
+
+	crawls, err := client.Jobs(JobFilter{Kind: JobCrawl})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(crawls) != 1 {
+		t.Fatalf("expected a single crawl, got %+v", crawls)
+	}
+	var params CrawlBody
+	if err := json.Unmarshal(crawls[0].Params, &params); err != nil || params.Url != "https://example.com" {
+		t.Errorf("expected the crawl params to be recorded, got %s (%v)", crawls[0].Params, err)
+	}
+
+	if later, _ := client.Jobs(JobFilter{Since: time.Now().Add(time.Hour)}); len(later) != 0 {
+		t.Errorf("expected no job in the future, got %+v", later)
+	}
+}
+
+func TestJobs_InMemory(t *testing.T) {
+	server := jobsServer(t)
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithJobRegistry(NewMemoryJobRegistry()))
+	if _, err := client.Crawl(&CrawlBody{Url: "https://example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	jobs, err := client.Jobs(JobFilter{})
+	if err != nil || len(jobs) != 1 || jobs[0].Kind != JobCrawl {
+		t.Errorf("expected the crawl to be registered, got %+v (%v)", jobs, err)
+	}
+}
+
+func TestJobs_NotRecordedByDefault(t *testing.T) {
+	server := jobsServer(t)
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, err := client.Crawl(&CrawlBody{Url: "https://example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if jobs, err := client.Jobs(JobFilter{}); !errors.Is(err, ErrNoJobRegistry) {
+		t.Errorf("expected ErrNoJobRegistry, got %+v (%v)", jobs, err)
+	}
+}
+
+func TestJobs_RedactsParams(t *testing.T) {
+	server := jobsServer(t)
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "registry.jsonl")
+	registry, err := NewFileJobRegistry(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithJobRegistry(registry))
+	body := &CrawlBody{Url: "https://example.com/?ref=test-api-key", WebhookUrl: "https://hooks.example.com/crawl?token=s3cr3t&id=1"}
+	if _, err := client.Crawl(body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(data), "s3cr3t") || strings.Contains(string(data), "test-api-key") {
+		t.Errorf("expected the credentials to be redacted, got %s", data)
+	}
+	jobs, _ := client.Jobs(JobFilter{})
+	var params CrawlBody
+	if err := json.Unmarshal(jobs[0].Params, &params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if params.WebhookUrl != "https://hooks.example.com/crawl?id=1&token=REDACTED" {
+		t.Errorf("unexpected webhook URL %q", params.WebhookUrl)
+	}
+}
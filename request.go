@@ -0,0 +1,57 @@
+package supadata
+
+import "context"
+
+// RequestOption customizes a single API call
+type RequestOption func(*requestConfig)
+
+type requestConfig struct {
+	ctx          context.Context
+	provenance   *Provenance
+	forceRefresh bool
+	retry        *RetryPolicy
+}
+
+func newRequestConfig(opts []RequestOption) *requestConfig {
+	rc := &requestConfig{}
+	for _, opt := range opts {
+		opt(rc)
+	}
+	return rc
+}
+
+// WithContext sets the context used to carry deadlines and cancellation for the call
+func WithContext(ctx context.Context) RequestOption {
+	return func(rc *requestConfig) {
+		rc.ctx = ctx
+	}
+}
+
+// WithProvenance records the provenance of the call into p once the response is received
+func WithProvenance(p *Provenance) RequestOption {
+	return func(rc *requestConfig) {
+		rc.provenance = p
+	}
+}
+
+// WithForceRefresh bypasses the response and account caches and the transcript store for the call, replacing the
+// cached or stored value with the fresh one
+func WithForceRefresh() RequestOption {
+	return func(rc *requestConfig) {
+		rc.forceRefresh = true
+	}
+}
+
+// WithNoRetry sends the call once, whatever the retry policy of the client
+func WithNoRetry() RequestOption {
+	return func(rc *requestConfig) {
+		rc.retry = &RetryPolicy{}
+	}
+}
+
+// WithRetryPolicy retries the call as described by p instead of the retry policy of the client
+func WithRetryPolicy(p RetryPolicy) RequestOption {
+	return func(rc *requestConfig) {
+		rc.retry = &p
+	}
+}
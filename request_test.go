@@ -0,0 +1,116 @@
+package supadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithContext_Cancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{})
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := newTestClient(server)
+	if _, err := client.Me(WithContext(ctx)); err == nil {
+		t.Fatal("expected error for cancelled context, got nil")
+	}
+}
+
+func TestJobIdsAreEscaped(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		jsonResponse(w, http.StatusOK, map[string]any{"status": "completed"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL+"/v1/"))
+	_, _ = client.TranscriptResult("../../me")
+	_, _ = client.CrawlResult("job?skip=1", 0)
+	_, _ = client.YouTubeBatchResult("a/b")
+
+	expected := []string{"/v1/transcript/..%2F..%2Fme", "/v1/web/crawl/job%3Fskip=1", "/v1/youtube/batch/a%2Fb"}
+	if len(paths) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], paths[i])
+		}
+	}
+
+	for _, jobId := range []string{"", ".", ".."} {
+		if _, err := client.TranscriptResult(jobId); !errors.Is(err, ErrInvalidJobId) {
+			t.Errorf("TranscriptResult(%q) = %v, expected ErrInvalidJobId", jobId, err)
+		}
+	}
+}
+
+func TestDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-key") != "test-api-key" || r.Header.Get("Accept") != "application/json" {
+			t.Errorf("missing default headers on %s: %v", r.Method, r.Header)
+		}
+		switch r.Method {
+		case http.MethodPut, http.MethodPatch:
+			if r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("expected a JSON content type on %s, got %q", r.Method, r.Header.Get("Content-Type"))
+			}
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["name"] != "updated" {
+				t.Errorf("unexpected body %v (%v)", body, err)
+			}
+			jsonResponse(w, http.StatusOK, map[string]any{"id": r.URL.Path, "query": r.URL.RawQuery})
+		case http.MethodDelete:
+			if r.Header.Get("Content-Type") != "" {
+				t.Errorf("expected no content type without a body, got %q", r.Header.Get("Content-Type"))
+			}
+			if r.URL.Path == "/web/crawl/missing" {
+				errorResponse(w, http.StatusNotFound, NotFound, "Job not found", "")
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	for _, method := range []string{http.MethodPut, http.MethodPatch} {
+		var out struct {
+			Id    string `json:"id"`
+			Query string `json:"query"`
+		}
+		if err := client.Do(method, "/web/crawl/job-1?force=true", map[string]string{"name": "updated"}, &out); err != nil {
+			t.Fatalf("unexpected error on %s: %v", method, err)
+		}
+		if out.Id != "/web/crawl/job-1" || out.Query != "force=true" {
+			t.Errorf("unexpected response on %s: %+v", method, out)
+		}
+	}
+
+	if err := client.Do(http.MethodDelete, "/web/crawl/job-1", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := client.Do(http.MethodDelete, "/web/crawl/missing", nil, nil)
+	var errResp *ErrorResponse
+	var reqErr *RequestError
+	if !errors.As(err, &errResp) || errResp.ErrorIdentifier != NotFound {
+		t.Errorf("expected the API error, got %v", err)
+	}
+	if !errors.As(err, &reqErr) || reqErr.Method != http.MethodDelete {
+		t.Errorf("expected a RequestError for DELETE, got %v", err)
+	}
+
+	if err := client.Do("TRACE", "/me", nil, nil); !errors.Is(err, ErrUnsupportedMethod) {
+		t.Errorf("expected ErrUnsupportedMethod, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// asyncHints are the phrases of the errors returned when a transcript is too long to be generated synchronously
+var asyncHints = []string{"too long", "use async", "asynchronous"}
+
+// requiresAsync reports whether err rejects a synchronous transcript request that an asynchronous job would accept
+func requiresAsync(err error) bool {
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.ErrorIdentifier != InvalidRequest {
+		return false
+	}
+	text := strings.ToLower(errResp.Message + " " + errResp.Details)
+	for _, hint := range asyncHints {
+		if strings.Contains(text, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveTranscript retrieves the transcript of params whether the API answers synchronously or with a job, in
+// which case the job is waited for. A job that finished with a failed status is reported by its error.
+func (s *Supadata) ResolveTranscript(ctx context.Context, params *TranscriptParams, opts ...WaitOption) (*TranscriptResult, error) {
+	ctx = ensureLineage(ctx)
+	transcript, err := s.Transcript(params, WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if !transcript.IsAsync() {
+		return &TranscriptResult{
+			Status:         Completed,
+			Content:        transcript.Sync.Content,
+			Lang:           transcript.Sync.Lang,
+			AvailableLangs: transcript.Sync.AvailableLangs,
+		}, nil
+	}
+
+	result, err := s.WaitForTranscript(ctx, transcript.Async.JobId, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if result.Status == Failed && result.Error != nil {
+		return result, result.Error
+	}
+	return result, nil
+}
@@ -0,0 +1,81 @@
+package supadata
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTranscript_FallsBackToAsync(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/transcript":
+			if r.URL.Query().Get("async") != "true" {
+				errorResponse(w, http.StatusBadRequest, InvalidRequest, "Video is too long", "Use async mode for long videos")
+				return
+			}
+			jsonResponse(w, http.StatusAccepted, map[string]any{"jobId": "job-1"})
+		case "/transcript/job-1":
+			jsonResponse(w, http.StatusOK, map[string]any{
+				"status":  "completed",
+				"lang":    "en",
+				"content": []map[string]any{{"text": "hello"}},
+			})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	transcript, err := client.Transcript(&TranscriptParams{Url: "https://youtu.be/x"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !transcript.IsAsync() || transcript.Async.JobId != "job-1" {
+		t.Fatalf("expected the request to be resubmitted as a job, got %+v", transcript)
+	}
+
+	result, err := client.ResolveTranscript(context.Background(), &TranscriptParams{Url: "https://youtu.be/x"}, WithPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != Completed || len(result.Content) != 1 || result.Content[0].Text != "hello" {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestResolveTranscript_Sync(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{"lang": "en", "content": []map[string]any{{"text": "hello"}}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	result, err := client.ResolveTranscript(context.Background(), &TranscriptParams{Url: "https://youtu.be/x"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != Completed || result.Lang != "en" || result.Content[0].Text != "hello" {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestTranscript_OtherErrorsAreNotRetried(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		errorResponse(w, http.StatusBadRequest, InvalidRequest, "Invalid URL", "")
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, err := client.Transcript(&TranscriptParams{Url: "x"}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if requests != 1 {
+		t.Errorf("expected a single request, got %d", requests)
+	}
+}
@@ -0,0 +1,189 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultRetryBaseDelay is the delay before the first retry of the default backoff
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	// DefaultRetryMaxDelay bounds the delay between two attempts of the default backoff
+	DefaultRetryMaxDelay = 30 * time.Second
+)
+
+// BackoffFunc returns the delay before retry number attempt, starting at 1, of a call that failed with err
+type BackoffFunc func(attempt int, err error) time.Duration
+
+// RetryableStatusError is the error passed to the BackoffFunc when the API answered with a transient status
+type RetryableStatusError struct {
+	StatusCode int
+	// RetryAfter is the delay requested by the Retry-After header, or zero
+	RetryAfter time.Duration
+}
+
+func (e *RetryableStatusError) Error() string {
+	return fmt.Sprintf("request failed with status %d", e.StatusCode)
+}
+
+// RetryPolicy describes how the calls failing with a connection error, a 429 or a 5xx status are retried.
+// A nil Backoff stands for DefaultBackoff.
+type RetryPolicy struct {
+	MaxRetries int
+	Backoff    BackoffFunc
+}
+
+type retryPolicyKey struct{}
+
+// withRetryPolicy returns a copy of ctx carrying the retry policy of a call
+func withRetryPolicy(ctx context.Context, p *RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, p)
+}
+
+// retryPolicy returns the retry policy of the call of req, falling back to the policy of the client
+func (s *Supadata) retryPolicy(req *http.Request) RetryPolicy {
+	if p, ok := req.Context().Value(retryPolicyKey{}).(*RetryPolicy); ok {
+		return *p
+	}
+	return RetryPolicy{MaxRetries: s.config.maxRetries, Backoff: s.config.backoff}
+}
+
+// WithRetries retries the calls that fail with a connection error, a 429 or a 5xx status up to maxRetries times,
+// waiting between attempts as decided by the backoff, DefaultBackoff unless WithBackoff sets another one.
+// The last response is returned when every attempt fails.
+func WithRetries(maxRetries int) ConfigOption {
+	return func(config *Config) {
+		config.maxRetries = max(maxRetries, 0)
+	}
+}
+
+// WithBackoff sets the delay between the attempts of the calls retried by WithRetries
+func WithBackoff(backoff BackoffFunc) ConfigOption {
+	return func(config *Config) {
+		config.backoff = backoff
+	}
+}
+
+// DefaultBackoff waits for the delay requested by a Retry-After header, or otherwise backs off exponentially from
+// DefaultRetryBaseDelay up to DefaultRetryMaxDelay with full jitter
+func DefaultBackoff(attempt int, err error) time.Duration {
+	var statusErr *RetryableStatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		return statusErr.RetryAfter
+	}
+	return ExponentialBackoff(DefaultRetryBaseDelay, DefaultRetryMaxDelay)(attempt, err)
+}
+
+// ExponentialBackoff returns a backoff doubling from base up to maxDelay, each delay being drawn uniformly between
+// zero and its value
+func ExponentialBackoff(base, maxDelay time.Duration) BackoffFunc {
+	return func(attempt int, _ error) time.Duration {
+		delay := maxDelay
+		if attempt < 32 {
+			delay = min(base<<(attempt-1), maxDelay)
+		}
+		if delay <= 0 {
+			return 0
+		}
+		//nolint:gosec // spreading retries does not need a secure random source
+		return rand.N(delay + 1)
+	}
+}
+
+// ConstantBackoff returns a backoff waiting delay before every retry
+func ConstantBackoff(delay time.Duration) BackoffFunc {
+	return func(int, error) time.Duration {
+		return delay
+	}
+}
+
+// retryable returns the error describing why the outcome of an attempt should be retried, or nil
+func retryable(ctx context.Context, resp *http.Response, err error) error {
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	if retryableStatus(resp.StatusCode) {
+		return &RetryableStatusError{StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+	}
+	return nil
+}
+
+// retryableStatus reports whether a response with status code is worth retrying
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// transientError reports whether err, returned by a call made with ctx, is worth retrying: a connection error, or a
+// 429 or 5xx status. Errors of a done ctx are not.
+func transientError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if code := errorStatusCode(err); code != 0 {
+		return retryableStatus(code)
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// parseRetryAfter parses a Retry-After header given either as seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0)
+	}
+	return 0
+}
+
+// sendRetried sends req, retrying it as configured by WithRetries or by the per-call retry options
+func (s *Supadata) sendRetried(req *http.Request) (*http.Response, error) {
+	policy := s.retryPolicy(req)
+	maxRetries, backoff := max(policy.MaxRetries, 0), policy.Backoff
+	if backoff == nil {
+		backoff = DefaultBackoff
+	}
+
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := s.sendLimited(attemptReq)
+		cause := retryable(req.Context(), resp, err)
+		if cause == nil || attempt == maxRetries || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+		if resp != nil {
+			closeBody(resp.Body)
+		}
+
+		if err := sleepContext(req.Context(), backoff(attempt+1, cause)); err != nil {
+			return nil, err
+		}
+		attemptReq = req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+		s.stats.retries.Add(1)
+	}
+}
@@ -0,0 +1,136 @@
+package supadata
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithRetries_RetriesTransientStatus(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.Header().Set("Retry-After", "7")
+			errorResponse(w, http.StatusServiceUnavailable, InternalError, "Unavailable", "")
+			return
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"id": "x"})
+	}))
+	defer server.Close()
+
+	var attempts []int
+	var retryAfter []time.Duration
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRetries(3),
+		WithBackoff(func(attempt int, err error) time.Duration {
+			attempts = append(attempts, attempt)
+			var statusErr *RetryableStatusError
+			if errors.As(err, &statusErr) {
+				retryAfter = append(retryAfter, statusErr.RetryAfter)
+			}
+			return time.Millisecond
+		}))
+	if _, err := client.YouTubeVideo("x"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 3 || len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Errorf("expected 2 retries, got %d requests and attempts %v", requests, attempts)
+	}
+	if len(retryAfter) != 2 || retryAfter[0] != 7*time.Second {
+		t.Errorf("expected the Retry-After delay to be passed to the backoff, got %v", retryAfter)
+	}
+	if got := client.Stats().Retries; got != 2 {
+		t.Errorf("expected 2 retries in the stats, got %d", got)
+	}
+}
+
+func TestWithRetries_GivesUp(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		errorResponse(w, http.StatusBadGateway, InternalError, "Bad gateway", "")
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRetries(2), WithBackoff(ConstantBackoff(0)))
+	if _, err := client.YouTubeVideo("x"); !HasErrorIdentifier(err, InternalError) {
+		t.Errorf("expected the last error to be returned, got %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 attempts, got %d", requests)
+	}
+}
+
+func TestWithRetries_DoesNotRetryClientErrors(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		errorResponse(w, http.StatusNotFound, NotFound, "Not found", "")
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRetries(2), WithBackoff(ConstantBackoff(0)))
+	if _, err := client.YouTubeVideo("x"); !IsNotFound(err) {
+		t.Errorf("expected not found, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected a single attempt, got %d", requests)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+	for attempt, bound := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second, 64: time.Second} {
+		if got := backoff(attempt, nil); got < 0 || got > bound {
+			t.Errorf("attempt %d: expected a delay up to %s, got %s", attempt, bound, got)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := parseRetryAfter("3", now); got != 3*time.Second {
+		t.Errorf("expected 3s, got %s", got)
+	}
+	if got := parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now); got != time.Minute {
+		t.Errorf("expected 1m, got %s", got)
+	}
+	if got := parseRetryAfter("soon", now); got != 0 {
+		t.Errorf("expected no delay, got %s", got)
+	}
+}
+
+func TestRetryPolicy_PerCall(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		errorResponse(w, http.StatusServiceUnavailable, InternalError, "Unavailable", "")
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRetries(2), WithBackoff(ConstantBackoff(0)))
+	if _, err := client.YouTubeVideo("x", WithNoRetry()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if requests != 1 {
+		t.Errorf("expected WithNoRetry to send a single request, got %d", requests)
+	}
+
+	requests = 0
+	if _, err := client.YouTubeVideo("x", WithRetryPolicy(RetryPolicy{MaxRetries: 4, Backoff: ConstantBackoff(0)})); err == nil {
+		t.Fatal("expected an error")
+	}
+	if requests != 5 {
+		t.Errorf("expected the per-call policy to send 5 requests, got %d", requests)
+	}
+
+	requests = 0
+	if _, err := client.YouTubeVideo("x"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if requests != 3 {
+		t.Errorf("expected the client policy to send 3 requests, got %d", requests)
+	}
+}
@@ -0,0 +1,207 @@
+// Package runner executes recurring tasks using the SDK, such as re-crawling a site every day or refreshing the
+// transcripts of a channel every week. The last run of every task can be persisted in a supadata.JobStore so that
+// a restarted service resumes the schedule instead of running every task again.
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/petros0/supadata-go"
+)
+
+// DefaultShutdownTimeout is how long running tasks may keep running once the runner is stopped
+const DefaultShutdownTimeout = 30 * time.Second
+
+// TaskKind is the kind of the records saving the last run of tasks in the job store
+const TaskKind supadata.JobKind = "task"
+
+// Task is a recurring unit of work
+type Task struct {
+	// Name identifies the task in the job store and logs, and must be unique within a runner
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context, client *supadata.Supadata) error
+	// Immediate runs the task as soon as the runner starts when it has no recorded run, instead of one schedule
+	// step later
+	Immediate bool
+}
+
+// Option customizes a Runner
+type Option func(*Runner)
+
+// WithStore persists the last run of every task in store
+func WithStore(store supadata.JobStore) Option {
+	return func(r *Runner) {
+		r.store = store
+	}
+}
+
+// WithLogger logs the start and outcome of every run
+func WithLogger(logger *slog.Logger) Option {
+	return func(r *Runner) {
+		r.logger = logger
+	}
+}
+
+// WithErrorHandler calls fn with the error of every failed run
+func WithErrorHandler(fn func(task string, err error)) Option {
+	return func(r *Runner) {
+		r.onError = fn
+	}
+}
+
+// WithShutdownTimeout sets how long running tasks may keep running once the runner is stopped, after which their
+// context is cancelled
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(r *Runner) {
+		r.shutdownTimeout = timeout
+	}
+}
+
+// Runner runs tasks on their schedule
+type Runner struct {
+	client          *supadata.Supadata
+	tasks           []Task
+	store           supadata.JobStore
+	logger          *slog.Logger
+	onError         func(task string, err error)
+	shutdownTimeout time.Duration
+	now             func() time.Time
+}
+
+// New creates a Runner whose tasks use client
+func New(client *supadata.Supadata, opts ...Option) *Runner {
+	r := &Runner{
+		client:          client,
+		shutdownTimeout: DefaultShutdownTimeout,
+		now:             time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Add registers a task. Tasks must be added before Run is called.
+func (r *Runner) Add(task Task) {
+	r.tasks = append(r.tasks, task)
+}
+
+// Run runs the tasks on their schedule until ctx is done. Runs in progress are then given the shutdown timeout to
+// complete before their context is cancelled, and Run returns once they have all returned. Runs failing after their
+// context was cancelled are not recorded in the store, so that they run again once the service restarts.
+func (r *Runner) Run(ctx context.Context) error {
+	names := make(map[string]bool, len(r.tasks))
+	for _, task := range r.tasks {
+		if task.Name == "" || names[task.Name] {
+			return fmt.Errorf("runner: task names must be unique and not empty, got %q", task.Name)
+		}
+		names[task.Name] = true
+	}
+
+	// Runs keep their own context so that they are not interrupted as soon as the runner stops
+	runCtx, cancelRuns := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelRuns()
+
+	var wg sync.WaitGroup
+	for _, task := range r.tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.loop(ctx, runCtx, task)
+		}()
+	}
+
+	<-ctx.Done()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(r.shutdownTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		cancelRuns()
+		<-done
+	}
+	return nil
+}
+
+// loop runs task whenever it is due until ctx is done
+func (r *Runner) loop(ctx, runCtx context.Context, task Task) {
+	last, known := r.lastRun(task.Name)
+	for {
+		next := r.now()
+		if known || !task.Immediate {
+			next = task.Schedule.Next(last)
+		}
+		known = true
+
+		timer := time.NewTimer(next.Sub(r.now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		last = r.now()
+		r.runOnce(runCtx, task, last)
+	}
+}
+
+// runOnce runs task, recording its run in the store
+func (r *Runner) runOnce(ctx context.Context, task Task, started time.Time) {
+	if r.logger != nil {
+		r.logger.Info("running task", slog.String("task", task.Name))
+	}
+
+	err := task.Run(supadata.WithLineage(ctx, "task-"+task.Name), r.client)
+	if err != nil && ctx.Err() != nil {
+		// The run was cut short by the shutdown timeout: it is not recorded so that it runs again after a restart
+		if r.logger != nil {
+			r.logger.Warn("task interrupted", slog.String("task", task.Name), slog.String("error", err.Error()))
+		}
+		return
+	}
+	if r.store != nil {
+		record := supadata.JobRecord{Id: recordId(task.Name), Kind: TaskKind, CreatedAt: started.UTC()}
+		err = errors.Join(err, r.store.Save(record))
+	}
+
+	if r.logger != nil {
+		if err != nil {
+			r.logger.Error("task failed", slog.String("task", task.Name), slog.String("error", err.Error()))
+		} else {
+			r.logger.Info("task completed", slog.String("task", task.Name), slog.Duration("duration", r.now().Sub(started)))
+		}
+	}
+	if err != nil && r.onError != nil {
+		r.onError(task.Name, err)
+	}
+}
+
+// lastRun returns the time of the last run of a task saved in the store, or the current time when unknown so that
+// the first run happens one schedule step from now
+func (r *Runner) lastRun(name string) (time.Time, bool) {
+	if r.store == nil {
+		return r.now(), false
+	}
+	record, err := r.store.Load(recordId(name))
+	if err != nil {
+		return r.now(), false
+	}
+	return record.CreatedAt, true
+}
+
+func recordId(name string) string {
+	return "task:" + name
+}
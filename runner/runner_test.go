@@ -0,0 +1,160 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/petros0/supadata-go"
+)
+
+func TestSchedules(t *testing.T) {
+	last := time.Date(2025, 3, 14, 10, 30, 0, 0, time.UTC) // a Friday
+
+	if next := Every(time.Hour).Next(last); !next.Equal(last.Add(time.Hour)) {
+		t.Errorf("unexpected Every next run %v", next)
+	}
+	if next := Daily(11, 0, nil).Next(last); !next.Equal(time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected Daily next run later today %v", next)
+	}
+	if next := Daily(9, 0, nil).Next(last); !next.Equal(time.Date(2025, 3, 15, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected Daily next run tomorrow %v", next)
+	}
+	if next := Weekly(time.Monday, 6, 0, nil).Next(last); !next.Equal(time.Date(2025, 3, 17, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected Weekly next run %v", next)
+	}
+}
+
+func TestRunner_RunsTasksAndRecordsRuns(t *testing.T) {
+	store := supadata.NewMemoryJobStore()
+	var runs atomic.Int32
+	var failures atomic.Int32
+	r := New(supadata.NewSupadata(supadata.WithAPIKey("test-api-key")),
+		WithStore(store),
+		WithErrorHandler(func(task string, err error) {
+			if task != "failing" {
+				t.Errorf("unexpected failing task %s", task)
+			}
+			failures.Add(1)
+		}),
+	)
+	r.Add(Task{
+		Name:     "recrawl",
+		Schedule: Every(10 * time.Millisecond),
+		Run: func(ctx context.Context, client *supadata.Supadata) error {
+			if lineage, _ := supadata.LineageFromContext(ctx); lineage != "task-recrawl" {
+				t.Errorf("unexpected lineage %q", lineage)
+			}
+			runs.Add(1)
+			return nil
+		},
+	})
+	r.Add(Task{
+		Name:      "failing",
+		Schedule:  Every(time.Hour),
+		Immediate: true,
+		Run: func(ctx context.Context, client *supadata.Supadata) error {
+			return errors.New("boom")
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := r.Run(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if runs.Load() < 3 {
+		t.Errorf("expected several runs, got %d", runs.Load())
+	}
+	if failures.Load() != 1 {
+		t.Errorf("expected the immediate task to fail once, got %d", failures.Load())
+	}
+	record, err := store.Load("task:recrawl")
+	if err != nil || record.Kind != TaskKind {
+		t.Errorf("expected the last run to be recorded, got %+v (%v)", record, err)
+	}
+}
+
+func TestRunner_ResumesSchedule(t *testing.T) {
+	store := supadata.NewMemoryJobStore()
+	_ = store.Save(supadata.JobRecord{Id: "task:daily", Kind: TaskKind, CreatedAt: time.Now()})
+
+	var runs atomic.Int32
+	r := New(nil, WithStore(store))
+	r.Add(Task{
+		Name:      "daily",
+		Schedule:  Every(24 * time.Hour),
+		Immediate: true,
+		Run: func(ctx context.Context, client *supadata.Supadata) error {
+			runs.Add(1)
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	_ = r.Run(ctx)
+
+	if runs.Load() != 0 {
+		t.Errorf("expected the recently run task not to run again, got %d runs", runs.Load())
+	}
+}
+
+func TestRunner_GracefulShutdown(t *testing.T) {
+	var interrupted atomic.Bool
+	started := make(chan struct{})
+	store := supadata.NewMemoryJobStore()
+	var failed atomic.Bool
+	r := New(nil,
+		WithShutdownTimeout(20*time.Millisecond),
+		WithStore(store),
+		WithErrorHandler(func(string, error) { failed.Store(true) }),
+	)
+	r.Add(Task{
+		Name:      "slow",
+		Schedule:  Every(time.Hour),
+		Immediate: true,
+		Run: func(ctx context.Context, client *supadata.Supadata) error {
+			close(started)
+			<-ctx.Done()
+			interrupted.Store(true)
+			return ctx.Err()
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	start := time.Now()
+	if err := r.Run(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !interrupted.Load() {
+		t.Error("expected the running task to be interrupted after the shutdown timeout")
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected the task to be given the shutdown timeout, returned after %v", elapsed)
+	}
+	// The interrupted run is neither recorded nor reported as a failure, so that it runs again after a restart
+	if _, err := store.Load(recordId("slow")); !errors.Is(err, supadata.ErrJobNotFound) {
+		t.Errorf("expected the interrupted run not to be recorded, got %v", err)
+	}
+	if failed.Load() {
+		t.Error("expected the interrupted run not to be reported as a failure")
+	}
+}
+
+func TestRunner_DuplicateNames(t *testing.T) {
+	r := New(nil)
+	r.Add(Task{Name: "a", Schedule: Every(time.Hour)})
+	r.Add(Task{Name: "a", Schedule: Every(time.Hour)})
+	if err := r.Run(context.Background()); err == nil {
+		t.Error("expected an error for duplicate task names")
+	}
+}
@@ -0,0 +1,50 @@
+package runner
+
+import "time"
+
+// Schedule decides when a task runs next
+type Schedule interface {
+	// Next returns the time of the first run strictly after last
+	Next(last time.Time) time.Time
+}
+
+// ScheduleFunc adapts a function to the Schedule interface
+type ScheduleFunc func(last time.Time) time.Time
+
+func (f ScheduleFunc) Next(last time.Time) time.Time {
+	return f(last)
+}
+
+// Every runs a task at a fixed interval
+func Every(interval time.Duration) Schedule {
+	return ScheduleFunc(func(last time.Time) time.Time {
+		return last.Add(interval)
+	})
+}
+
+// Daily runs a task every day at hour:minute in loc, or UTC when loc is nil
+func Daily(hour, minute int, loc *time.Location) Schedule {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return ScheduleFunc(func(last time.Time) time.Time {
+		last = last.In(loc)
+		next := time.Date(last.Year(), last.Month(), last.Day(), hour, minute, 0, 0, loc)
+		if !next.After(last) {
+			next = next.AddDate(0, 0, 1)
+		}
+		return next
+	})
+}
+
+// Weekly runs a task every week on day at hour:minute in loc, or UTC when loc is nil
+func Weekly(day time.Weekday, hour, minute int, loc *time.Location) Schedule {
+	daily := Daily(hour, minute, loc)
+	return ScheduleFunc(func(last time.Time) time.Time {
+		next := daily.Next(last)
+		for next.Weekday() != day {
+			next = daily.Next(next)
+		}
+		return next
+	})
+}
@@ -0,0 +1,181 @@
+package supadata
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scope identifies a group of API capabilities that an API key may be allowed to use
+type Scope string
+
+const (
+	ScopeTranscript Scope = "transcript"
+	ScopeMetadata   Scope = "metadata"
+	ScopeWeb        Scope = "web"
+	ScopeYouTube    Scope = "youtube"
+	ScopeAccount    Scope = "account"
+)
+
+// ErrMissingScope is matched by errors.Is when the API key lacks the scope required by a call
+var ErrMissingScope = errors.New("api key is missing the required scope")
+
+// MissingScopeError is returned when the API key lacks the scope required by a call
+type MissingScopeError struct {
+	Scope Scope
+	// Cause is the forbidden response that revealed the missing scope
+	Cause *ErrorResponse
+}
+
+func (e *MissingScopeError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrMissingScope, e.Scope)
+}
+
+func (e *MissingScopeError) Is(target error) bool {
+	return target == ErrMissingScope
+}
+
+func (e *MissingScopeError) Unwrap() error {
+	if e.Cause == nil {
+		return nil
+	}
+	return e.Cause
+}
+
+// missingScopeTTL is how long a scope found missing is remembered, so that a key updated in the meantime is used
+// again
+const missingScopeTTL = 10 * time.Minute
+
+// WithScopeDetection remembers which scopes the API key was forbidden from using and fails further calls
+// requiring them with a *MissingScopeError, without sending the request. A scope is only recorded when the forbidden
+// response mentions a scope, and is forgotten after a while or once a call requiring it succeeds.
+func WithScopeDetection() ConfigOption {
+	return func(config *Config) {
+		config.scopeDetection = true
+	}
+}
+
+// scopeForEndpoint returns the scope required to call the endpoint
+func scopeForEndpoint(endpoint string) Scope {
+	switch {
+	case strings.HasPrefix(endpoint, "/transcript"):
+		return ScopeTranscript
+	case strings.HasPrefix(endpoint, "/metadata"):
+		return ScopeMetadata
+	case strings.HasPrefix(endpoint, "/web/"):
+		return ScopeWeb
+	case strings.HasPrefix(endpoint, "/youtube/"):
+		return ScopeYouTube
+	case strings.HasPrefix(endpoint, "/me"):
+		return ScopeAccount
+	}
+	return ""
+}
+
+// missingScope is a scope found missing, remembered until expires
+type missingScope struct {
+	cause   *ErrorResponse
+	expires time.Time
+}
+
+type scopeMap struct {
+	mu      sync.RWMutex
+	missing map[Scope]missingScope
+}
+
+func (m *scopeMap) get(scope Scope) (*ErrorResponse, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	entry, ok := m.missing[scope]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.cause, true
+}
+
+func (m *scopeMap) set(scope Scope, cause *ErrorResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.missing == nil {
+		m.missing = make(map[Scope]missingScope)
+	}
+	m.missing[scope] = missingScope{cause: cause, expires: time.Now().Add(missingScopeTTL)}
+}
+
+func (m *scopeMap) clear(scope Scope) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.missing, scope)
+}
+
+// HasScope reports whether the API key may use the scope, as far as the client has observed
+func (s *Supadata) HasScope(scope Scope) bool {
+	_, missing := s.scopes.get(scope)
+	return !missing
+}
+
+// MissingScopes returns the scopes the API key was found to lack, sorted by name
+func (s *Supadata) MissingScopes() []Scope {
+	s.scopes.mu.RLock()
+	defer s.scopes.mu.RUnlock()
+
+	now := time.Now()
+	scopes := make([]Scope, 0, len(s.scopes.missing))
+	for scope, entry := range s.scopes.missing {
+		if !now.After(entry.expires) {
+			scopes = append(scopes, scope)
+		}
+	}
+	sort.Slice(scopes, func(i, j int) bool { return scopes[i] < scopes[j] })
+	return scopes
+}
+
+// checkScope fails fast when the endpoint requires a scope already known to be missing
+func (s *Supadata) checkScope(endpoint string) error {
+	if !s.config.scopeDetection {
+		return nil
+	}
+	scope := scopeForEndpoint(endpoint)
+	if cause, missing := s.scopes.get(scope); missing {
+		return &MissingScopeError{Scope: scope, Cause: cause}
+	}
+	return nil
+}
+
+// detectMissingScope records the scope of the endpoint as missing when the response is forbidden for lack of a
+// scope, and clears it when the response is successful
+func (s *Supadata) detectMissingScope(endpoint string, resp *http.Response) error {
+	if !s.config.scopeDetection {
+		return nil
+	}
+	scope := scopeForEndpoint(endpoint)
+	if scope == "" {
+		return nil
+	}
+	if resp.StatusCode < 400 {
+		s.scopes.clear(scope)
+		return nil
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		return nil
+	}
+
+	_, err := s.handleRawResponse(resp)
+
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) && errResp.ErrorIdentifier == Forbidden && mentionsScope(errResp) {
+		s.scopes.set(scope, errResp)
+		return &MissingScopeError{Scope: scope, Cause: errResp}
+	}
+	return err
+}
+
+// mentionsScope reports whether a forbidden response is about the scopes of the key rather than, e.g., a single
+// resource
+func mentionsScope(errResp *ErrorResponse) bool {
+	return strings.Contains(strings.ToLower(errResp.Message+" "+errResp.Details), "scope")
+}
@@ -0,0 +1,146 @@
+package supadata
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithScopeDetection(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path == "/web/scrape" {
+			errorResponse(w, http.StatusForbidden, Forbidden, "API key is missing the web scope", "")
+			return
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"plan": "pro"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(
+		WithAPIKey("test-api-key"),
+		WithBaseURL(server.URL),
+		WithScopeDetection(),
+	)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Scrape(&ScrapeParams{Url: "https://example.com"})
+		if !errors.Is(err, ErrMissingScope) {
+			t.Fatalf("expected ErrMissingScope, got %v", err)
+		}
+		var scopeErr *MissingScopeError
+		if !errors.As(err, &scopeErr) || scopeErr.Scope != ScopeWeb {
+			t.Errorf("expected missing scope %q, got %v", ScopeWeb, err)
+		}
+		var apiErr *ErrorResponse
+		if !errors.As(err, &apiErr) || apiErr.ErrorIdentifier != Forbidden {
+			t.Errorf("expected underlying forbidden error, got %v", err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected forbidden scope to be requested once, got %d calls", got)
+	}
+
+	if client.HasScope(ScopeWeb) {
+		t.Error("expected web scope to be missing")
+	}
+	if !client.HasScope(ScopeAccount) {
+		t.Error("expected account scope to be available")
+	}
+	if _, err := client.Me(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := client.MissingScopes(); len(got) != 1 || got[0] != ScopeWeb {
+		t.Errorf("expected missing scopes [web], got %v", got)
+	}
+}
+
+func TestWithScopeDetection_OtherForbiddenResponses(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		errorResponse(w, http.StatusForbidden, Forbidden, "This page is private", "")
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithScopeDetection())
+	for i := 0; i < 2; i++ {
+		_, err := client.Scrape(&ScrapeParams{Url: "https://example.com"})
+		if errors.Is(err, ErrMissingScope) || !HasErrorIdentifier(err, Forbidden) {
+			t.Fatalf("expected a plain forbidden error, got %v", err)
+		}
+	}
+	if calls.Load() != 2 || !client.HasScope(ScopeWeb) {
+		t.Errorf("expected the scope not to be recorded, got %d calls", calls.Load())
+	}
+}
+
+func TestWithScopeDetection_Expires(t *testing.T) {
+	forbidden := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if forbidden {
+			errorResponse(w, http.StatusForbidden, Forbidden, "Missing scope: web", "")
+			return
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"url": "https://example.com"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithScopeDetection())
+	if _, err := client.Scrape(&ScrapeParams{Url: "https://example.com"}); !errors.Is(err, ErrMissingScope) {
+		t.Fatalf("expected ErrMissingScope, got %v", err)
+	}
+
+	// The key gains the scope, and the recorded one expires
+	forbidden = false
+	client.scopes.mu.Lock()
+	entry := client.scopes.missing[ScopeWeb]
+	entry.expires = time.Now().Add(-time.Second)
+	client.scopes.missing[ScopeWeb] = entry
+	client.scopes.mu.Unlock()
+
+	if _, err := client.Scrape(&ScrapeParams{Url: "https://example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(client.scopes.missing) != 0 {
+		t.Errorf("expected the successful call to clear the scope, got %v", client.scopes.missing)
+	}
+}
+
+func TestWithScopeDetection_Disabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorResponse(w, http.StatusForbidden, Forbidden, "Forbidden", "")
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.Scrape(&ScrapeParams{Url: "https://example.com"})
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("expected *ErrorResponse, got %T", err)
+	}
+	if !client.HasScope(ScopeWeb) {
+		t.Error("expected scopes not to be tracked without scope detection")
+	}
+}
+
+func TestScopeForEndpoint(t *testing.T) {
+	tests := map[string]Scope{
+		"/transcript":          ScopeTranscript,
+		"/transcript/job-1":    ScopeTranscript,
+		"/metadata":            ScopeMetadata,
+		"/web/crawl/job-1":     ScopeWeb,
+		"/youtube/batch/job-1": ScopeYouTube,
+		"/me":                  ScopeAccount,
+		"/something-else":      "",
+	}
+	for endpoint, expected := range tests {
+		if got := scopeForEndpoint(endpoint); got != expected {
+			t.Errorf("scopeForEndpoint(%q) = %q, expected %q", endpoint, got, expected)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package supadata
+
+// YouTubeSearchVideo is a video result of a YouTube search
+type YouTubeSearchVideo struct {
+	Id          string
+	Title       string
+	Description string
+	Thumbnails  Thumbnails
+	// Duration is the length of the video in seconds
+	Duration    int
+	ViewCount   *int
+	UploadDate  string
+	ChannelId   string
+	ChannelName string
+}
+
+// YouTubeSearchChannel is a channel result of a YouTube search
+type YouTubeSearchChannel struct {
+	Id              string
+	Name            string
+	Description     string
+	Thumbnails      Thumbnails
+	SubscriberCount *int
+	VideoCount      *int
+}
+
+// YouTubeSearchPlaylist is a playlist result of a YouTube search
+type YouTubeSearchPlaylist struct {
+	Id          string
+	Title       string
+	Description string
+	Thumbnails  Thumbnails
+	VideoCount  *int
+	ChannelId   string
+	ChannelName string
+}
+
+// Videos returns the video results, in the order of the search
+func (r *YouTubeSearchResult) Videos() []YouTubeSearchVideo {
+	var videos []YouTubeSearchVideo
+	for _, item := range r.Results {
+		if item.Type == string(SearchTypeVideo) {
+			videos = append(videos, YouTubeSearchVideo{
+				Id:          item.Id,
+				Title:       item.Title,
+				Description: item.Description,
+				Thumbnails:  item.Thumbnails,
+				Duration:    item.Duration,
+				ViewCount:   item.ViewCount,
+				UploadDate:  item.UploadDate,
+				ChannelId:   item.ChannelId,
+				ChannelName: item.ChannelName,
+			})
+		}
+	}
+	return videos
+}
+
+// Channels returns the channel results, in the order of the search. The name of a channel is the title of its result.
+func (r *YouTubeSearchResult) Channels() []YouTubeSearchChannel {
+	var channels []YouTubeSearchChannel
+	for _, item := range r.Results {
+		if item.Type == string(SearchTypeChannel) {
+			channels = append(channels, YouTubeSearchChannel{
+				Id:              item.Id,
+				Name:            item.Title,
+				Description:     item.Description,
+				Thumbnails:      item.Thumbnails,
+				SubscriberCount: item.SubscriberCount,
+				VideoCount:      item.VideoCount,
+			})
+		}
+	}
+	return channels
+}
+
+// Playlists returns the playlist results, in the order of the search
+func (r *YouTubeSearchResult) Playlists() []YouTubeSearchPlaylist {
+	var playlists []YouTubeSearchPlaylist
+	for _, item := range r.Results {
+		if item.Type == string(SearchTypePlaylist) {
+			playlists = append(playlists, YouTubeSearchPlaylist{
+				Id:          item.Id,
+				Title:       item.Title,
+				Description: item.Description,
+				Thumbnails:  item.Thumbnails,
+				VideoCount:  item.VideoCount,
+				ChannelId:   item.ChannelId,
+				ChannelName: item.ChannelName,
+			})
+		}
+	}
+	return playlists
+}
+
+// searchResultKey identifies a search result across pages, the same ID possibly naming results of different types
+func searchResultKey(item *YouTubeSearchResultItem) string {
+	return item.Type + ":" + item.Id
+}
@@ -0,0 +1,37 @@
+package supadata
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestYouTubeSearchResult_TypedAccessors(t *testing.T) {
+	var result YouTubeSearchResult
+	err := json.Unmarshal([]byte(`{"query": "go", "results": [
+		{"type": "video", "id": "dQw4w9WgXcQ", "title": "Never Gonna Give You Up", "duration": 213, "channelId": "UC1", "channelName": "Rick Astley"},
+		{"type": "channel", "id": "UC1", "title": "Rick Astley", "subscriberCount": 4000000},
+		{"type": "playlist", "id": "PL1", "title": "Hits", "videoCount": 12, "channelName": "Rick Astley"},
+		{"type": "video", "id": "yPYZpwSpKmA", "title": "Together Forever"},
+		{"type": "movie", "id": "m1", "title": "A movie"}
+	]}`), &result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	videos := result.Videos()
+	if len(videos) != 2 || videos[0].Duration != 213 || videos[0].ChannelName != "Rick Astley" || videos[1].Id != "yPYZpwSpKmA" {
+		t.Errorf("unexpected videos %+v", videos)
+	}
+	channels := result.Channels()
+	if len(channels) != 1 || channels[0].Name != "Rick Astley" || *channels[0].SubscriberCount != 4000000 {
+		t.Errorf("unexpected channels %+v", channels)
+	}
+	playlists := result.Playlists()
+	if len(playlists) != 1 || playlists[0].Id != "PL1" || *playlists[0].VideoCount != 12 {
+		t.Errorf("unexpected playlists %+v", playlists)
+	}
+
+	if empty := (&YouTubeSearchResult{}).Videos(); empty != nil {
+		t.Errorf("expected no videos, got %+v", empty)
+	}
+}
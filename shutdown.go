@@ -0,0 +1,88 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrClientClosed is returned by the calls made after Close, and by the waiters and watchers it interrupted
+var ErrClientClosed = errors.New("client closed")
+
+// shutdown tracks the closing of the client and the background goroutines it must wait for
+type shutdown struct {
+	once   sync.Once
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+
+	mu     sync.Mutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// context returns the context cancelled by Close
+func (sd *shutdown) context() context.Context {
+	sd.once.Do(func() {
+		sd.ctx, sd.cancel = context.WithCancelCause(context.Background())
+	})
+	return sd.ctx
+}
+
+// start registers a background goroutine, reporting false when the client is already closed
+func (sd *shutdown) start() bool {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	if sd.closed {
+		return false
+	}
+	sd.wg.Add(1)
+	return true
+}
+
+// isClosed reports whether Close was called
+func (sd *shutdown) isClosed() bool {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	return sd.closed
+}
+
+// Close shuts the client down for a deterministic stop, e.g. during a deploy. Waiters and job watchers stop polling
+// and return ErrClientClosed, the requests they have in flight being cancelled, and calls made afterwards fail with
+// ErrClientClosed. Other requests in flight are left to complete. Close returns once every job watcher has exited,
+// after closing the idle connections of the HTTP client. Finished jobs have already been removed from the job
+// store, so the jobs still recorded there are the ones to resume after a restart.
+func (s *Supadata) Close() error {
+	s.shutdown.context()
+	s.shutdown.mu.Lock()
+	s.shutdown.closed = true
+	s.shutdown.mu.Unlock()
+
+	s.shutdown.cancel(ErrClientClosed)
+	s.shutdown.wg.Wait()
+	s.config.client.CloseIdleConnections()
+	return nil
+}
+
+// bindClose returns a context derived from ctx that is also cancelled, with ErrClientClosed as cause, when the
+// client is closed. The returned function releases it.
+func (s *Supadata) bindClose(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(s.shutdown.context(), func() {
+		cancel(ErrClientClosed)
+	})
+	return ctx, func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
+
+// interrupted returns err, marked with the cause of the cancellation of ctx when ctx was cancelled for another
+// reason than its own error, e.g. ErrClientClosed
+func interrupted(ctx context.Context, err error) error {
+	cause := context.Cause(ctx)
+	if err == nil || cause == nil || errors.Is(err, cause) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", cause, err)
+}
@@ -0,0 +1,81 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClose_InterruptsWaitersAndWatchers(t *testing.T) {
+	polled := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polled <- struct{}{}
+		jsonResponse(w, http.StatusOK, map[string]any{"status": "active"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	waitErr := make(chan error, 1)
+	go func() {
+		_, err := client.WaitForTranscript(context.Background(), "job-1", WithPollInterval(time.Hour))
+		waitErr <- err
+	}()
+	events := client.WatchJob(context.Background(), JobRecord{Id: "job-2", Kind: JobTranscript}, WithPollInterval(time.Hour))
+	if event := <-events; event.Status != string(Active) {
+		t.Fatalf("unexpected first event %+v", event)
+	}
+	<-polled
+	<-polled
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The watcher has exited once Close returns
+	select {
+	case event, ok := <-events:
+		if ok {
+			t.Errorf("expected the watcher to stop, got %+v", event)
+		}
+	default:
+		t.Error("expected the events channel to be closed when Close returns")
+	}
+	select {
+	case err := <-waitErr:
+		if !errors.Is(err, ErrClientClosed) {
+			t.Errorf("expected ErrClientClosed from the waiter, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the waiter to be interrupted by Close")
+	}
+}
+
+func TestClose_RejectsLaterCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request after Close")
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	if err := client.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Fatalf("expected Close to be idempotent, got %v", err)
+	}
+
+	if _, err := client.Me(); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("expected ErrClientClosed, got %v", err)
+	}
+	event, ok := <-client.WatchJob(context.Background(), JobRecord{Id: "job-1", Kind: JobCrawl})
+	if !ok || !errors.Is(event.Err, ErrClientClosed) || event.JobId != "job-1" {
+		t.Errorf("expected an ErrClientClosed event, got %+v", event)
+	}
+	results := client.FetchTranscripts(context.Background(), []string{"dQw4w9WgXcQ"}, nil)
+	if !errors.Is(results[0].Err, ErrClientClosed) {
+		t.Errorf("expected ErrClientClosed for the fetched transcript, got %v", results[0].Err)
+	}
+}
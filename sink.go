@@ -0,0 +1,275 @@
+package supadata
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// OutputSink receives the records produced by bulk and export helpers. Adding an output format only requires
+// a new OutputSink implementation.
+type OutputSink interface {
+	WriteTranscript(videoId string, transcript *YouTubeTranscriptResult) error
+	WritePage(page *CrawlPage) error
+	WriteVideo(video *YouTubeVideo) error
+	// Close flushes buffered records. It closes the resources the sink opened itself, e.g. the file of a
+	// JSONLFileSink, but not the writers or database passed to its constructor.
+	Close() error
+}
+
+// RecordKind identifies the kind of record written to an OutputSink
+type RecordKind string
+
+const (
+	RecordTranscript RecordKind = "transcript"
+	RecordPage       RecordKind = "page"
+	RecordVideo      RecordKind = "video"
+)
+
+// JSONLRecord is a line written by the JSONL sink
+type JSONLRecord struct {
+	Kind    RecordKind      `json:"kind"`
+	VideoId string          `json:"videoId,omitempty"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// JSONLSink writes every record as a JSONLRecord line to a single writer. It is safe for concurrent use.
+type JSONLSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONLSink creates a sink writing JSON Lines to w
+func NewJSONLSink(w io.Writer) *JSONLSink {
+	return &JSONLSink{enc: json.NewEncoder(w)}
+}
+
+func (s *JSONLSink) write(kind RecordKind, videoId string, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(JSONLRecord{Kind: kind, VideoId: videoId, Data: raw})
+}
+
+func (s *JSONLSink) WriteTranscript(videoId string, transcript *YouTubeTranscriptResult) error {
+	return s.write(RecordTranscript, videoId, transcript)
+}
+
+func (s *JSONLSink) WritePage(page *CrawlPage) error {
+	return s.write(RecordPage, "", page)
+}
+
+func (s *JSONLSink) WriteVideo(video *YouTubeVideo) error {
+	return s.write(RecordVideo, video.Id, video)
+}
+
+func (s *JSONLSink) Close() error {
+	return nil
+}
+
+// JSONLFileSink appends every record as a JSONLRecord line to a file, syncing it to disk after each record so that
+// the records written survive a crash during a long job. A line left incomplete by a crash is removed when the file
+// is opened again. It is safe for concurrent use.
+type JSONLFileSink struct {
+	file *os.File
+	sink *JSONLSink
+}
+
+// NewJSONLFileSink opens the file at path for appending, creating it when missing
+func NewJSONLFileSink(path string) (*JSONLFileSink, error) {
+	file, err := os.OpenFile(filepath.Clean(path), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := trimPartialLine(file); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &JSONLFileSink{file: file, sink: NewJSONLSink(file)}, nil
+}
+
+// trimPartialLine truncates the file after its last complete line and moves the offset to its end
+func trimPartialLine(file *os.File) error {
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	end := size
+	buf := make([]byte, 4096)
+	for end > 0 {
+		n := int64(len(buf))
+		if end < n {
+			n = end
+		}
+		if _, err := file.ReadAt(buf[:n], end-n); err != nil {
+			return err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			end = end - n + int64(i) + 1
+			break
+		}
+		end -= n
+	}
+	if end == size {
+		return nil
+	}
+	if err := file.Truncate(end); err != nil {
+		return err
+	}
+	_, err = file.Seek(end, io.SeekStart)
+	return err
+}
+
+// sync flushes the record written to disk
+func (s *JSONLFileSink) sync(err error) error {
+	if err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+func (s *JSONLFileSink) WriteTranscript(videoId string, transcript *YouTubeTranscriptResult) error {
+	return s.sync(s.sink.WriteTranscript(videoId, transcript))
+}
+
+func (s *JSONLFileSink) WritePage(page *CrawlPage) error {
+	return s.sync(s.sink.WritePage(page))
+}
+
+func (s *JSONLFileSink) WriteVideo(video *YouTubeVideo) error {
+	return s.sync(s.sink.WriteVideo(video))
+}
+
+// Close closes the file, which is owned by the sink
+func (s *JSONLFileSink) Close() error {
+	return s.file.Close()
+}
+
+// CSVSinkWriters are the destinations of a CSV sink, one per record kind. Records of a kind whose writer is nil
+// are discarded.
+type CSVSinkWriters struct {
+	Transcripts io.Writer
+	Pages       io.Writer
+	Videos      io.Writer
+}
+
+// CSVSink writes each record kind as CSV with a header row to its own writer. It is safe for concurrent use.
+type CSVSink struct {
+	mu          sync.Mutex
+	transcripts *csvTable
+	pages       *csvTable
+	videos      *csvTable
+}
+
+// NewCSVSink creates a sink writing CSV to the given writers
+func NewCSVSink(writers CSVSinkWriters) *CSVSink {
+	return &CSVSink{
+		transcripts: newCSVTable(writers.Transcripts),
+		pages:       newCSVTable(writers.Pages),
+		videos:      newCSVTable(writers.Videos),
+	}
+}
+
+func (s *CSVSink) WriteTranscript(videoId string, transcript *YouTubeTranscriptResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.transcripts.write(transcriptRow{videoId: videoId, transcript: transcript})
+}
+
+func (s *CSVSink) WritePage(page *CrawlPage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pages.write(*page)
+}
+
+func (s *CSVSink) WriteVideo(video *YouTubeVideo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.videos.write(*video)
+}
+
+func (s *CSVSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errors.Join(s.transcripts.flush(), s.pages.flush(), s.videos.flush())
+}
+
+// csvTable lazily writes a header before the first record
+type csvTable struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func newCSVTable(w io.Writer) *csvTable {
+	if w == nil {
+		return &csvTable{}
+	}
+	return &csvTable{w: csv.NewWriter(w)}
+}
+
+func (t *csvTable) write(record csvRecord) error {
+	if t.w == nil {
+		return nil
+	}
+	if !t.headerWritten {
+		if err := t.w.Write(record.csvHeader()); err != nil {
+			return err
+		}
+		t.headerWritten = true
+	}
+	return t.w.Write(record.csvRecord())
+}
+
+func (t *csvTable) flush() error {
+	if t.w == nil {
+		return nil
+	}
+	t.w.Flush()
+	return t.w.Error()
+}
+
+// transcriptRow is the CSV representation of a transcript written to a sink
+type transcriptRow struct {
+	videoId    string
+	transcript *YouTubeTranscriptResult
+}
+
+func (r transcriptRow) csvHeader() []string {
+	return []string{"videoId", "lang", "availableLangs", "transcript"}
+}
+
+func (r transcriptRow) csvRecord() []string {
+	return []string{
+		r.videoId, r.transcript.Lang, strings.Join(r.transcript.AvailableLangs, " "),
+		joinTranscript(r.transcript.Content),
+	}
+}
+
+func (v YouTubeVideo) csvHeader() []string {
+	return []string{
+		"id", "title", "description", "duration", "channelId", "channelName", "tags", "uploadDate",
+		"viewCount", "likeCount",
+	}
+}
+
+func (v YouTubeVideo) csvRecord() []string {
+	var uploadDate string
+	if v.UploadDate != nil {
+		uploadDate = *v.UploadDate
+	}
+	return []string{
+		v.Id, v.Title, v.Description, strconv.Itoa(v.Duration), v.Channel.Id, v.Channel.Name,
+		strings.Join(v.Tags, " "), uploadDate, formatOptionalInt(v.ViewCount), formatOptionalInt(v.LikeCount),
+	}
+}
@@ -0,0 +1,86 @@
+package supadata
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+var sqliteSchema = []string{
+	`CREATE TABLE IF NOT EXISTS transcripts (
+		video_id TEXT NOT NULL,
+		lang TEXT NOT NULL,
+		text TEXT NOT NULL,
+		data TEXT NOT NULL,
+		PRIMARY KEY (video_id, lang)
+	)`,
+	`CREATE TABLE IF NOT EXISTS pages (
+		url TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL,
+		content TEXT NOT NULL,
+		data TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS videos (
+		id TEXT PRIMARY KEY,
+		title TEXT NOT NULL,
+		channel_id TEXT NOT NULL,
+		data TEXT NOT NULL
+	)`,
+}
+
+// SQLiteSink stores records in the transcripts, pages and videos tables of a SQLite database, replacing
+// existing rows with the same key. The caller opens the database with the SQLite driver of their choice
+// (e.g. modernc.org/sqlite or github.com/mattn/go-sqlite3) and remains responsible for closing it.
+type SQLiteSink struct {
+	db *sql.DB
+}
+
+// NewSQLiteSink creates the sink tables when missing and returns a sink writing to db
+func NewSQLiteSink(db *sql.DB) (*SQLiteSink, error) {
+	for _, stmt := range sqliteSchema {
+		if _, err := db.Exec(stmt); err != nil {
+			return nil, err
+		}
+	}
+	return &SQLiteSink{db: db}, nil
+}
+
+func (s *SQLiteSink) WriteTranscript(videoId string, transcript *YouTubeTranscriptResult) error {
+	data, err := json.Marshal(transcript)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(
+		`INSERT OR REPLACE INTO transcripts (video_id, lang, text, data) VALUES (?, ?, ?, ?)`,
+		videoId, transcript.Lang, joinTranscript(transcript.Content), string(data),
+	)
+	return err
+}
+
+func (s *SQLiteSink) WritePage(page *CrawlPage) error {
+	data, err := json.Marshal(page)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(
+		`INSERT OR REPLACE INTO pages (url, name, description, content, data) VALUES (?, ?, ?, ?, ?)`,
+		page.Url, page.Name, page.Description, page.Content, string(data),
+	)
+	return err
+}
+
+func (s *SQLiteSink) WriteVideo(video *YouTubeVideo) error {
+	data, err := json.Marshal(video)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(
+		`INSERT OR REPLACE INTO videos (id, title, channel_id, data) VALUES (?, ?, ?, ?)`,
+		video.Id, video.Title, video.Channel.Id, string(data),
+	)
+	return err
+}
+
+func (s *SQLiteSink) Close() error {
+	return nil
+}
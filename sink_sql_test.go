@@ -0,0 +1,112 @@
+package supadata
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingDriver is a database/sql driver recording the executed statements and their arguments
+type recordingDriver struct {
+	mu    sync.Mutex
+	execs []recordedExec
+}
+
+type recordedExec struct {
+	query string
+	args  []driver.Value
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) {
+	return &recordingConn{driver: d}, nil
+}
+
+type recordingConn struct {
+	driver *recordingDriver
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return &recordingStmt{conn: c, query: query}, nil
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+type recordingStmt struct {
+	conn  *recordingConn
+	query string
+}
+
+func (s *recordingStmt) Close() error {
+	return nil
+}
+
+func (s *recordingStmt) NumInput() int {
+	return -1
+}
+
+func (s *recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.driver.mu.Lock()
+	defer s.conn.driver.mu.Unlock()
+	s.conn.driver.execs = append(s.conn.driver.execs, recordedExec{query: s.query, args: args})
+	return driver.RowsAffected(1), nil
+}
+
+func (s *recordingStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("queries are not supported")
+}
+
+func TestSQLiteSink(t *testing.T) {
+	rec := &recordingDriver{}
+	sql.Register("recording-sqlite-sink", rec)
+	db, err := sql.Open("recording-sqlite-sink", "")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	sink, err := NewSQLiteSink(db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.WriteTranscript("abc", &YouTubeTranscriptResult{Lang: "en", Content: []TranscriptContent{{Text: "Hi"}}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.WritePage(&CrawlPage{Url: "https://example.com", Name: "Home"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.WriteVideo(&YouTubeVideo{Id: "abc", Title: "Video"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(rec.execs) != len(sqliteSchema)+3 {
+		t.Fatalf("expected %d statements, got %d", len(sqliteSchema)+3, len(rec.execs))
+	}
+	for i := range sqliteSchema {
+		if !strings.HasPrefix(rec.execs[i].query, "CREATE TABLE IF NOT EXISTS") {
+			t.Errorf("expected schema statement, got %q", rec.execs[i].query)
+		}
+	}
+
+	transcript := rec.execs[len(sqliteSchema)]
+	if !strings.Contains(transcript.query, "INTO transcripts") {
+		t.Errorf("expected transcript insert, got %q", transcript.query)
+	}
+	if transcript.args[0] != "abc" || transcript.args[1] != "en" || transcript.args[2] != "Hi" {
+		t.Errorf("unexpected transcript args %v", transcript.args)
+	}
+	if page := rec.execs[len(sqliteSchema)+1]; page.args[0] != "https://example.com" {
+		t.Errorf("unexpected page args %v", page.args)
+	}
+	if video := rec.execs[len(sqliteSchema)+2]; video.args[0] != "abc" || video.args[1] != "Video" {
+		t.Errorf("unexpected video args %v", video.args)
+	}
+}
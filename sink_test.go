@@ -0,0 +1,177 @@
+package supadata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestJSONLSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONLSink(&buf)
+
+	if err := sink.WriteTranscript("abc", &YouTubeTranscriptResult{Lang: "en"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.WritePage(&CrawlPage{Url: "https://example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.WriteVideo(&YouTubeVideo{Id: "def"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	expected := []struct {
+		kind    RecordKind
+		videoId string
+	}{{RecordTranscript, "abc"}, {RecordPage, ""}, {RecordVideo, "def"}}
+	for i, line := range lines {
+		var record JSONLRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("failed to decode line %d: %v", i, err)
+		}
+		if record.Kind != expected[i].kind || record.VideoId != expected[i].videoId {
+			t.Errorf("unexpected record %d: %+v", i, record)
+		}
+	}
+}
+
+func TestJSONLFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.jsonl")
+	sink, err := NewJSONLFileSink(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.WritePage(&CrawlPage{Url: "https://example.com/a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Simulate a crash in the middle of a record
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, _ = f.WriteString(`{"kind":"page","data":{"url":"https://exa`)
+	_ = f.Close()
+
+	sink, err = NewJSONLFileSink(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.WriteVideo(&YouTubeVideo{Id: "dQw4w9WgXcQ"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected the partial line to be dropped, got %q", data)
+	}
+	for i, kind := range []RecordKind{RecordPage, RecordVideo} {
+		var record JSONLRecord
+		if err := json.Unmarshal([]byte(lines[i]), &record); err != nil || record.Kind != kind {
+			t.Errorf("unexpected line %q: %v", lines[i], err)
+		}
+	}
+}
+
+func TestCSVSink(t *testing.T) {
+	var transcripts, pages bytes.Buffer
+	sink := NewCSVSink(CSVSinkWriters{Transcripts: &transcripts, Pages: &pages})
+
+	_ = sink.WriteTranscript("abc", &YouTubeTranscriptResult{
+		Lang:    "en",
+		Content: []TranscriptContent{{Text: "Hello"}, {Text: "world"}},
+	})
+	_ = sink.WriteTranscript("def", &YouTubeTranscriptResult{Lang: "es", AvailableLangs: []string{"es", "en"}})
+	_ = sink.WritePage(&CrawlPage{Url: "https://example.com", Name: "Home"})
+	if err := sink.WriteVideo(&YouTubeVideo{Id: "abc"}); err != nil {
+		t.Fatalf("expected videos to be discarded without error, got %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedTranscripts := "videoId,lang,availableLangs,transcript\n" +
+		"abc,en,,Hello world\n" +
+		"def,es,es en,\n"
+	if transcripts.String() != expectedTranscripts {
+		t.Errorf("unexpected transcripts:\n%s", transcripts.String())
+	}
+	expectedPages := "url,name,description,ogUrl,countCharacters,content\n" +
+		"https://example.com,Home,,,0,\n"
+	if pages.String() != expectedPages {
+		t.Errorf("unexpected pages:\n%s", pages.String())
+	}
+}
+
+func TestDirSink(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewDirSink(dir, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_ = sink.WriteTranscript("abc", &YouTubeTranscriptResult{
+		Lang:    "en",
+		Content: []TranscriptContent{{Text: "Hello"}, {Text: "world"}},
+	})
+	_ = sink.WriteVideo(&YouTubeVideo{Id: "abc", Title: "Video"})
+
+	data, err := os.ReadFile(filepath.Join(dir, "abc.en.txt"))
+	if err != nil || string(data) != "Hello world\n" {
+		t.Errorf("unexpected transcript file %q (err=%v)", data, err)
+	}
+	var video YouTubeVideo
+	data, err = os.ReadFile(filepath.Join(dir, "abc.json"))
+	if err != nil || json.Unmarshal(data, &video) != nil || video.Title != "Video" {
+		t.Errorf("unexpected video file %q (err=%v)", data, err)
+	}
+	if got := len(sink.Files()); got != 2 {
+		t.Errorf("expected 2 files, got %d", got)
+	}
+}
+
+func TestExportCrawl_Sink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"status": "completed",
+			"pages":  []map[string]any{{"url": "https://example.com/a"}, {"url": "https://example.com/b"}},
+		})
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := newTestClient(server)
+	n, err := client.ExportCrawl(context.Background(), "job-1", NewJSONLSink(&buf))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 pages written, got %d", n)
+	}
+	if got := strings.Count(buf.String(), "\n"); got != 2 {
+		t.Errorf("expected 2 lines, got %d", got)
+	}
+}
@@ -0,0 +1,51 @@
+package supadata
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+const (
+	sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+	// MaxSitemapUrls is the maximum number of URLs allowed in a single sitemap by the sitemaps.org protocol
+	MaxSitemapUrls = 50000
+)
+
+type sitemapUrlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Urls    []sitemapUrl `xml:"url"`
+}
+
+type sitemapUrl struct {
+	Loc string `xml:"loc"`
+}
+
+// WriteSitemap writes the discovered URLs as a sitemap.xml document, skipping empty and duplicate entries
+func (r *MapResult) WriteSitemap(w io.Writer) error {
+	set := sitemapUrlSet{Xmlns: sitemapNamespace}
+	seen := make(map[string]bool, len(r.Urls))
+	for _, u := range r.Urls {
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		set.Urls = append(set.Urls, sitemapUrl{Loc: u})
+	}
+	if len(set.Urls) > MaxSitemapUrls {
+		return fmt.Errorf("sitemap has %d urls, exceeding the limit of %d", len(set.Urls), MaxSitemapUrls)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(set); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
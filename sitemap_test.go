@@ -0,0 +1,50 @@
+package supadata
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestMapResult_WriteSitemap(t *testing.T) {
+	result := &MapResult{Urls: []string{
+		"https://example.com/",
+		"https://example.com/docs?a=1&b=2",
+		"",
+		"https://example.com/",
+	}}
+
+	var buf bytes.Buffer
+	if err := result.WriteSitemap(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url>
+    <loc>https://example.com/</loc>
+  </url>
+  <url>
+    <loc>https://example.com/docs?a=1&amp;b=2</loc>
+  </url>
+</urlset>
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected sitemap:\n%s", buf.String())
+	}
+}
+
+func TestMapResult_WriteSitemap_TooManyUrls(t *testing.T) {
+	result := &MapResult{}
+	for i := 0; i <= MaxSitemapUrls; i++ {
+		result.Urls = append(result.Urls, fmt.Sprintf("https://example.com/%d", i))
+	}
+
+	var buf bytes.Buffer
+	if err := result.WriteSitemap(&buf); err == nil {
+		t.Fatal("expected error for oversized sitemap, got nil")
+	}
+	if buf.Len() != 0 {
+		t.Error("expected nothing to be written on error")
+	}
+}
@@ -0,0 +1,84 @@
+package supadata
+
+import "sync/atomic"
+
+// endpointCredits is the estimated credit cost of a single call to each endpoint
+var endpointCredits = map[string]int64{
+	"/transcript":                   1,
+	"/metadata":                     1,
+	"/youtube/search":               1,
+	"/youtube/video":                1,
+	"/youtube/video/batch":          1,
+	"/youtube/transcript":           1,
+	"/youtube/transcript/batch":     1,
+	"/youtube/transcript/translate": 1,
+	"/youtube/channel":              1,
+	"/youtube/channel/videos":       1,
+	"/youtube/playlist":             1,
+	"/youtube/playlist/videos":      1,
+	"/web/scrape":                   1,
+	"/web/map":                      1,
+	"/web/crawl":                    1,
+}
+
+// Stats holds client-side counters describing how the client has been used
+type Stats struct {
+	CacheHits   int64
+	CacheMisses int64
+	// CreditsSaved is an estimate of the credits not spent thanks to cache hits
+	CreditsSaved int64
+	// CreditsUsed is the sum of the credits the API reported having charged
+	CreditsUsed int64
+	// CoalescedRequests is the number of requests that shared the upstream call of an identical request
+	CoalescedRequests int64
+	// HedgedRequests is the number of second requests sent by WithHedgedRequests for slow calls
+	HedgedRequests int64
+	// Retries is the number of requests sent again by WithRetries after a transient failure
+	Retries int64
+}
+
+// CacheHitRate returns the ratio of cache hits to cache lookups, or 0 when the cache was never used
+func (st Stats) CacheHitRate() float64 {
+	total := st.CacheHits + st.CacheMisses
+	if total == 0 {
+		return 0
+	}
+	return float64(st.CacheHits) / float64(total)
+}
+
+type clientStats struct {
+	cacheHits    atomic.Int64
+	cacheMisses  atomic.Int64
+	creditsSaved atomic.Int64
+	creditsUsed  atomic.Int64
+	coalesced    atomic.Int64
+	hedged       atomic.Int64
+	retries      atomic.Int64
+}
+
+func (cs *clientStats) recordCacheHit(endpoint string) {
+	cs.cacheHits.Add(1)
+	cs.creditsSaved.Add(endpointCredits[endpoint])
+}
+
+func (cs *clientStats) recordCacheMiss() {
+	cs.cacheMisses.Add(1)
+}
+
+// Stats returns a snapshot of the client counters
+func (s *Supadata) Stats() Stats {
+	return Stats{
+		CacheHits:         s.stats.cacheHits.Load(),
+		CacheMisses:       s.stats.cacheMisses.Load(),
+		CreditsSaved:      s.stats.creditsSaved.Load(),
+		CreditsUsed:       s.stats.creditsUsed.Load(),
+		CoalescedRequests: s.stats.coalesced.Load(),
+		HedgedRequests:    s.stats.hedged.Load(),
+		Retries:           s.stats.retries.Load(),
+	}
+}
+
+// CreditsUsed returns the sum of the credits the API reported having charged for the calls of the client
+func (s *Supadata) CreditsUsed() int64 {
+	return s.stats.creditsUsed.Load()
+}
@@ -0,0 +1,93 @@
+package supadata
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStats_CacheCounters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{"platform": "youtube"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(
+		WithAPIKey("test-api-key"),
+		WithBaseURL(server.URL),
+		WithCache(NewMemoryCache(), time.Minute),
+	)
+
+	for i := 0; i < 4; i++ {
+		if _, err := client.Metadata("https://youtube.com/watch?v=123"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	stats := client.Stats()
+	if stats.CacheMisses != 1 {
+		t.Errorf("expected 1 miss, got %d", stats.CacheMisses)
+	}
+	if stats.CacheHits != 3 {
+		t.Errorf("expected 3 hits, got %d", stats.CacheHits)
+	}
+	if stats.CreditsSaved != 3 {
+		t.Errorf("expected 3 credits saved, got %d", stats.CreditsSaved)
+	}
+	if rate := stats.CacheHitRate(); rate != 0.75 {
+		t.Errorf("expected hit rate 0.75, got %v", rate)
+	}
+}
+
+func TestStats_NoCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{"platform": "youtube"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, _ = client.Metadata("https://youtube.com/watch?v=123")
+
+	if stats := client.Stats(); stats != (Stats{}) {
+		t.Errorf("expected zero stats without cache, got %+v", stats)
+	}
+	if rate := client.Stats().CacheHitRate(); rate != 0 {
+		t.Errorf("expected hit rate 0, got %v", rate)
+	}
+}
+
+func TestCreditsUsed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/web/crawl" {
+			w.Header().Set(headerCreditsUsed, "5")
+		} else if r.URL.Path == "/youtube/video" {
+			w.Header().Set(headerCreditsUsed, "1")
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"jobId": "job-1"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithCreditBudget(10))
+
+	var p Provenance
+	if _, err := client.Crawl(&CrawlBody{Url: "https://example.com"}, WithProvenance(&p)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Credits == nil || *p.Credits != 5 {
+		t.Errorf("expected per-call cost 5, got %v", p.Credits)
+	}
+	if _, err := client.YouTubeVideo("abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Me(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if client.CreditsUsed() != 6 || client.Stats().CreditsUsed != 6 {
+		t.Errorf("expected 6 credits used, got %d", client.CreditsUsed())
+	}
+	if client.RemainingCredits() != 4 {
+		t.Errorf("expected the budget to be charged the reported cost, got %d remaining", client.RemainingCredits())
+	}
+}
@@ -3,15 +3,26 @@ package supadata
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
+	"sort"
+	"strings"
 	"time"
 )
 
 const (
 	BaseUrl = "https://api.supadata.ai/v1"
+
+	// APIVersion is the version of the Supadata API targeted by this SDK
+	APIVersion = "v1"
+
+	// Version is the version of this SDK reported when it cannot be read from the build information
+	Version = "1.0.0"
 )
 
 type ErrorIdentifier string
@@ -32,6 +43,8 @@ type ErrorResponse struct {
 	Message          string          `json:"message"`
 	Details          string          `json:"details"`
 	DocumentationUrl string          `json:"documentationUrl"`
+	// StatusCode is the HTTP status of the response
+	StatusCode int `json:"-"`
 }
 
 func (e *ErrorResponse) Error() string {
@@ -73,11 +86,14 @@ const (
 )
 
 type TranscriptParams struct {
-	Url       string
-	Lang      string
-	Text      bool
-	ChunkSize int
-	Mode      TranscriptModeParam
+	Url        string
+	Lang       Lang
+	Text       *bool
+	ChunkSize  int
+	Mode       TranscriptModeParam
+	WebhookUrl string
+	// Extra holds unmodeled query parameters, see ExtraParams
+	Extra ExtraParams
 }
 
 type TranscriptResultStatus string
@@ -159,10 +175,28 @@ type AccountInfo struct {
 	UsedCredits    int    `json:"usedCredits"`
 }
 
+// ScrapeDevice is the class of device the scraped page is rendered for
+type ScrapeDevice string
+
+const (
+	Desktop ScrapeDevice = "desktop"
+	Mobile  ScrapeDevice = "mobile"
+)
+
 type ScrapeParams struct {
 	Url     string
-	NoLinks bool
-	Lang    string
+	NoLinks *bool
+	Lang    Lang
+	// Device renders responsive pages for a device class, the API default when empty
+	Device ScrapeDevice
+	// UserAgent overrides the User-Agent the API sends to the scraped page
+	UserAgent string
+	// Headers are sent by the API with its request to the scraped page, e.g. an Authorization header
+	Headers map[string]string
+	// Cookies are sent by the API with its request to the scraped page, e.g. a session cookie
+	Cookies map[string]string
+	// Extra holds unmodeled query parameters, see ExtraParams
+	Extra ExtraParams
 }
 
 type ScrapeResult struct {
@@ -177,8 +211,10 @@ type ScrapeResult struct {
 
 type MapParams struct {
 	Url     string
-	NoLinks bool
-	Lang    string
+	NoLinks *bool
+	Lang    Lang
+	// Extra holds unmodeled query parameters, see ExtraParams
+	Extra ExtraParams
 }
 
 type MapResult struct {
@@ -186,8 +222,15 @@ type MapResult struct {
 }
 
 type CrawlBody struct {
-	Url   string `json:"url"`
-	Limit int    `json:"limit,omitempty"`
+	Url          string   `json:"url"`
+	Limit        int      `json:"limit,omitempty"`
+	IncludePaths []string `json:"includePaths,omitempty"`
+	ExcludePaths []string `json:"excludePaths,omitempty"`
+	Depth        int      `json:"depth,omitempty"`
+	RenderJs     *bool    `json:"renderJs,omitempty"`
+	WebhookUrl   string   `json:"webhookUrl,omitempty"`
+	// Extra holds unmodeled body fields, see ExtraFields
+	Extra ExtraFields `json:"-"`
 }
 
 type CrawlJob struct {
@@ -289,21 +332,28 @@ type YouTubeSearchParams struct {
 	Features      []YouTubeSearchFeature
 	Limit         int
 	NextPageToken string
+	// Region is an ISO 3166-1 alpha-2 country code pinning the locale of the results, e.g. "US"
+	Region string
+	// Lang is the interface language of the results, e.g. "en"
+	Lang       Lang
+	SafeSearch *bool
+	// Extra holds unmodeled query parameters, see ExtraParams
+	Extra ExtraParams
 }
 
 type YouTubeSearchResultItem struct {
-	Type            string `json:"type"`
-	Id              string `json:"id"`
-	Title           string `json:"title"`
-	Description     string `json:"description"`
-	Thumbnail       string `json:"thumbnail"`
-	Duration        int    `json:"duration,omitempty"`
-	ViewCount       *int   `json:"viewCount,omitempty"`
-	UploadDate      string `json:"uploadDate,omitempty"`
-	ChannelId       string `json:"channelId,omitempty"`
-	ChannelName     string `json:"channelName,omitempty"`
-	SubscriberCount *int   `json:"subscriberCount,omitempty"`
-	VideoCount      *int   `json:"videoCount,omitempty"`
+	Type            string     `json:"type"`
+	Id              string     `json:"id"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	Thumbnails      Thumbnails `json:"thumbnail"`
+	Duration        int        `json:"duration,omitempty"`
+	ViewCount       *int       `json:"viewCount,omitempty"`
+	UploadDate      string     `json:"uploadDate,omitempty"`
+	ChannelId       string     `json:"channelId,omitempty"`
+	ChannelName     string     `json:"channelName,omitempty"`
+	SubscriberCount *int       `json:"subscriberCount,omitempty"`
+	VideoCount      *int       `json:"videoCount,omitempty"`
 }
 
 type YouTubeSearchResult struct {
@@ -325,7 +375,7 @@ type YouTubeVideo struct {
 	Duration            int                 `json:"duration"`
 	Channel             YouTubeVideoChannel `json:"channel"`
 	Tags                []string            `json:"tags"`
-	Thumbnail           string              `json:"thumbnail"`
+	Thumbnails          Thumbnails          `json:"thumbnail"`
 	UploadDate          *string             `json:"uploadDate"`
 	ViewCount           *int                `json:"viewCount"`
 	LikeCount           *int                `json:"likeCount"`
@@ -337,18 +387,28 @@ type YouTubeVideoBatchParams struct {
 	PlaylistId string   `json:"playlistId,omitempty"`
 	ChannelId  string   `json:"channelId,omitempty"`
 	Limit      int      `json:"limit,omitempty"`
+	WebhookUrl string   `json:"webhookUrl,omitempty"`
+	// Extra holds unmodeled body fields, see ExtraFields
+	Extra ExtraFields `json:"-"`
 }
 
 type YouTubeBatchJob struct {
 	JobId string `json:"jobId"`
+	// JobIds lists every job submitted when the request was split into several jobs, JobId being the first one
+	JobIds []string `json:"jobIds,omitempty"`
+	// SubmittedAt is when the client submitted the job, zero for jobs not submitted by this client
+	SubmittedAt time.Time `json:"-"`
 }
 
 type YouTubeTranscriptParams struct {
 	Url       string
 	VideoId   string
-	Text      bool
+	Text      *bool
 	ChunkSize int
-	Lang      string
+	Lang      Lang
+	Mode      TranscriptModeParam
+	// Extra holds unmodeled query parameters, see ExtraParams
+	Extra ExtraParams
 }
 
 type YouTubeTranscriptResult struct {
@@ -362,16 +422,26 @@ type YouTubeTranscriptBatchParams struct {
 	PlaylistId string   `json:"playlistId,omitempty"`
 	ChannelId  string   `json:"channelId,omitempty"`
 	Limit      int      `json:"limit,omitempty"`
-	Lang       string   `json:"lang,omitempty"`
-	Text       bool     `json:"text,omitempty"`
+	Lang       Lang     `json:"lang,omitempty"`
+	Text       *bool    `json:"text,omitempty"`
+	// Translate translates every transcript into Lang instead of returning it in its original language
+	Translate  *bool  `json:"translate,omitempty"`
+	WebhookUrl string `json:"webhookUrl,omitempty"`
+	// Extra holds unmodeled body fields, see ExtraFields
+	Extra ExtraFields `json:"-"`
 }
 
+// ErrTranslateLangRequired is returned when a transcript batch asks for a translation without a target Lang
+var ErrTranslateLangRequired = errors.New("translate requires a target lang")
+
 type YouTubeTranscriptTranslateParams struct {
 	Url       string
 	VideoId   string
-	Text      bool
+	Text      *bool
 	ChunkSize int
-	Lang      string
+	Lang      Lang
+	// Extra holds unmodeled query parameters, see ExtraParams
+	Extra ExtraParams
 }
 
 type YouTubeTranscriptTranslateResult struct {
@@ -380,14 +450,14 @@ type YouTubeTranscriptTranslateResult struct {
 }
 
 type YouTubeChannel struct {
-	Id              string `json:"id"`
-	Name            string `json:"name"`
-	Description     string `json:"description,omitempty"`
-	SubscriberCount *int   `json:"subscriberCount,omitempty"`
-	VideoCount      *int   `json:"videoCount,omitempty"`
-	ViewCount       *int   `json:"viewCount,omitempty"`
-	Thumbnail       string `json:"thumbnail,omitempty"`
-	Banner          string `json:"banner,omitempty"`
+	Id              string     `json:"id"`
+	Name            string     `json:"name"`
+	Description     string     `json:"description,omitempty"`
+	SubscriberCount *int       `json:"subscriberCount,omitempty"`
+	VideoCount      *int       `json:"videoCount,omitempty"`
+	ViewCount       *int       `json:"viewCount,omitempty"`
+	Thumbnails      Thumbnails `json:"thumbnail,omitempty"`
+	Banner          string     `json:"banner,omitempty"`
 }
 
 type YouTubePlaylist struct {
@@ -411,26 +481,34 @@ const (
 )
 
 type YouTubeChannelVideosParams struct {
-	Id    string
-	Limit int
-	Type  YouTubeChannelVideoType
+	Id            string
+	Limit         int
+	Type          YouTubeChannelVideoType
+	NextPageToken string
+	// Extra holds unmodeled query parameters, see ExtraParams
+	Extra ExtraParams
 }
 
 type YouTubeChannelVideosResult struct {
-	VideoIds []string `json:"videoIds"`
-	ShortIds []string `json:"shortIds"`
-	LiveIds  []string `json:"liveIds"`
+	VideoIds      []string `json:"videoIds"`
+	ShortIds      []string `json:"shortIds"`
+	LiveIds       []string `json:"liveIds"`
+	NextPageToken string   `json:"nextPageToken,omitempty"`
 }
 
 type YouTubePlaylistVideosParams struct {
-	Id    string
-	Limit int
+	Id            string
+	Limit         int
+	NextPageToken string
+	// Extra holds unmodeled query parameters, see ExtraParams
+	Extra ExtraParams
 }
 
 type YouTubePlaylistVideosResult struct {
-	VideoIds []string `json:"videoIds"`
-	ShortIds []string `json:"shortIds"`
-	LiveIds  []string `json:"liveIds"`
+	VideoIds      []string `json:"videoIds"`
+	ShortIds      []string `json:"shortIds"`
+	LiveIds       []string `json:"liveIds"`
+	NextPageToken string   `json:"nextPageToken,omitempty"`
 }
 
 // YouTubeBatchStatus represents the status of a batch job
@@ -460,21 +538,69 @@ type YouTubeBatchResult struct {
 	Status      YouTubeBatchStatus       `json:"status"`
 	Results     []YouTubeBatchResultItem `json:"results,omitempty"`
 	Stats       YouTubeBatchStats        `json:"stats"`
-	CompletedAt *string                  `json:"completedAt,omitempty"`
+	CompletedAt *time.Time               `json:"completedAt,omitempty"`
 }
 
+// Config holds the settings of a client. It is built from the ConfigOptions given to NewSupadata and is not changed
+// afterwards.
 type Config struct {
-	apiKey  string
-	baseURL string
-	client  *http.Client
-}
-
+	apiKey   string
+	apiKeys  []string
+	baseURL  string
+	client   *http.Client
+	timeout  *time.Duration
+	cache    Cache
+	cacheTTL time.Duration
+
+	scopeDetection  bool
+	logger          *slog.Logger
+	limiter         *rateLimiter
+	jobStore        JobStore
+	jobRegistry     JobRegistry
+	transcriptStore TranscriptStore
+	creditBudget    int64
+
+	accountCacheTTL time.Duration
+	planChecks      bool
+	fallbackURLs    []string
+	userAgentSuffix string
+	coalescing      bool
+	dump            *httpDump
+	adaptivePacing  bool
+	maxConcurrent   int
+	hedgeDelay      time.Duration
+	maxRetries      int
+	backoff         BackoffFunc
+	jsonMarshal     func(any) ([]byte, error)
+	jsonUnmarshal   func([]byte, any) error
+
+	defaultLang      Lang
+	defaultChunkSize int
+	defaultMode      TranscriptModeParam
+
+	featuresEncoding FeaturesEncoding
+}
+
+// Supadata is a client of the Supadata API. Its configuration is fixed by NewSupadata, and it is safe for concurrent
+// use by multiple goroutines.
 type Supadata struct {
-	config *Config
+	config   *Config
+	stats    clientStats
+	scopes   scopeMap
+	budget   *creditBudget
+	account  accountCache
+	failover *failover
+	inflight coalescer
+	quota    quota
+	keys     *keyPool
+	shutdown shutdown
+
+	inflightLimit semaphore
 }
 
 func (s *Supadata) setDefaultHeaders(req *http.Request) {
-	req.Header.Set("User-Agent", "supadata-go/1.0.0")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", s.userAgent())
 	req.Header.Set("x-api-key", s.config.apiKey)
 }
 
@@ -483,18 +609,24 @@ type ConfigOption func(*Config)
 func WithAPIKey(apiKey string) ConfigOption {
 	return func(config *Config) {
 		config.apiKey = apiKey
+		config.apiKeys = nil
 	}
 }
 
+// WithTimeout sets the timeout of the requests, zero meaning no timeout. It applies to the client given to WithClient
+// whatever the order of the options.
 func WithTimeout(timeout time.Duration) ConfigOption {
 	return func(config *Config) {
-		config.client.Timeout = timeout
+		config.timeout = &timeout
 	}
 }
 
+// WithClient sends the requests with a copy of client, so that the options of the client, e.g. WithTimeout, do not
+// change the caller's client
 func WithClient(client *http.Client) ConfigOption {
 	return func(config *Config) {
-		config.client = client
+		copied := *client
+		config.client = &copied
 	}
 }
 
@@ -504,6 +636,13 @@ func WithBaseURL(baseURL string) ConfigOption {
 	}
 }
 
+// WithLogger logs every request at debug level, including the lineage ID of the originating operation
+func WithLogger(logger *slog.Logger) ConfigOption {
+	return func(config *Config) {
+		config.logger = logger
+	}
+}
+
 func NewSupadata(opts ...ConfigOption) *Supadata {
 	defaultClient := &http.Client{
 		Timeout:   60 * time.Second,
@@ -511,65 +650,250 @@ func NewSupadata(opts ...ConfigOption) *Supadata {
 	}
 
 	c := &Config{
-		apiKey:  os.Getenv("SUPADATA_API_KEY"),
-		baseURL: BaseUrl,
-		client:  defaultClient,
+		apiKey:        os.Getenv("SUPADATA_API_KEY"),
+		baseURL:       BaseUrl,
+		client:        defaultClient,
+		jsonMarshal:   json.Marshal,
+		jsonUnmarshal: json.Unmarshal,
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.timeout != nil {
+		client := *c.client
+		client.Timeout = *c.timeout
+		c.client = &client
+	}
+	if c.planChecks && c.accountCacheTTL == 0 {
+		c.accountCacheTTL = DefaultAccountCacheTTL
+	}
 
-	return &Supadata{
+	s := &Supadata{
 		config: c,
 	}
-
+	if c.creditBudget > 0 {
+		s.budget = &creditBudget{limit: c.creditBudget}
+	}
+	if len(c.fallbackURLs) > 0 {
+		s.failover = newFailover(c.baseURL, c.fallbackURLs)
+	}
+	if c.maxConcurrent > 0 {
+		s.inflightLimit = make(semaphore, c.maxConcurrent)
+	}
+	if len(c.apiKeys) > 0 {
+		s.keys = newKeyPool(c.apiKeys)
+	}
+	return s
 }
 
-func (s *Supadata) prepareRequest(method, endpoint string, body io.Reader) (*http.Request, error) {
-	req, err := http.NewRequest(method, s.config.baseURL+endpoint, body)
+// ErrUnsupportedMethod is returned when a request uses an HTTP method the API does not serve
+var ErrUnsupportedMethod = errors.New("unsupported HTTP method")
+
+// prepareRequest builds a request to endpoint with the default headers. A non-nil payload is sent as the JSON body
+// of the request, whatever the method.
+func (s *Supadata) prepareRequest(method, endpoint string, payload any) (*http.Request, error) {
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedMethod, method)
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := s.config.jsonMarshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = s.mergeExtra(data, payload); err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	target, err := url.JoinPath(s.config.baseURL, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(method, target, body)
 	if err != nil {
 		return nil, err
 	}
 	s.setDefaultHeaders(req)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	return req, nil
 }
 
+// Do calls an endpoint without a dedicated method, e.g. one added to the API after this version of the client, with
+// the headers, retries and error handling of the other methods. The endpoint is relative to the base URL and may
+// carry a query string. A non-nil body is sent as JSON, and the JSON response is decoded into out unless it is nil
+// or the response has no body.
+func (s *Supadata) Do(method, endpoint string, body, out any, opts ...RequestOption) error {
+	endpoint, query, _ := strings.Cut(endpoint, "?")
+	req, err := s.prepareRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.URL.RawQuery = query
+
+	resp, err := s.do(req, opts)
+	if err != nil {
+		return err
+	}
+	data, err := s.handleRawResponse(resp)
+	if err != nil || out == nil || len(data) == 0 {
+		return err
+	}
+	return s.decode(resp.Request, data, out)
+}
+
+// do sends the request with the configured HTTP client, applying the per-call options
+func (s *Supadata) do(req *http.Request, opts []RequestOption) (*http.Response, error) {
+	if s.shutdown.isClosed() {
+		return nil, s.requestError(req, ErrClientClosed)
+	}
+	rc := newRequestConfig(opts)
+	if rc.ctx != nil {
+		req = req.WithContext(rc.ctx)
+	}
+	if rc.retry != nil {
+		req = req.WithContext(withRetryPolicy(req.Context(), rc.retry))
+	}
+
+	endpoint := s.endpointPath(req)
+	if err := s.checkScope(endpoint); err != nil {
+		return nil, s.requestError(req, err)
+	}
+	if err := s.checkPlan(rc.ctx, endpoint); err != nil {
+		return nil, s.requestError(req, err)
+	}
+
+	start := time.Now()
+	resp, err := s.doCoalesced(req, rc.forceRefresh)
+	s.logRequest(req, endpoint, resp, err, time.Since(start))
+	if err != nil {
+		return nil, s.requestError(req, err)
+	}
+
+	if credits, ok := creditsUsed(resp); ok {
+		s.stats.creditsUsed.Add(int64(credits))
+	}
+
+	if err := s.detectMissingScope(endpoint, resp); err != nil {
+		return nil, s.requestError(req, err)
+	}
+
+	if rc.provenance != nil {
+		*rc.provenance = s.newProvenance(req, resp)
+	}
+	return resp, nil
+}
+
+// logRequest logs the outcome of a request when a logger is configured
+func (s *Supadata) logRequest(req *http.Request, endpoint string, resp *http.Response, err error, elapsed time.Duration) {
+	if s.config.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("endpoint", endpoint),
+		slog.Duration("duration", elapsed),
+	}
+	if lineage, ok := LineageFromContext(req.Context()); ok {
+		attrs = append(attrs, slog.String("lineage", lineage))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", s.Redact(err.Error())))
+	} else {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	s.config.logger.LogAttrs(req.Context(), slog.LevelDebug, "supadata request", attrs...)
+}
+
+// endpointPath returns the path of the request relative to the configured base URL
+func (s *Supadata) endpointPath(req *http.Request) string {
+	if base, err := url.Parse(s.config.baseURL); err == nil {
+		return strings.TrimPrefix(req.URL.EscapedPath(), strings.TrimSuffix(base.EscapedPath(), "/"))
+	}
+	return req.URL.EscapedPath()
+}
+
 // handleResponse is a generic function that handles HTTP responses and unmarshals them into the specified type
-func handleResponse[T any](resp *http.Response) (*T, error) {
-	body, err := handleRawResponse(resp)
+func handleResponse[T any](s *Supadata, resp *http.Response) (*T, error) {
+	body, err := s.handleRawResponse(resp)
 	if err != nil {
 		return nil, err
 	}
 
 	var result T
-	if err := json.Unmarshal(body, &result); err != nil {
+	if err := s.decode(resp.Request, body, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
-// handleRawResponse handles HTTP responses and returns the raw body bytes for custom processing
-func handleRawResponse(resp *http.Response) ([]byte, error) {
+// handleRawResponse handles HTTP responses and returns the raw body bytes for custom processing.
+// The body is always drained and closed, including on errors, so that the connection can be reused.
+func (s *Supadata) handleRawResponse(resp *http.Response) ([]byte, error) {
 	body, err := io.ReadAll(resp.Body)
+	closeBody(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, s.requestError(resp.Request, err)
+	}
+	if err := s.checkContentType(resp, body); err != nil {
+		return nil, s.requestError(resp.Request, err)
 	}
 
 	if resp.StatusCode >= 400 {
 		var errResp ErrorResponse
-		if err := json.Unmarshal(body, &errResp); err != nil {
-			return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+		if err := s.config.jsonUnmarshal(body, &errResp); err != nil {
+			if retryableStatus(resp.StatusCode) {
+				return nil, s.requestError(resp.Request, &RetryableStatusError{StatusCode: resp.StatusCode})
+			}
+			return nil, s.requestError(resp.Request, fmt.Errorf("request failed with status %d", resp.StatusCode))
 		}
-		return nil, &errResp
+		errResp.StatusCode = resp.StatusCode
+		errResp.Message, errResp.Details = s.Redact(errResp.Message), s.Redact(errResp.Details)
+		return nil, s.requestError(resp.Request, &errResp)
 	}
 	return body, nil
 }
 
+// maxDrainBytes bounds how much of an unread response body is discarded before closing it. Draining lets the
+// connection be reused for the next request, while larger leftovers are cheaper to drop with the connection.
+const maxDrainBytes = 64 << 10
+
+// closeBody discards what remains of body, up to maxDrainBytes, and closes it
+func closeBody(body io.ReadCloser) {
+	_, _ = io.CopyN(io.Discard, body, maxDrainBytes)
+	_ = body.Close()
+}
+
 // Universal Endpoints
 
-// Transcript initiates a transcript request (sync or async)
-func (s *Supadata) Transcript(params *TranscriptParams) (*Transcript, error) {
+// Transcript initiates a transcript request (sync or async).
+// When the API rejects the synchronous request because the media is too long, the request is resubmitted as an
+// asynchronous job; see ResolveTranscript to get the final transcript either way.
+func (s *Supadata) Transcript(params *TranscriptParams, opts ...RequestOption) (*Transcript, error) {
+	transcript, err := s.transcript(params, false, opts)
+	if err != nil && requiresAsync(err) {
+		return s.transcript(params, true, opts)
+	}
+	return transcript, err
+}
+
+// transcript sends a transcript request, asking for an asynchronous job when async is set
+func (s *Supadata) transcript(params *TranscriptParams, async bool, opts []RequestOption) (*Transcript, error) {
+	withDefaults := *params
+	s.applyTranscriptDefaults(&withDefaults.Lang, &withDefaults.ChunkSize, &withDefaults.Mode)
+	params = &withDefaults
+	if err := params.Lang.Validate(); err != nil {
+		return nil, err
+	}
+
 	req, err := s.prepareRequest("GET", "/transcript", nil)
 	if err != nil {
 		return nil, err
@@ -578,11 +902,9 @@ func (s *Supadata) Transcript(params *TranscriptParams) (*Transcript, error) {
 	q := req.URL.Query()
 	q.Set("url", params.Url)
 	if params.Lang != "" {
-		q.Set("lang", params.Lang)
-	}
-	if params.Text {
-		q.Set("text", "true")
+		q.Set("lang", string(params.Lang))
 	}
+	setBool(q, "text", params.Text)
 	if params.ChunkSize > 0 {
 		q.Set("chunkSize", fmt.Sprintf("%d", params.ChunkSize))
 	}
@@ -591,95 +913,118 @@ func (s *Supadata) Transcript(params *TranscriptParams) (*Transcript, error) {
 	} else {
 		q.Set("mode", string(Auto))
 	}
+	if params.WebhookUrl != "" {
+		q.Set("webhookUrl", params.WebhookUrl)
+	}
+	if async {
+		q.Set("async", "true")
+	}
+	setExtra(q, params.Extra)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := s.config.client.Do(req)
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := handleRawResponse(resp)
+	body, err := s.handleRawResponse(resp)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
 	// Check if response is async (has jobId) or sync (has content)
 	var raw map[string]json.RawMessage
-	if err := json.Unmarshal(body, &raw); err != nil {
+	if err := s.decode(req, body, &raw); err != nil {
 		return nil, err
 	}
 
 	if _, hasJobId := raw["jobId"]; hasJobId {
 		var async AsyncTranscript
-		if err := json.Unmarshal(body, &async); err != nil {
+		if err := s.decode(req, body, &async); err != nil {
 			return nil, err
 		}
-		return &Transcript{Async: &async}, nil
+		return &Transcript{Async: &async}, s.registerJob(JobTranscript, async.JobId, "/transcript", nil, params)
 	}
 
 	var sync SyncTranscript
-	if err := json.Unmarshal(body, &sync); err != nil {
+	if err := s.decode(req, body, &sync); err != nil {
 		return nil, err
 	}
 	return &Transcript{Sync: &sync}, nil
 }
 
 // TranscriptResult retrieves the result of an async transcript job
-func (s *Supadata) TranscriptResult(jobId string) (*TranscriptResult, error) {
-	req, err := s.prepareRequest("GET", "/transcript/"+jobId, nil)
+func (s *Supadata) TranscriptResult(jobId string, opts ...RequestOption) (*TranscriptResult, error) {
+	endpoint, err := jobPath("/transcript", jobId)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := s.config.client.Do(req)
+	req, err := s.prepareRequest("GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	return handleResponse[TranscriptResult](resp)
+	resp, err := s.do(req, opts)
+	if err != nil {
+		return nil, err
+	}
+	return handleResponse[TranscriptResult](s, resp)
 }
 
-// Metadata retrieves metadata for a given URL
-func (s *Supadata) Metadata(url string) (*Metadata, error) {
+// Metadata retrieves metadata for a given URL, removing its tracking parameters first with NormalizeURL so that
+// shared links of the same content hit the same cache entry
+func (s *Supadata) Metadata(url string, opts ...RequestOption) (*Metadata, error) {
 	req, err := s.prepareRequest("GET", "/metadata", nil)
 	if err != nil {
 		return nil, err
 	}
 
 	q := req.URL.Query()
-	q.Set("url", url)
+	q.Set("url", NormalizeURL(url))
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := s.config.client.Do(req)
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	return handleResponse[Metadata](resp)
+	return handleResponse[Metadata](s, resp)
 }
 
 // Account Endpoints
 
-// Me retrieves account information
-func (s *Supadata) Me() (*AccountInfo, error) {
+// Me retrieves account information, from the account cache when WithAccountCacheTTL is set
+func (s *Supadata) Me(opts ...RequestOption) (*AccountInfo, error) {
+	ttl := s.config.accountCacheTTL
+	if ttl > 0 && !newRequestConfig(opts).forceRefresh {
+		if info, ok := s.account.get(); ok {
+			return info, nil
+		}
+	}
+
 	req, err := s.prepareRequest("GET", "/me", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.config.client.Do(req)
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	return handleResponse[AccountInfo](resp)
+	info, err := handleResponse[AccountInfo](s, resp)
+	if err != nil {
+		return nil, err
+	}
+	if ttl > 0 {
+		s.account.set(info, ttl)
+	}
+	return info, nil
 }
 
 // Web Endpoints
 
 // Scrape extracts content from a webpage as markdown
-func (s *Supadata) Scrape(params *ScrapeParams) (*ScrapeResult, error) {
+func (s *Supadata) Scrape(params *ScrapeParams, opts ...RequestOption) (*ScrapeResult, error) {
+	if err := params.Lang.Validate(); err != nil {
+		return nil, err
+	}
 	req, err := s.prepareRequest("GET", "/web/scrape", nil)
 	if err != nil {
 		return nil, err
@@ -687,25 +1032,56 @@ func (s *Supadata) Scrape(params *ScrapeParams) (*ScrapeResult, error) {
 
 	q := req.URL.Query()
 	q.Set("url", params.Url)
-	if params.NoLinks {
-		q.Set("noLinks", "true")
-	}
+	setBool(q, "noLinks", params.NoLinks)
 	if params.Lang != "" {
-		q.Set("lang", params.Lang)
+		q.Set("lang", string(params.Lang))
+	}
+	if params.Device != "" {
+		q.Set("device", string(params.Device))
+	}
+	if params.UserAgent != "" {
+		q.Set("userAgent", params.UserAgent)
+	}
+	if len(params.Headers) > 0 {
+		headers, err := s.config.jsonMarshal(params.Headers)
+		if err != nil {
+			return nil, err
+		}
+		q.Set("headers", string(headers))
 	}
+	if len(params.Cookies) > 0 {
+		q.Set("cookies", cookieHeader(params.Cookies))
+	}
+	setExtra(q, params.Extra)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := s.config.client.Do(req)
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	return handleResponse[ScrapeResult](s, resp)
+}
+
+// cookieHeader formats cookies as the value of a Cookie header, sorted by name
+func cookieHeader(cookies map[string]string) string {
+	names := make([]string, 0, len(cookies))
+	for name := range cookies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-	return handleResponse[ScrapeResult](resp)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = (&http.Cookie{Name: name, Value: cookies[name]}).String()
+	}
+	return strings.Join(pairs, "; ")
 }
 
 // Map discovers all URLs on a website
-func (s *Supadata) Map(params *MapParams) (*MapResult, error) {
+func (s *Supadata) Map(params *MapParams, opts ...RequestOption) (*MapResult, error) {
+	if err := params.Lang.Validate(); err != nil {
+		return nil, err
+	}
 	req, err := s.prepareRequest("GET", "/web/map", nil)
 	if err != nil {
 		return nil, err
@@ -713,48 +1089,45 @@ func (s *Supadata) Map(params *MapParams) (*MapResult, error) {
 
 	q := req.URL.Query()
 	q.Set("url", params.Url)
-	if params.NoLinks {
-		q.Set("noLinks", "true")
-	}
+	setBool(q, "noLinks", params.NoLinks)
 	if params.Lang != "" {
-		q.Set("lang", params.Lang)
+		q.Set("lang", string(params.Lang))
 	}
+	setExtra(q, params.Extra)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := s.config.client.Do(req)
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	return handleResponse[MapResult](resp)
+	return handleResponse[MapResult](s, resp)
 }
 
 // Crawl initiates an async crawl job for a website
-func (s *Supadata) Crawl(params *CrawlBody) (*CrawlJob, error) {
-	body, err := json.Marshal(params)
+func (s *Supadata) Crawl(params *CrawlBody, opts ...RequestOption) (*CrawlJob, error) {
+	req, err := s.prepareRequest("POST", "/web/crawl", params)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := s.prepareRequest("POST", "/web/crawl", bytes.NewReader(body))
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := s.config.client.Do(req)
+	job, err := handleResponse[CrawlJob](s, resp)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	return handleResponse[CrawlJob](resp)
+	return job, s.registerJob(JobCrawl, job.JobId, "/web/crawl", nil, params)
 }
 
 // CrawlResult retrieves the status and results of a crawl job
-func (s *Supadata) CrawlResult(jobId string, skip int) (*CrawlResult, error) {
-	req, err := s.prepareRequest("GET", "/web/crawl/"+jobId, nil)
+func (s *Supadata) CrawlResult(jobId string, skip int, opts ...RequestOption) (*CrawlResult, error) {
+	endpoint, err := jobPath("/web/crawl", jobId)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.prepareRequest("GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -765,19 +1138,20 @@ func (s *Supadata) CrawlResult(jobId string, skip int) (*CrawlResult, error) {
 		req.URL.RawQuery = q.Encode()
 	}
 
-	resp, err := s.config.client.Do(req)
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	return handleResponse[CrawlResult](resp)
+	return handleResponse[CrawlResult](s, resp)
 }
 
 // YouTube Endpoints
 
 // YouTubeSearch searches YouTube for videos, channels, or playlists
-func (s *Supadata) YouTubeSearch(params *YouTubeSearchParams) (*YouTubeSearchResult, error) {
+func (s *Supadata) YouTubeSearch(params *YouTubeSearchParams, opts ...RequestOption) (*YouTubeSearchResult, error) {
+	if err := params.Lang.Validate(); err != nil {
+		return nil, err
+	}
 	req, err := s.prepareRequest("GET", "/youtube/search", nil)
 	if err != nil {
 		return nil, err
@@ -797,10 +1171,8 @@ func (s *Supadata) YouTubeSearch(params *YouTubeSearchParams) (*YouTubeSearchRes
 	if params.SortBy != "" {
 		q.Set("sortBy", string(params.SortBy))
 	}
-	if len(params.Features) > 0 {
-		for _, f := range params.Features {
-			q.Add("features", string(f))
-		}
+	if err := s.setFeatures(q, params.Features); err != nil {
+		return nil, err
 	}
 	if params.Limit > 0 {
 		q.Set("limit", fmt.Sprintf("%d", params.Limit))
@@ -808,61 +1180,108 @@ func (s *Supadata) YouTubeSearch(params *YouTubeSearchParams) (*YouTubeSearchRes
 	if params.NextPageToken != "" {
 		q.Set("nextPageToken", params.NextPageToken)
 	}
+	if params.Region != "" {
+		q.Set("region", params.Region)
+	}
+	if params.Lang != "" {
+		q.Set("lang", string(params.Lang))
+	}
+	setBool(q, "safeSearch", params.SafeSearch)
+	setExtra(q, params.Extra)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := s.config.client.Do(req)
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	return handleResponse[YouTubeSearchResult](resp)
+	return handleResponse[YouTubeSearchResult](s, resp)
 }
 
 // YouTubeVideo retrieves metadata for a YouTube video
-func (s *Supadata) YouTubeVideo(id string) (*YouTubeVideo, error) {
+func (s *Supadata) YouTubeVideo(id string, opts ...RequestOption) (*YouTubeVideo, error) {
+	videoId, err := resolveId(id, ParseVideoID)
+	if err != nil {
+		return nil, err
+	}
 	req, err := s.prepareRequest("GET", "/youtube/video", nil)
 	if err != nil {
 		return nil, err
 	}
 
 	q := req.URL.Query()
-	q.Set("id", id)
+	q.Set("id", videoId)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := s.config.client.Do(req)
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	return handleResponse[YouTubeVideo](resp)
+	return handleResponse[YouTubeVideo](s, resp)
 }
 
-// YouTubeVideoBatch initiates a batch job to retrieve multiple video metadata
-func (s *Supadata) YouTubeVideoBatch(params *YouTubeVideoBatchParams) (*YouTubeBatchJob, error) {
-	body, err := json.Marshal(params)
+// YouTubeVideoBatch initiates a batch job to retrieve multiple video metadata.
+// More than MaxBatchVideoIds video IDs are split into several jobs, see YouTubeBatchJobResult.
+func (s *Supadata) YouTubeVideoBatch(params *YouTubeVideoBatchParams, opts ...RequestOption) (*YouTubeBatchJob, error) {
+	videoIds, playlistId, err := parseBatchSource(params.VideoIds, params.PlaylistId)
 	if err != nil {
 		return nil, err
 	}
+	parsed := *params
+	parsed.VideoIds, parsed.PlaylistId = videoIds, playlistId
+	params = &parsed
+
+	submittedAt := time.Now()
+	var job *YouTubeBatchJob
+	if len(params.VideoIds) > MaxBatchVideoIds {
+		job, err = submitBatchChunks(params.VideoIds, func(ids []string) (*YouTubeBatchJob, error) {
+			chunk := *params
+			chunk.VideoIds = ids
+			return s.submitYouTubeVideoBatch(&chunk, opts)
+		})
+	} else {
+		job, err = s.submitYouTubeVideoBatch(params, opts)
+	}
+	if job == nil {
+		return nil, err
+	}
+	job.SubmittedAt = submittedAt
+	return job, errors.Join(err, s.registerJob(JobBatch, job.JobId, "/youtube/video/batch", job.JobIds, params))
+}
 
-	req, err := s.prepareRequest("POST", "/youtube/video/batch", bytes.NewReader(body))
+// submitYouTubeVideoBatch submits a single batch job of at most MaxBatchVideoIds videos
+func (s *Supadata) submitYouTubeVideoBatch(params *YouTubeVideoBatchParams, opts []RequestOption) (*YouTubeBatchJob, error) {
+	req, err := s.prepareRequest("POST", "/youtube/video/batch", params)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := s.config.client.Do(req)
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	return handleResponse[YouTubeBatchJob](resp)
+	return handleResponse[YouTubeBatchJob](s, resp)
 }
 
 // YouTubeTranscript retrieves the transcript for a YouTube video
-func (s *Supadata) YouTubeTranscript(params *YouTubeTranscriptParams) (*YouTubeTranscriptResult, error) {
+func (s *Supadata) YouTubeTranscript(params *YouTubeTranscriptParams, opts ...RequestOption) (*YouTubeTranscriptResult, error) {
+	if err := validateVideoId(params.VideoId); err != nil {
+		return nil, err
+	}
+	withDefaults := *params
+	s.applyTranscriptDefaults(&withDefaults.Lang, &withDefaults.ChunkSize, &withDefaults.Mode)
+	params = &withDefaults
+	if err := params.Lang.Validate(); err != nil {
+		return nil, err
+	}
+
+	key, stored := s.transcriptKey(params)
+	if stored && !newRequestConfig(opts).forceRefresh {
+		transcript, err := s.storedTranscript(key)
+		if transcript != nil || err != nil {
+			return transcript, err
+		}
+	}
+
 	req, err := s.prepareRequest("GET", "/youtube/transcript", nil)
 	if err != nil {
 		return nil, err
@@ -875,50 +1294,91 @@ func (s *Supadata) YouTubeTranscript(params *YouTubeTranscriptParams) (*YouTubeT
 	if params.VideoId != "" {
 		q.Set("videoId", params.VideoId)
 	}
-	if params.Text {
-		q.Set("text", "true")
-	}
+	setBool(q, "text", params.Text)
 	if params.ChunkSize > 0 {
 		q.Set("chunkSize", fmt.Sprintf("%d", params.ChunkSize))
 	}
 	if params.Lang != "" {
-		q.Set("lang", params.Lang)
+		q.Set("lang", string(params.Lang))
+	}
+	if params.Mode != "" {
+		q.Set("mode", string(params.Mode))
 	}
+	setExtra(q, params.Extra)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := s.config.client.Do(req)
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	return handleResponse[YouTubeTranscriptResult](resp)
+	transcript, err := handleResponse[YouTubeTranscriptResult](s, resp)
+	if err != nil || !stored {
+		return transcript, err
+	}
+	return transcript, s.storeTranscript(key, transcript)
 }
 
-// YouTubeTranscriptBatch initiates a batch job to retrieve transcripts for multiple videos
-func (s *Supadata) YouTubeTranscriptBatch(params *YouTubeTranscriptBatchParams) (*YouTubeBatchJob, error) {
-	body, err := json.Marshal(params)
+// YouTubeTranscriptBatch initiates a batch job to retrieve transcripts for multiple videos.
+// More than MaxBatchVideoIds video IDs are split into several jobs, see YouTubeBatchJobResult.
+func (s *Supadata) YouTubeTranscriptBatch(params *YouTubeTranscriptBatchParams, opts ...RequestOption) (*YouTubeBatchJob, error) {
+	videoIds, playlistId, err := parseBatchSource(params.VideoIds, params.PlaylistId)
 	if err != nil {
 		return nil, err
 	}
+	parsed := *params
+	parsed.VideoIds, parsed.PlaylistId = videoIds, playlistId
+	s.applyTranscriptDefaults(&parsed.Lang, nil, nil)
+	params = &parsed
+	if err := params.Lang.Validate(); err != nil {
+		return nil, err
+	}
+	if isTrue(params.Translate) && params.Lang == "" {
+		return nil, ErrTranslateLangRequired
+	}
 
-	req, err := s.prepareRequest("POST", "/youtube/transcript/batch", bytes.NewReader(body))
-	if err != nil {
+	submittedAt := time.Now()
+	var job *YouTubeBatchJob
+	if len(params.VideoIds) > MaxBatchVideoIds {
+		job, err = submitBatchChunks(params.VideoIds, func(ids []string) (*YouTubeBatchJob, error) {
+			chunk := *params
+			chunk.VideoIds = ids
+			return s.submitYouTubeTranscriptBatch(&chunk, opts)
+		})
+	} else {
+		job, err = s.submitYouTubeTranscriptBatch(params, opts)
+	}
+	if job == nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
+	job.SubmittedAt = submittedAt
+	return job, errors.Join(err, s.registerJob(JobBatch, job.JobId, "/youtube/transcript/batch", job.JobIds, params))
+}
 
-	resp, err := s.config.client.Do(req)
+// submitYouTubeTranscriptBatch submits a single batch job of at most MaxBatchVideoIds videos
+func (s *Supadata) submitYouTubeTranscriptBatch(params *YouTubeTranscriptBatchParams, opts []RequestOption) (*YouTubeBatchJob, error) {
+	req, err := s.prepareRequest("POST", "/youtube/transcript/batch", params)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	return handleResponse[YouTubeBatchJob](resp)
+	resp, err := s.do(req, opts)
+	if err != nil {
+		return nil, err
+	}
+	return handleResponse[YouTubeBatchJob](s, resp)
 }
 
 // YouTubeTranscriptTranslate retrieves a translated transcript for a YouTube video
-func (s *Supadata) YouTubeTranscriptTranslate(params *YouTubeTranscriptTranslateParams) (*YouTubeTranscriptTranslateResult, error) {
+func (s *Supadata) YouTubeTranscriptTranslate(params *YouTubeTranscriptTranslateParams, opts ...RequestOption) (*YouTubeTranscriptTranslateResult, error) {
+	if err := validateVideoId(params.VideoId); err != nil {
+		return nil, err
+	}
+	withDefaults := *params
+	s.applyTranscriptDefaults(&withDefaults.Lang, &withDefaults.ChunkSize, nil)
+	params = &withDefaults
+	if err := params.Lang.Validate(); err != nil {
+		return nil, err
+	}
 	req, err := s.prepareRequest("GET", "/youtube/transcript/translate", nil)
 	if err != nil {
 		return nil, err
@@ -931,125 +1391,140 @@ func (s *Supadata) YouTubeTranscriptTranslate(params *YouTubeTranscriptTranslate
 	if params.VideoId != "" {
 		q.Set("videoId", params.VideoId)
 	}
-	if params.Text {
-		q.Set("text", "true")
-	}
+	setBool(q, "text", params.Text)
 	if params.ChunkSize > 0 {
 		q.Set("chunkSize", fmt.Sprintf("%d", params.ChunkSize))
 	}
-	q.Set("lang", params.Lang)
+	q.Set("lang", string(params.Lang))
+	setExtra(q, params.Extra)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := s.config.client.Do(req)
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	return handleResponse[YouTubeTranscriptTranslateResult](resp)
+	return handleResponse[YouTubeTranscriptTranslateResult](s, resp)
 }
 
 // YouTubeChannel retrieves metadata for a YouTube channel
-func (s *Supadata) YouTubeChannel(id string) (*YouTubeChannel, error) {
+func (s *Supadata) YouTubeChannel(id string, opts ...RequestOption) (*YouTubeChannel, error) {
+	channelRef, err := resolveId(id, ParseChannelRef)
+	if err != nil {
+		return nil, err
+	}
 	req, err := s.prepareRequest("GET", "/youtube/channel", nil)
 	if err != nil {
 		return nil, err
 	}
 
 	q := req.URL.Query()
-	q.Set("id", id)
+	q.Set("id", channelRef)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := s.config.client.Do(req)
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	return handleResponse[YouTubeChannel](resp)
+	return handleResponse[YouTubeChannel](s, resp)
 }
 
 // YouTubePlaylist retrieves metadata for a YouTube playlist
-func (s *Supadata) YouTubePlaylist(id string) (*YouTubePlaylist, error) {
+func (s *Supadata) YouTubePlaylist(id string, opts ...RequestOption) (*YouTubePlaylist, error) {
+	playlistId, err := resolveId(id, ParsePlaylistID)
+	if err != nil {
+		return nil, err
+	}
 	req, err := s.prepareRequest("GET", "/youtube/playlist", nil)
 	if err != nil {
 		return nil, err
 	}
 
 	q := req.URL.Query()
-	q.Set("id", id)
+	q.Set("id", playlistId)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := s.config.client.Do(req)
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	return handleResponse[YouTubePlaylist](resp)
+	return handleResponse[YouTubePlaylist](s, resp)
 }
 
 // YouTubeChannelVideos retrieves video IDs from a YouTube channel
-func (s *Supadata) YouTubeChannelVideos(params *YouTubeChannelVideosParams) (*YouTubeChannelVideosResult, error) {
+func (s *Supadata) YouTubeChannelVideos(params *YouTubeChannelVideosParams, opts ...RequestOption) (*YouTubeChannelVideosResult, error) {
+	channelRef, err := resolveId(params.Id, ParseChannelRef)
+	if err != nil {
+		return nil, err
+	}
 	req, err := s.prepareRequest("GET", "/youtube/channel/videos", nil)
 	if err != nil {
 		return nil, err
 	}
 
 	q := req.URL.Query()
-	q.Set("id", params.Id)
+	q.Set("id", channelRef)
 	if params.Limit > 0 {
 		q.Set("limit", fmt.Sprintf("%d", params.Limit))
 	}
 	if params.Type != "" {
 		q.Set("type", string(params.Type))
 	}
+	if params.NextPageToken != "" {
+		q.Set("nextPageToken", params.NextPageToken)
+	}
+	setExtra(q, params.Extra)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := s.config.client.Do(req)
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	return handleResponse[YouTubeChannelVideosResult](resp)
+	return handleResponse[YouTubeChannelVideosResult](s, resp)
 }
 
 // YouTubePlaylistVideos retrieves video IDs from a YouTube playlist
-func (s *Supadata) YouTubePlaylistVideos(params *YouTubePlaylistVideosParams) (*YouTubePlaylistVideosResult, error) {
+func (s *Supadata) YouTubePlaylistVideos(params *YouTubePlaylistVideosParams, opts ...RequestOption) (*YouTubePlaylistVideosResult, error) {
+	playlistId, err := resolveId(params.Id, ParsePlaylistID)
+	if err != nil {
+		return nil, err
+	}
 	req, err := s.prepareRequest("GET", "/youtube/playlist/videos", nil)
 	if err != nil {
 		return nil, err
 	}
 
 	q := req.URL.Query()
-	q.Set("id", params.Id)
+	q.Set("id", playlistId)
 	if params.Limit > 0 {
 		q.Set("limit", fmt.Sprintf("%d", params.Limit))
 	}
+	if params.NextPageToken != "" {
+		q.Set("nextPageToken", params.NextPageToken)
+	}
+	setExtra(q, params.Extra)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := s.config.client.Do(req)
+	resp, err := s.do(req, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	return handleResponse[YouTubePlaylistVideosResult](resp)
+	return handleResponse[YouTubePlaylistVideosResult](s, resp)
 }
 
 // YouTubeBatchResult retrieves the status and results of a batch job
-func (s *Supadata) YouTubeBatchResult(jobId string) (*YouTubeBatchResult, error) {
-	req, err := s.prepareRequest("GET", "/youtube/batch/"+jobId, nil)
+func (s *Supadata) YouTubeBatchResult(jobId string, opts ...RequestOption) (*YouTubeBatchResult, error) {
+	endpoint, err := jobPath("/youtube/batch", jobId)
 	if err != nil {
 		return nil, err
 	}
-
-	resp, err := s.config.client.Do(req)
+	req, err := s.prepareRequest("GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	return handleResponse[YouTubeBatchResult](resp)
+	resp, err := s.do(req, opts)
+	if err != nil {
+		return nil, err
+	}
+	return handleResponse[YouTubeBatchResult](s, resp)
 }
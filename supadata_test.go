@@ -2,9 +2,12 @@ package supadata
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -73,10 +76,12 @@ func TestNewSupadata_WithTimeout(t *testing.T) {
 }
 
 func TestNewSupadata_WithClient(t *testing.T) {
-	customClient := &http.Client{Timeout: 10 * time.Second}
+	transport := &http.Transport{}
+	customClient := &http.Client{Timeout: 10 * time.Second, Transport: transport}
 	client := NewSupadata(WithClient(customClient))
 
-	if client.config.client != customClient {
+	// The client is copied, keeping its transport and settings
+	if client.config.client.Transport != transport || client.config.client.Timeout != 10*time.Second {
 		t.Error("expected custom client to be used")
 	}
 }
@@ -160,7 +165,7 @@ func TestRequest_QueryParams(t *testing.T) {
 	_, _ = client.Transcript(&TranscriptParams{
 		Url:       "https://youtube.com/watch?v=test&foo=bar",
 		Lang:      "es",
-		Text:      true,
+		Text:      Bool(true),
 		ChunkSize: 500,
 		Mode:      Generate,
 	})
@@ -296,6 +301,28 @@ func TestTranscript_AllModeParams(t *testing.T) {
 	}
 }
 
+func TestTranscript_WithWebhookUrl(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("webhookUrl"); got != "https://hooks.example.com/transcript" {
+			t.Errorf("expected webhookUrl %q, got %q", "https://hooks.example.com/transcript", got)
+		}
+		jsonResponse(w, http.StatusAccepted, map[string]any{"jobId": "job-123"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	result, err := client.Transcript(&TranscriptParams{
+		Url:        "https://youtube.com/watch?v=123",
+		WebhookUrl: "https://hooks.example.com/transcript",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsAsync() {
+		t.Error("expected async response")
+	}
+}
+
 // =============================================================================
 // Transcript Method Tests - Edge Cases
 // =============================================================================
@@ -330,11 +357,63 @@ func TestTranscript_NonJSONError(t *testing.T) {
 		t.Fatal("expected error, got nil")
 	}
 	// Should get a generic error since body isn't valid JSON
-	if err.Error() != "request failed with status 502" {
+	if err.Error() != "supadata: GET /transcript: request failed with status 502" {
 		t.Errorf("expected generic error message, got %q", err.Error())
 	}
 }
 
+// trackedBody records whether a response body was read to the end and closed
+type trackedBody struct {
+	io.Reader
+	drained, closed bool
+}
+
+func (b *trackedBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	if err == io.EOF {
+		b.drained = true
+	}
+	return n, err
+}
+
+func (b *trackedBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTranscript_DrainsAndClosesBody(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"success", http.StatusOK, `{"content": [], "lang": "en"}`},
+		{"api error", http.StatusNotFound, `{"error": "not-found", "message": "Not found"}`},
+		{"non json error", http.StatusBadGateway, "Bad Gateway"},
+		{"malformed json", http.StatusOK, "{invalid json"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			body := &trackedBody{Reader: strings.NewReader(tc.body)}
+			client := NewSupadata(WithAPIKey("test-api-key"), WithClient(&http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{StatusCode: tc.status, Header: http.Header{}, Body: body, Request: req}, nil
+				}),
+			}))
+
+			_, _ = client.Transcript(&TranscriptParams{Url: "https://youtube.com/watch?v=123"})
+			if !body.drained || !body.closed {
+				t.Errorf("expected the body to be drained and closed, got drained=%v closed=%v", body.drained, body.closed)
+			}
+		})
+	}
+}
+
 // =============================================================================
 // TranscriptResult Method Tests
 // =============================================================================
@@ -697,8 +776,8 @@ func TestErrorResponse_AllIdentifiers(t *testing.T) {
 			if err == nil {
 				t.Fatal("expected error, got nil")
 			}
-			errResp, ok := err.(*ErrorResponse)
-			if !ok {
+			var errResp *ErrorResponse
+			if !errors.As(err, &errResp) {
 				t.Fatalf("expected *ErrorResponse, got %T", err)
 			}
 			if errResp.ErrorIdentifier != id {
@@ -765,7 +844,8 @@ func TestEndpoints_ErrorHandling(t *testing.T) {
 			if err == nil {
 				t.Fatal("expected error, got nil")
 			}
-			if _, ok := err.(*ErrorResponse); !ok {
+			var errResp *ErrorResponse
+			if !errors.As(err, &errResp) {
 				t.Fatalf("expected *ErrorResponse, got %T", err)
 			}
 		})
@@ -943,7 +1023,7 @@ func TestScrape_WithParams(t *testing.T) {
 	client := newTestClient(server)
 	_, err := client.Scrape(&ScrapeParams{
 		Url:     "https://example.com",
-		NoLinks: true,
+		NoLinks: Bool(true),
 		Lang:    "es",
 	})
 	if err != nil {
@@ -951,6 +1031,73 @@ func TestScrape_WithParams(t *testing.T) {
 	}
 }
 
+func TestScrape_WithHeadersAndCookies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q.Get("headers"); got != `{"Accept-Language":"de-DE","Authorization":"Bearer token"}` {
+			t.Errorf("unexpected headers %q", got)
+		}
+		if got := q.Get("cookies"); got != "consent=yes; session=abc" {
+			t.Errorf("unexpected cookies %q", got)
+		}
+
+		jsonResponse(w, http.StatusOK, map[string]any{"url": "https://example.com/account"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.Scrape(&ScrapeParams{
+		Url:     "https://example.com/account",
+		Headers: map[string]string{"Authorization": "Bearer token", "Accept-Language": "de-DE"},
+		Cookies: map[string]string{"session": "abc", "consent": "yes"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestScrape_WithDevice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q.Get("device"); got != "mobile" {
+			t.Errorf("expected device=mobile, got %q", got)
+		}
+		if got := q.Get("userAgent"); got != "Mozilla/5.0 (iPhone)" {
+			t.Errorf("expected userAgent, got %q", got)
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"url": "https://example.com"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.Scrape(&ScrapeParams{
+		Url:       "https://example.com",
+		Device:    Mobile,
+		UserAgent: "Mozilla/5.0 (iPhone)",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestScrape_OmitsUnsetOptionalParams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		for _, param := range []string{"headers", "cookies", "device", "userAgent"} {
+			if q.Has(param) {
+				t.Errorf("expected no %s, got %q", param, r.URL.RawQuery)
+			}
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"url": "https://example.com"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, err := client.Scrape(&ScrapeParams{Url: "https://example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // =============================================================================
 // Map Method Tests
 // =============================================================================
@@ -1008,7 +1155,7 @@ func TestMap_WithParams(t *testing.T) {
 	client := newTestClient(server)
 	_, err := client.Map(&MapParams{
 		Url:     "https://example.com",
-		NoLinks: true,
+		NoLinks: Bool(true),
 		Lang:    "fr",
 	})
 	if err != nil {
@@ -1084,6 +1231,94 @@ func TestCrawl_WithLimit(t *testing.T) {
 	}
 }
 
+func TestCrawl_WithScope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if got := body["includePaths"]; len(got.([]any)) != 1 || got.([]any)[0] != "/docs/*" {
+			t.Errorf("expected includePaths [/docs/*], got %v", got)
+		}
+		if got := body["excludePaths"]; len(got.([]any)) != 2 {
+			t.Errorf("expected 2 excludePaths, got %v", got)
+		}
+		if got := body["depth"]; got != float64(3) {
+			t.Errorf("expected depth 3, got %v", got)
+		}
+		if got := body["renderJs"]; got != true {
+			t.Errorf("expected renderJs true, got %v", got)
+		}
+
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"jobId": "crawl-job-789",
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.Crawl(&CrawlBody{
+		Url:          "https://example.com",
+		IncludePaths: []string{"/docs/*"},
+		ExcludePaths: []string{"/login", "/search"},
+		Depth:        3,
+		RenderJs:     Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCrawl_WithWebhookUrl(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body CrawlBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if body.WebhookUrl != "https://hooks.example.com/crawl" {
+			t.Errorf("expected webhookUrl %q, got %q", "https://hooks.example.com/crawl", body.WebhookUrl)
+		}
+
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"jobId": "crawl-job-789",
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.Crawl(&CrawlBody{
+		Url:        "https://example.com",
+		WebhookUrl: "https://hooks.example.com/crawl",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCrawl_OmitsUnsetScope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		for _, key := range []string{"limit", "includePaths", "excludePaths", "depth", "renderJs", "webhookUrl"} {
+			if _, ok := body[key]; ok {
+				t.Errorf("expected %q to be omitted, got %v", key, body[key])
+			}
+		}
+
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"jobId": "crawl-job-789",
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, err := client.Crawl(&CrawlBody{Url: "https://example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // =============================================================================
 // CrawlResult Method Tests
 // =============================================================================
@@ -1320,6 +1555,62 @@ func TestYouTubeSearch_WithParams(t *testing.T) {
 	}
 }
 
+func TestYouTubeSearch_WithLocale(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q.Get("region"); got != "DE" {
+			t.Errorf("expected region=DE, got %q", got)
+		}
+		if got := q.Get("lang"); got != "de" {
+			t.Errorf("expected lang=de, got %q", got)
+		}
+		if got := q.Get("safeSearch"); got != "true" {
+			t.Errorf("expected safeSearch=true, got %q", got)
+		}
+
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"query":        "test",
+			"results":      []map[string]any{},
+			"totalResults": 0,
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.YouTubeSearch(&YouTubeSearchParams{
+		Query:      "test",
+		Region:     "DE",
+		Lang:       "de",
+		SafeSearch: Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestYouTubeSearch_OmitsUnsetLocale(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		for _, key := range []string{"region", "lang", "safeSearch"} {
+			if q.Has(key) {
+				t.Errorf("expected %s to be omitted, got %q", key, q.Get(key))
+			}
+		}
+
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"query":        "test",
+			"results":      []map[string]any{},
+			"totalResults": 0,
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, err := client.YouTubeSearch(&YouTubeSearchParams{Query: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // =============================================================================
 // YouTube Video Tests
 // =============================================================================
@@ -1413,6 +1704,32 @@ func TestYouTubeVideoBatch_Success(t *testing.T) {
 	}
 }
 
+func TestYouTubeVideoBatch_WithWebhookUrl(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body YouTubeVideoBatchParams
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if body.WebhookUrl != "https://hooks.example.com/batch" {
+			t.Errorf("expected webhookUrl %q, got %q", "https://hooks.example.com/batch", body.WebhookUrl)
+		}
+
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"jobId": "batch-job-123",
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.YouTubeVideoBatch(&YouTubeVideoBatchParams{
+		VideoIds:   []string{"video1"},
+		WebhookUrl: "https://hooks.example.com/batch",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // =============================================================================
 // YouTube Transcript Tests
 // =============================================================================
@@ -1463,6 +1780,9 @@ func TestYouTubeTranscript_WithParams(t *testing.T) {
 		if got := q.Get("chunkSize"); got != "500" {
 			t.Errorf("expected chunkSize=500, got %q", got)
 		}
+		if got := q.Get("mode"); got != "generate" {
+			t.Errorf("expected mode=generate, got %q", got)
+		}
 
 		jsonResponse(w, http.StatusOK, map[string]any{
 			"content":        []map[string]any{},
@@ -1476,8 +1796,9 @@ func TestYouTubeTranscript_WithParams(t *testing.T) {
 	_, err := client.YouTubeTranscript(&YouTubeTranscriptParams{
 		Url:       "https://youtube.com/watch?v=123",
 		Lang:      "es",
-		Text:      true,
+		Text:      Bool(true),
 		ChunkSize: 500,
+		Mode:      Generate,
 	})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -1517,6 +1838,52 @@ func TestYouTubeTranscriptBatch_Success(t *testing.T) {
 	}
 }
 
+func TestYouTubeTranscriptBatch_Translate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		if body["translate"] != true || body["lang"] != "de" {
+			t.Errorf("expected translate=true and lang=de, got %v", body)
+		}
+
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"jobId": "translate-batch-123",
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	result, err := client.YouTubeTranscriptBatch(&YouTubeTranscriptBatchParams{
+		PlaylistId: "PLxyz123",
+		Lang:       "de",
+		Translate:  Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.JobId != "translate-batch-123" {
+		t.Errorf("expected jobId %q, got %q", "translate-batch-123", result.JobId)
+	}
+}
+
+func TestYouTubeTranscriptBatch_TranslateWithoutLang(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request")
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.YouTubeTranscriptBatch(&YouTubeTranscriptBatchParams{
+		PlaylistId: "PLxyz123",
+		Translate:  Bool(true),
+	})
+	if !errors.Is(err, ErrTranslateLangRequired) {
+		t.Fatalf("expected ErrTranslateLangRequired, got %v", err)
+	}
+}
+
 // =============================================================================
 // YouTube Transcript Translate Tests
 // =============================================================================
@@ -1713,6 +2080,30 @@ func TestYouTubeChannelVideos_WithParams(t *testing.T) {
 	}
 }
 
+func TestYouTubeChannelVideos_Pagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("nextPageToken"); got != "token-1" {
+			t.Errorf("expected nextPageToken=token-1, got %q", got)
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"videoIds":      []string{"v1"},
+			"shortIds":      []string{},
+			"liveIds":       []string{},
+			"nextPageToken": "token-2",
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	result, err := client.YouTubeChannelVideos(&YouTubeChannelVideosParams{Id: "channel123", NextPageToken: "token-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.NextPageToken != "token-2" {
+		t.Errorf("expected nextPageToken %q, got %q", "token-2", result.NextPageToken)
+	}
+}
+
 // =============================================================================
 // YouTube Playlist Videos Tests
 // =============================================================================
@@ -1769,6 +2160,28 @@ func TestYouTubePlaylistVideos_WithLimit(t *testing.T) {
 	}
 }
 
+func TestYouTubePlaylistVideos_Pagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("nextPageToken"); got != "token-1" {
+			t.Errorf("expected nextPageToken=token-1, got %q", got)
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"videoIds":      []string{"v1"},
+			"nextPageToken": "token-2",
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	result, err := client.YouTubePlaylistVideos(&YouTubePlaylistVideosParams{Id: "PLxyz123", NextPageToken: "token-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.NextPageToken != "token-2" {
+		t.Errorf("expected nextPageToken %q, got %q", "token-2", result.NextPageToken)
+	}
+}
+
 // =============================================================================
 // YouTube Batch Result Tests
 // =============================================================================
@@ -0,0 +1,93 @@
+package supadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Thumbnail is an image of a video, a channel or a search result at a given resolution
+type Thumbnail struct {
+	Url string `json:"url"`
+	// Width and Height are zero when the API does not report the resolution
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+}
+
+// youTubeThumbnailSizes are the resolutions of the standard YouTube thumbnail files, by file name
+var youTubeThumbnailSizes = map[string][2]int{
+	"default":       {120, 90},
+	"mqdefault":     {320, 180},
+	"hqdefault":     {480, 360},
+	"sddefault":     {640, 480},
+	"hq720":         {1280, 720},
+	"maxresdefault": {1280, 720},
+}
+
+// size returns the resolution of the thumbnail, inferred from the file name of standard YouTube thumbnails when the
+// API does not report it
+func (t Thumbnail) size() (int, int) {
+	if t.Width > 0 && t.Height > 0 {
+		return t.Width, t.Height
+	}
+	u, err := url.Parse(t.Url)
+	if err != nil {
+		return 0, 0
+	}
+	name := strings.TrimSuffix(path.Base(u.Path), path.Ext(u.Path))
+	size := youTubeThumbnailSizes[name]
+	return size[0], size[1]
+}
+
+// Thumbnails lists the sizes of a thumbnail. It is decoded from a single URL as well as from a list of sizes, so
+// that responses holding either are supported.
+type Thumbnails []Thumbnail
+
+// UnmarshalJSON decodes a thumbnail URL, a thumbnail object or a list of them
+func (t *Thumbnails) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*t = nil
+		return nil
+	case bytes.HasPrefix(data, []byte(`"`)):
+		var u string
+		if err := json.Unmarshal(data, &u); err != nil {
+			return err
+		}
+		*t = nil
+		if u != "" {
+			*t = Thumbnails{{Url: u}}
+		}
+		return nil
+	case bytes.HasPrefix(data, []byte("{")):
+		var thumbnail Thumbnail
+		if err := json.Unmarshal(data, &thumbnail); err != nil {
+			return err
+		}
+		*t = Thumbnails{thumbnail}
+		return nil
+	}
+	var thumbnails []Thumbnail
+	if err := json.Unmarshal(data, &thumbnails); err != nil {
+		return err
+	}
+	*t = thumbnails
+	return nil
+}
+
+// Best returns the thumbnail with the highest resolution, the first one when the resolutions are unknown, or the
+// zero Thumbnail when there is none
+func (t Thumbnails) Best() Thumbnail {
+	var best Thumbnail
+	bestArea := -1
+	for _, thumbnail := range t {
+		width, height := thumbnail.size()
+		if width*height > bestArea {
+			best, bestArea = thumbnail, width*height
+		}
+	}
+	return best
+}
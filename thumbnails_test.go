@@ -0,0 +1,84 @@
+package supadata
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestThumbnails_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected int
+	}{
+		{"url", `"https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg"`, 1},
+		{"empty url", `""`, 0},
+		{"null", `null`, 0},
+		{"object", `{"url": "https://example.com/a.jpg", "width": 100, "height": 100}`, 1},
+		{"list", `[{"url": "https://example.com/a.jpg"}, {"url": "https://example.com/b.jpg"}]`, 2},
+	}
+	for _, tt := range tests {
+		var thumbnails Thumbnails
+		if err := json.Unmarshal([]byte(tt.data), &thumbnails); err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if len(thumbnails) != tt.expected {
+			t.Errorf("%s: expected %d thumbnails, got %v", tt.name, tt.expected, thumbnails)
+		}
+	}
+
+	var thumbnails Thumbnails
+	if err := json.Unmarshal([]byte(`42`), &thumbnails); err == nil {
+		t.Error("expected an error for a number")
+	}
+}
+
+func TestThumbnails_Best(t *testing.T) {
+	sized := Thumbnails{
+		{Url: "https://example.com/small.jpg", Width: 120, Height: 90},
+		{Url: "https://example.com/large.jpg", Width: 1280, Height: 720},
+		{Url: "https://example.com/medium.jpg", Width: 480, Height: 360},
+	}
+	if best := sized.Best(); best.Url != "https://example.com/large.jpg" {
+		t.Errorf("expected the largest thumbnail, got %+v", best)
+	}
+
+	inferred := Thumbnails{
+		{Url: "https://i.ytimg.com/vi/dQw4w9WgXcQ/mqdefault.jpg"},
+		{Url: "https://i.ytimg.com/vi/dQw4w9WgXcQ/maxresdefault.jpg"},
+		{Url: "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg"},
+	}
+	if best := inferred.Best(); best.Url != "https://i.ytimg.com/vi/dQw4w9WgXcQ/maxresdefault.jpg" {
+		t.Errorf("expected the resolution to be inferred from YouTube file names, got %+v", best)
+	}
+
+	unknown := Thumbnails{{Url: "https://example.com/a.jpg"}, {Url: "https://example.com/b.jpg"}}
+	if best := unknown.Best(); best.Url != "https://example.com/a.jpg" {
+		t.Errorf("expected the first thumbnail when the resolutions are unknown, got %+v", best)
+	}
+	if best := (Thumbnails{}).Best(); best != (Thumbnail{}) {
+		t.Errorf("expected the zero thumbnail, got %+v", best)
+	}
+}
+
+func TestYouTubeVideo_Thumbnails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"id":        "dQw4w9WgXcQ",
+			"thumbnail": "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg",
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	video, err := client.YouTubeVideo("dQw4w9WgXcQ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if best := video.Thumbnails.Best(); best.Url != "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg" {
+		t.Errorf("unexpected thumbnail %+v", best)
+	}
+}
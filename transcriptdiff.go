@@ -0,0 +1,143 @@
+package supadata
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// SegmentDiff compares the native and generated transcripts over the time span of a segment
+type SegmentDiff struct {
+	Offset   float64
+	Duration float64
+	// Native and Generated hold the text of each transcript over the span, empty when it has none
+	Native    string
+	Generated string
+	// Similarity is the share of words common to both texts in the same order, from 0 to 1
+	Similarity float64
+}
+
+// TranscriptDiff is the segment-aligned comparison of the native and generated transcripts of a video
+type TranscriptDiff struct {
+	Native    *YouTubeTranscriptResult
+	Generated *YouTubeTranscriptResult
+	Segments  []SegmentDiff
+	// Similarity is the similarity of the whole transcripts, from 0 to 1
+	Similarity float64
+}
+
+// CompareTranscripts fetches the transcript of a video in the Native and Generate modes and compares them segment
+// by segment, e.g. to evaluate the quality of generated captions
+func (s *Supadata) CompareTranscripts(ctx context.Context, videoId string, lang Lang) (*TranscriptDiff, error) {
+	ctx = ensureLineage(ctx)
+	native, err := s.YouTubeTranscript(&YouTubeTranscriptParams{VideoId: videoId, Lang: lang, Mode: Native}, WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	generated, err := s.YouTubeTranscript(&YouTubeTranscriptParams{VideoId: videoId, Lang: lang, Mode: Generate}, WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	diff := DiffTranscripts(native.Content, generated.Content)
+	diff.Native, diff.Generated = native, generated
+	return diff, nil
+}
+
+// DiffTranscripts aligns the generated segments on the native ones by time, each generated segment being assigned to
+// the native segment covering its midpoint, and scores the similarity of every aligned pair. The generated segments
+// covered by no native segment are reported on their own. Words are compared regardless of case and punctuation.
+func DiffTranscripts(native, generated []TranscriptContent) *TranscriptDiff {
+	diff := &TranscriptDiff{}
+	aligned := make([][]string, len(native))
+	var unaligned []SegmentDiff
+	for _, segment := range generated {
+		if i := coveringSegment(native, segment.Offset+segment.Duration/2); i >= 0 {
+			aligned[i] = append(aligned[i], segment.Text)
+			continue
+		}
+		unaligned = append(unaligned, SegmentDiff{Offset: segment.Offset, Duration: segment.Duration, Generated: segment.Text})
+	}
+
+	var common, total int
+	score := func(d *SegmentDiff) {
+		nativeWords, generatedWords := diffWords(d.Native), diffWords(d.Generated)
+		lcs := commonWords(nativeWords, generatedWords)
+		common += 2 * lcs
+		total += len(nativeWords) + len(generatedWords)
+		d.Similarity = similarity(2*lcs, len(nativeWords)+len(generatedWords))
+	}
+	for i, segment := range native {
+		d := SegmentDiff{
+			Offset:    segment.Offset,
+			Duration:  segment.Duration,
+			Native:    segment.Text,
+			Generated: strings.Join(aligned[i], " "),
+		}
+		score(&d)
+		diff.Segments = append(diff.Segments, d)
+	}
+	for _, d := range unaligned {
+		score(&d)
+		diff.Segments = insertSegmentDiff(diff.Segments, d)
+	}
+	diff.Similarity = similarity(common, total)
+	return diff
+}
+
+// coveringSegment returns the index of the segment covering offset, or -1 when there is none
+func coveringSegment(segments []TranscriptContent, offset float64) int {
+	for i, segment := range segments {
+		if offset >= segment.Offset && offset < segment.Offset+segment.Duration {
+			return i
+		}
+	}
+	return -1
+}
+
+// insertSegmentDiff inserts d in segments, keeping them sorted by offset
+func insertSegmentDiff(segments []SegmentDiff, d SegmentDiff) []SegmentDiff {
+	i := len(segments)
+	for i > 0 && segments[i-1].Offset > d.Offset {
+		i--
+	}
+	segments = append(segments, SegmentDiff{})
+	copy(segments[i+1:], segments[i:])
+	segments[i] = d
+	return segments
+}
+
+// similarity returns the ratio of common to total words, two empty texts being identical
+func similarity(common, total int) float64 {
+	if total == 0 {
+		return 1
+	}
+	return float64(common) / float64(total)
+}
+
+// diffWords splits text into lowercase words without punctuation, leaving out the non-speech cues
+func diffWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(StripNonSpeech(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+}
+
+// commonWords returns the length of the longest common subsequence of words of a and b
+func commonWords(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			switch {
+			case a[i] == b[j]:
+				curr[j+1] = prev[j] + 1
+			case prev[j+1] >= curr[j]:
+				curr[j+1] = prev[j+1]
+			default:
+				curr[j+1] = curr[j]
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
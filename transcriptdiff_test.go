@@ -0,0 +1,70 @@
+package supadata
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiffTranscripts(t *testing.T) {
+	native := []TranscriptContent{
+		{Text: "We're no strangers to love", Offset: 0, Duration: 2000},
+		{Text: "You know the rules and so do I", Offset: 2000, Duration: 3000},
+	}
+	generated := []TranscriptContent{
+		{Text: "we're no strangers", Offset: 0, Duration: 1000},
+		{Text: "to love!", Offset: 1000, Duration: 800},
+		{Text: "you know the rules and so do eye", Offset: 2000, Duration: 3000},
+		{Text: "[Music] outro", Offset: 6000, Duration: 1000},
+	}
+
+	diff := DiffTranscripts(native, generated)
+	if len(diff.Segments) != 3 {
+		t.Fatalf("expected 3 segments, got %+v", diff.Segments)
+	}
+	if first := diff.Segments[0]; first.Generated != "we're no strangers to love!" || first.Similarity != 1 {
+		t.Errorf("expected the first segments to be merged and identical, got %+v", first)
+	}
+	if second := diff.Segments[1]; math.Abs(second.Similarity-7.0/8) > 1e-9 {
+		t.Errorf("expected a similarity of 7/8, got %+v", second)
+	}
+	if last := diff.Segments[2]; last.Native != "" || last.Offset != 6000 || last.Similarity != 0 {
+		t.Errorf("expected the unaligned segment to be reported, got %+v", last)
+	}
+	// 5+5 common words in the first segment, 7+7 in the second, over 5+5 + 8+8 + 1 words
+	if expected := 24.0 / 27; math.Abs(diff.Similarity-expected) > 1e-9 {
+		t.Errorf("expected an overall similarity of %f, got %f", expected, diff.Similarity)
+	}
+
+	if empty := DiffTranscripts(nil, nil); empty.Similarity != 1 || len(empty.Segments) != 0 {
+		t.Errorf("expected empty transcripts to be identical, got %+v", empty)
+	}
+}
+
+func TestCompareTranscripts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		text := "hello world"
+		if r.URL.Query().Get("mode") == string(Generate) {
+			text = "hello word"
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"content": []map[string]any{{"text": text, "offset": 0, "duration": 1000}},
+			"lang":    "en",
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	diff, err := client.CompareTranscripts(context.Background(), "dQw4w9WgXcQ", LangEnglish)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff.Native.Content[0].Text != "hello world" || diff.Generated.Content[0].Text != "hello word" {
+		t.Errorf("unexpected transcripts %+v %+v", diff.Native, diff.Generated)
+	}
+	if diff.Similarity != 0.5 {
+		t.Errorf("expected a similarity of 0.5, got %f", diff.Similarity)
+	}
+}
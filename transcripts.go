@@ -0,0 +1,253 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PlaylistTranscriptsOptions customizes PlaylistTranscripts
+type PlaylistTranscriptsOptions struct {
+	Text *bool
+	// Concurrency is the number of transcripts fetched at the same time on the free plan, DefaultFetchConcurrency
+	// when zero
+	Concurrency int
+	// Wait customizes how the transcript batch is waited for
+	Wait []WaitOption
+}
+
+// PlaylistTranscripts retrieves the transcripts of every video of a YouTube playlist, keyed by video ID.
+// The transcripts are retrieved with a transcript batch, or with FetchTranscripts when the account is on the free plan.
+// Videos whose transcript could not be retrieved are missing from the map and reported in the returned error,
+// alongside the transcripts that were retrieved.
+func (s *Supadata) PlaylistTranscripts(ctx context.Context, playlistId string, lang Lang, opts *PlaylistTranscriptsOptions) (map[string]*YouTubeTranscriptResult, error) {
+	ctx = ensureLineage(ctx)
+	var videoIds []string
+	for videoId, err := range s.AllPlaylistVideoIds(ctx, playlistId) {
+		if err != nil {
+			return nil, err
+		}
+		videoIds = append(videoIds, videoId)
+	}
+	return s.collectTranscripts(ctx, videoIds, lang, opts)
+}
+
+// collectTranscripts retrieves the transcripts of videoIds, with a batch unless the account is on the free plan
+func (s *Supadata) collectTranscripts(ctx context.Context, videoIds []string, lang Lang, opts *PlaylistTranscriptsOptions) (map[string]*YouTubeTranscriptResult, error) {
+	if opts == nil {
+		opts = &PlaylistTranscriptsOptions{}
+	}
+	wc := newWaitConfig(opts.Wait)
+	if err := checkProgress[YouTubeBatchStats](wc); err != nil {
+		return nil, err
+	}
+	transcripts := make(map[string]*YouTubeTranscriptResult, len(videoIds))
+	if len(videoIds) == 0 {
+		return transcripts, nil
+	}
+
+	var errs []error
+	if s.onFreePlan(ctx) {
+		results := s.FetchTranscripts(ctx, videoIds, &FetchTranscriptsOptions{
+			Concurrency: opts.Concurrency,
+			Lang:        lang,
+			Text:        opts.Text,
+		})
+		for _, result := range results {
+			if result.Err != nil {
+				errs = append(errs, fmt.Errorf("video %s: %w", result.VideoId, result.Err))
+				continue
+			}
+			transcripts[result.VideoId] = result.Transcript
+		}
+		return transcripts, errors.Join(errs...)
+	}
+
+	job, err := s.YouTubeTranscriptBatch(&YouTubeTranscriptBatchParams{
+		VideoIds: videoIds,
+		Lang:     lang,
+		Text:     opts.Text,
+	}, WithContext(ctx))
+	if job == nil {
+		return nil, err
+	}
+	errs = append(errs, err)
+
+	result, err := s.waitForBatchJob(ctx, job, wc)
+	if err != nil {
+		return nil, err
+	}
+	errs = append(errs, batchTranscripts(job.JobId, result, func(videoId string, transcript *YouTubeTranscriptResult) error {
+		transcripts[videoId] = transcript
+		return nil
+	})...)
+	return transcripts, errors.Join(errs...)
+}
+
+// ChannelTranscriptsOptions customizes ChannelTranscripts
+type ChannelTranscriptsOptions struct {
+	Lang Lang
+	Text *bool
+	// Sink, when set, receives every transcript as soon as its batch has finished, e.g. a DirSink writing them
+	// to disk. The sink is closed once all transcripts are written.
+	Sink OutputSink
+	// Concurrency is the number of transcripts fetched at the same time on the free plan, DefaultFetchConcurrency
+	// when zero
+	Concurrency int
+	// Wait customizes how the transcript batches are waited for
+	Wait []WaitOption
+}
+
+// channelRecordPrefix prefixes the ID of the job store records tracking the batches of a channel
+const channelRecordPrefix = "channel:"
+
+// ChannelTranscripts retrieves the transcripts of every video, short and live stream of a YouTube channel, keyed by
+// video ID. channelRef is any reference accepted by ParseChannelRef. The videos are submitted as transcript batches
+// of at most MaxBatchVideoIds videos, or fetched with FetchTranscripts when the account is on the free plan.
+//
+// With a job store configured, the submitted batches are recorded so that a later call for the same channel, e.g.
+// after a restart, resumes waiting for the batches that had not finished instead of submitting the channel again.
+// The returned map then only holds the transcripts of the resumed batches, use a Sink to keep every transcript.
+// Videos whose transcript could not be retrieved are reported in the returned error.
+func (s *Supadata) ChannelTranscripts(ctx context.Context, channelRef string, opts *ChannelTranscriptsOptions) (map[string]*YouTubeTranscriptResult, error) {
+	ctx = ensureLineage(ctx)
+	if opts == nil {
+		opts = &ChannelTranscriptsOptions{}
+	}
+	closeSink := func() error {
+		if opts.Sink == nil {
+			return nil
+		}
+		return opts.Sink.Close()
+	}
+	wc := newWaitConfig(opts.Wait)
+	if err := checkProgress[YouTubeBatchStats](wc); err != nil {
+		return nil, errors.Join(err, closeSink())
+	}
+	channelId, err := resolveId(channelRef, ParseChannelRef)
+	if err != nil {
+		return nil, errors.Join(err, closeSink())
+	}
+
+	transcripts := make(map[string]*YouTubeTranscriptResult)
+	add := func(videoId string, transcript *YouTubeTranscriptResult) error {
+		transcripts[videoId] = transcript
+		if opts.Sink == nil {
+			return nil
+		}
+		return opts.Sink.WriteTranscript(videoId, transcript)
+	}
+
+	recordId := channelRecordPrefix + channelId
+	jobIds, err := s.pendingChannelJobs(recordId)
+	if err != nil {
+		return nil, errors.Join(err, closeSink())
+	}
+
+	var errs []error
+	if jobIds == nil {
+		var videoIds []string
+		for videoId, err := range s.AllChannelVideoIds(ctx, channelId) {
+			if err != nil {
+				return nil, errors.Join(err, closeSink())
+			}
+			videoIds = append(videoIds, videoId)
+		}
+
+		if s.onFreePlan(ctx) {
+			results := s.FetchTranscripts(ctx, videoIds, &FetchTranscriptsOptions{
+				Concurrency: opts.Concurrency,
+				Lang:        opts.Lang,
+				Text:        opts.Text,
+			})
+			for _, result := range results {
+				if result.Err != nil {
+					errs = append(errs, fmt.Errorf("video %s: %w", result.VideoId, result.Err))
+				} else if err := add(result.VideoId, result.Transcript); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			return transcripts, errors.Join(append(errs, closeSink())...)
+		}
+
+		for start := 0; start < len(videoIds); start += MaxBatchVideoIds {
+			job, err := s.YouTubeTranscriptBatch(&YouTubeTranscriptBatchParams{
+				VideoIds: videoIds[start:min(start+MaxBatchVideoIds, len(videoIds))],
+				Lang:     opts.Lang,
+				Text:     opts.Text,
+			}, WithContext(ctx))
+			if job == nil {
+				if len(jobIds) > 0 {
+					err = fmt.Errorf("%w (submitted batches %s)", err, strings.Join(jobIds, ", "))
+				}
+				return nil, errors.Join(append(errs, err, closeSink())...)
+			}
+			errs = append(errs, err)
+			// Each batch is recorded once submitted, so that the batches already paid for are resumed when a later
+			// one fails to be submitted
+			jobIds = append(jobIds, job.JobId)
+			errs = append(errs, s.trackChannelJobs(recordId, jobIds))
+		}
+	}
+
+	for i, jobId := range jobIds {
+		result, err := s.waitForBatchJob(ctx, &YouTubeBatchJob{JobId: jobId}, wc)
+		if err != nil {
+			return transcripts, errors.Join(append(errs, err, closeSink())...)
+		}
+		errs = append(errs, batchTranscripts(jobId, result, add)...)
+		errs = append(errs, s.trackChannelJobs(recordId, jobIds[i+1:]))
+	}
+	return transcripts, errors.Join(append(errs, closeSink())...)
+}
+
+// pendingChannelJobs returns the batches of a channel that were submitted but not finished by an earlier call,
+// nil when there are none or no job store is configured
+func (s *Supadata) pendingChannelJobs(recordId string) ([]string, error) {
+	if s.config.jobStore == nil {
+		return nil, nil
+	}
+	record, err := s.config.jobStore.Load(recordId)
+	if errors.Is(err, ErrJobNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading job %s: %w", recordId, err)
+	}
+	return record.JobIds, nil
+}
+
+// trackChannelJobs records the batches of a channel that have not finished yet, removing the record once all have
+func (s *Supadata) trackChannelJobs(recordId string, jobIds []string) error {
+	if len(jobIds) == 0 {
+		return s.forgetJob(recordId)
+	}
+	return s.registerJobRecord(JobRecord{Id: recordId, Kind: JobChannelTranscripts, JobIds: jobIds})
+}
+
+// batchTranscripts calls add with every transcript of a finished transcript batch and returns the errors of the
+// batch, its items and add
+func batchTranscripts(jobId string, result *YouTubeBatchResult, add func(videoId string, transcript *YouTubeTranscriptResult) error) []error {
+	var errs []error
+	if result.Status == BatchFailed {
+		errs = append(errs, fmt.Errorf("transcript batch %s failed", jobId))
+	}
+	for _, item := range result.Results {
+		switch {
+		case item.ErrorCode != "":
+			errs = append(errs, fmt.Errorf("video %s: %s", item.VideoId, item.ErrorCode))
+		case item.Transcript != nil:
+			if err := add(item.VideoId, item.Transcript); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// onFreePlan reports whether the account is on the free plan, false when the plan cannot be determined
+func (s *Supadata) onFreePlan(ctx context.Context) bool {
+	account, err := s.Me(WithContext(ctx))
+	return err == nil && strings.EqualFold(account.Plan, freePlan)
+}
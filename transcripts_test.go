@@ -0,0 +1,236 @@
+package supadata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func playlistTranscriptsServer(t *testing.T, plan string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/me":
+			jsonResponse(w, http.StatusOK, map[string]any{"plan": plan})
+		case "/youtube/playlist/videos":
+			if r.URL.Query().Get("nextPageToken") == "" {
+				jsonResponse(w, http.StatusOK, map[string]any{"videoIds": []string{"a", "b"}, "nextPageToken": "page-2"})
+				return
+			}
+			jsonResponse(w, http.StatusOK, map[string]any{"shortIds": []string{"missing"}})
+		case "/youtube/transcript":
+			videoId := r.URL.Query().Get("videoId")
+			if videoId == "missing" {
+				errorResponse(w, http.StatusNotFound, NotFound, "Not found", "")
+				return
+			}
+			jsonResponse(w, http.StatusOK, map[string]any{"lang": "en", "content": []map[string]any{{"text": videoId}}})
+		case "/youtube/transcript/batch":
+			var body YouTubeTranscriptBatchParams
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+			if len(body.VideoIds) != 3 || body.Lang != "en" {
+				t.Errorf("unexpected batch params %+v", body)
+			}
+			jsonResponse(w, http.StatusOK, map[string]any{"jobId": "job-1"})
+		case "/youtube/batch/job-1":
+			jsonResponse(w, http.StatusOK, map[string]any{
+				"status": "completed",
+				"results": []map[string]any{
+					{"videoId": "a", "transcript": map[string]any{"lang": "en", "content": []map[string]any{{"text": "a"}}}},
+					{"videoId": "b", "transcript": map[string]any{"lang": "en", "content": []map[string]any{{"text": "b"}}}},
+					{"videoId": "missing", "errorCode": "transcript-unavailable"},
+				},
+			})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestPlaylistTranscripts(t *testing.T) {
+	for _, plan := range []string{"Pro", "Free"} {
+		t.Run(plan, func(t *testing.T) {
+			server := playlistTranscriptsServer(t, plan)
+			defer server.Close()
+
+			client := newTestClient(server)
+			transcripts, err := client.PlaylistTranscripts(context.Background(), "PL1", "en", &PlaylistTranscriptsOptions{
+				Wait: []WaitOption{WithPollInterval(time.Millisecond)},
+			})
+			if err == nil {
+				t.Fatal("expected the missing transcript to be reported")
+			}
+			if len(transcripts) != 2 {
+				t.Fatalf("expected 2 transcripts, got %d", len(transcripts))
+			}
+			for _, videoId := range []string{"a", "b"} {
+				transcript := transcripts[videoId]
+				if transcript == nil || transcript.Content[0].Text != videoId {
+					t.Errorf("unexpected transcript for %s: %+v", videoId, transcript)
+				}
+			}
+		})
+	}
+}
+
+func channelTranscriptsServer(t *testing.T, requests map[string]int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/me":
+			jsonResponse(w, http.StatusOK, map[string]any{"plan": "Pro"})
+		case "/youtube/channel/videos":
+			if got := r.URL.Query().Get("id"); got != "@creator" {
+				t.Errorf("expected id @creator, got %q", got)
+			}
+			jsonResponse(w, http.StatusOK, map[string]any{"videoIds": []string{"a", "b"}})
+		case "/youtube/transcript/batch":
+			jsonResponse(w, http.StatusOK, map[string]any{"jobId": "job-1"})
+		case "/youtube/batch/job-1", "/youtube/batch/job-2":
+			videoId := map[string]string{"/youtube/batch/job-1": "a", "/youtube/batch/job-2": "c"}[r.URL.Path]
+			jsonResponse(w, http.StatusOK, map[string]any{
+				"status": "completed",
+				"results": []map[string]any{
+					{"videoId": videoId, "transcript": map[string]any{"lang": "en", "content": []map[string]any{{"text": videoId}}}},
+				},
+			})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestChannelTranscripts(t *testing.T) {
+	requests := make(map[string]int)
+	server := channelTranscriptsServer(t, requests)
+	defer server.Close()
+
+	store := NewMemoryJobStore()
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithJobStore(store))
+	var buf bytes.Buffer
+	transcripts, err := client.ChannelTranscripts(context.Background(), "https://www.youtube.com/@creator", &ChannelTranscriptsOptions{
+		Lang: "en",
+		Sink: NewJSONLSink(&buf),
+		Wait: []WaitOption{WithPollInterval(time.Millisecond)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(transcripts) != 1 || transcripts["a"] == nil {
+		t.Errorf("expected the transcript of a, got %v", transcripts)
+	}
+	if !strings.Contains(buf.String(), `"videoId":"a"`) {
+		t.Errorf("expected the transcript to be written to the sink, got %q", buf.String())
+	}
+	if records, _ := store.List(); len(records) != 0 {
+		t.Errorf("expected no records left, got %+v", records)
+	}
+}
+
+func TestChannelTranscripts_Resume(t *testing.T) {
+	requests := make(map[string]int)
+	server := channelTranscriptsServer(t, requests)
+	defer server.Close()
+
+	store := NewMemoryJobStore()
+	if err := store.Save(JobRecord{Id: "channel:@creator", Kind: JobChannelTranscripts, JobIds: []string{"job-2"}}); err != nil {
+		t.Fatal(err)
+	}
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithJobStore(store))
+	transcripts, err := client.ChannelTranscripts(context.Background(), "@creator", &ChannelTranscriptsOptions{
+		Wait: []WaitOption{WithPollInterval(time.Millisecond)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(transcripts) != 1 || transcripts["c"] == nil {
+		t.Errorf("expected the transcript of the resumed batch, got %v", transcripts)
+	}
+	if requests["/youtube/channel/videos"] != 0 || requests["/youtube/transcript/batch"] != 0 {
+		t.Errorf("expected the channel not to be submitted again, got %v", requests)
+	}
+	if _, err := store.Load("channel:@creator"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("expected the record to be removed, got %v", err)
+	}
+}
+
+func TestChannelTranscripts_RecordsBatchesBeforeSubmissionFailure(t *testing.T) {
+	videoIds := make([]string, MaxBatchVideoIds+1)
+	for i := range videoIds {
+		videoIds[i] = fmt.Sprintf("video-%05d", i)
+	}
+	var batches int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/me":
+			jsonResponse(w, http.StatusOK, map[string]any{"plan": "Pro"})
+		case "/youtube/channel/videos":
+			jsonResponse(w, http.StatusOK, map[string]any{"videoIds": videoIds})
+		case "/youtube/transcript/batch":
+			batches++
+			if batches > 1 {
+				errorResponse(w, http.StatusBadRequest, InvalidRequest, "Invalid request", "")
+				return
+			}
+			jsonResponse(w, http.StatusOK, map[string]any{"jobId": "job-1"})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	store := NewMemoryJobStore()
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithJobStore(store))
+	_, err := client.ChannelTranscripts(context.Background(), "@creator", nil)
+	if err == nil || !strings.Contains(err.Error(), "job-1") {
+		t.Fatalf("expected the submission error to name the submitted batch, got %v", err)
+	}
+	record, err := store.Load("channel:@creator")
+	if err != nil {
+		t.Fatalf("expected the submitted batch to be recorded, got %v", err)
+	}
+	if len(record.JobIds) != 1 || record.JobIds[0] != "job-1" {
+		t.Errorf("expected job-1 to be recorded, got %v", record.JobIds)
+	}
+}
+
+// closeTrackingSink records whether the sink it wraps was closed
+type closeTrackingSink struct {
+	OutputSink
+	closed bool
+}
+
+func (s *closeTrackingSink) Close() error {
+	s.closed = true
+	return s.OutputSink.Close()
+}
+
+func TestChannelTranscripts_ClosesSinkWhenResumeFails(t *testing.T) {
+	store, err := NewFileJobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.WriteFile(store.path("channel:@creator"), []byte("{"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := NewSupadata(WithAPIKey("test-api-key"), WithJobStore(store))
+	sink := &closeTrackingSink{OutputSink: NewJSONLSink(io.Discard)}
+	if _, err := client.ChannelTranscripts(context.Background(), "@creator", &ChannelTranscriptsOptions{Sink: sink}); err == nil {
+		t.Fatal("expected an error loading the corrupt record, got nil")
+	}
+	if !sink.closed {
+		t.Error("expected the sink to be closed")
+	}
+}
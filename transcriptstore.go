@@ -0,0 +1,183 @@
+package supadata
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrTranscriptNotFound is returned by a TranscriptStore when no transcript is stored for a key
+var ErrTranscriptNotFound = errors.New("transcript not found")
+
+// TranscriptKey identifies a stored transcript by the parameters it was requested with
+type TranscriptKey struct {
+	VideoId string
+	Lang    string
+	Mode    TranscriptModeParam
+}
+
+func (k TranscriptKey) String() string {
+	return k.VideoId + "." + k.Lang + "." + string(k.Mode)
+}
+
+// TranscriptStore persists YouTube transcripts so that they are not fetched, and charged, again
+type TranscriptStore interface {
+	// Get returns ErrTranscriptNotFound when no transcript is stored for key
+	Get(key TranscriptKey) (*YouTubeTranscriptResult, error)
+	Put(key TranscriptKey, transcript *YouTubeTranscriptResult) error
+}
+
+// WithTranscriptStore serves the YouTubeTranscript calls from store when it holds the transcript of the same video,
+// lang and mode, and stores the transcripts fetched from the API. Only the calls without Text or ChunkSize use the
+// store. When a transcript cannot be stored, it is returned along with the error.
+func WithTranscriptStore(store TranscriptStore) ConfigOption {
+	return func(config *Config) {
+		config.transcriptStore = store
+	}
+}
+
+// transcriptKey returns the key of the transcript requested by params, or false when the call bypasses the store
+func (s *Supadata) transcriptKey(params *YouTubeTranscriptParams) (TranscriptKey, bool) {
+	if s.config.transcriptStore == nil || isTrue(params.Text) || params.ChunkSize > 0 {
+		return TranscriptKey{}, false
+	}
+	videoId := params.VideoId
+	if videoId == "" {
+		var err error
+		if videoId, err = ParseVideoID(params.Url); err != nil {
+			return TranscriptKey{}, false
+		}
+	}
+	return TranscriptKey{VideoId: videoId, Lang: string(params.Lang), Mode: params.Mode}, true
+}
+
+// storedTranscript returns the stored transcript of key, or nil when none is stored
+func (s *Supadata) storedTranscript(key TranscriptKey) (*YouTubeTranscriptResult, error) {
+	transcript, err := s.config.transcriptStore.Get(key)
+	if errors.Is(err, ErrTranscriptNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading stored transcript %s: %w", key, err)
+	}
+	return transcript, nil
+}
+
+// storeTranscript saves a transcript fetched from the API
+func (s *Supadata) storeTranscript(key TranscriptKey, transcript *YouTubeTranscriptResult) error {
+	if err := s.config.transcriptStore.Put(key, transcript); err != nil {
+		return fmt.Errorf("storing transcript %s: %w", key, err)
+	}
+	return nil
+}
+
+// FileTranscriptStore is a TranscriptStore keeping one JSON file per transcript in a directory
+type FileTranscriptStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileTranscriptStore creates a FileTranscriptStore in dir, creating the directory when needed
+func NewFileTranscriptStore(dir string) (*FileTranscriptStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &FileTranscriptStore{dir: dir}, nil
+}
+
+func (f *FileTranscriptStore) path(key TranscriptKey) string {
+	return filepath.Join(f.dir, url.PathEscape(key.String())+".json")
+}
+
+func (f *FileTranscriptStore) Get(key TranscriptKey) (*YouTubeTranscriptResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	data, err := os.ReadFile(filepath.Clean(f.path(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrTranscriptNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var transcript YouTubeTranscriptResult
+	if err := json.Unmarshal(data, &transcript); err != nil {
+		return nil, err
+	}
+	return &transcript, nil
+}
+
+// Put writes the transcript to a temporary file before renaming it, so that a crash never leaves a truncated file
+func (f *FileTranscriptStore) Put(key TranscriptKey, transcript *YouTubeTranscriptResult) error {
+	data, err := json.Marshal(transcript)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	path := f.path(key)
+	if err := writeFile(path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, data); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+const sqliteTranscriptStoreSchema = `CREATE TABLE IF NOT EXISTS stored_transcripts (
+	video_id TEXT NOT NULL,
+	lang TEXT NOT NULL,
+	mode TEXT NOT NULL,
+	data TEXT NOT NULL,
+	PRIMARY KEY (video_id, lang, mode)
+)`
+
+// SQLiteTranscriptStore is a TranscriptStore keeping transcripts in the stored_transcripts table of a SQLite
+// database. As with SQLiteSink, the caller opens the database with the driver of their choice and closes it.
+type SQLiteTranscriptStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteTranscriptStore creates the stored_transcripts table when missing and returns a store using db
+func NewSQLiteTranscriptStore(db *sql.DB) (*SQLiteTranscriptStore, error) {
+	if _, err := db.Exec(sqliteTranscriptStoreSchema); err != nil {
+		return nil, err
+	}
+	return &SQLiteTranscriptStore{db: db}, nil
+}
+
+func (s *SQLiteTranscriptStore) Get(key TranscriptKey) (*YouTubeTranscriptResult, error) {
+	var data string
+	err := s.db.QueryRow(
+		`SELECT data FROM stored_transcripts WHERE video_id = ? AND lang = ? AND mode = ?`,
+		key.VideoId, key.Lang, string(key.Mode),
+	).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTranscriptNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var transcript YouTubeTranscriptResult
+	if err := json.Unmarshal([]byte(data), &transcript); err != nil {
+		return nil, err
+	}
+	return &transcript, nil
+}
+
+func (s *SQLiteTranscriptStore) Put(key TranscriptKey, transcript *YouTubeTranscriptResult) error {
+	data, err := json.Marshal(transcript)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(
+		`INSERT OR REPLACE INTO stored_transcripts (video_id, lang, mode, data) VALUES (?, ?, ?, ?)`,
+		key.VideoId, key.Lang, string(key.Mode), string(data),
+	)
+	return err
+}
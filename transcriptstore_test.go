@@ -0,0 +1,57 @@
+package supadata
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithTranscriptStore(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		jsonResponse(w, http.StatusOK, map[string]any{"lang": r.URL.Query().Get("lang"), "content": []map[string]any{{"text": "hello"}}})
+	}))
+	defer server.Close()
+
+	store, err := NewFileTranscriptStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithTranscriptStore(store))
+
+	params := &YouTubeTranscriptParams{VideoId: "abc", Lang: "en"}
+	for range 2 {
+		transcript, err := client.YouTubeTranscript(params)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if transcript.Lang != "en" || transcript.Content[0].Text != "hello" {
+			t.Errorf("unexpected transcript %+v", transcript)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the second call to be served from the store, got %d requests", requests)
+	}
+
+	if _, err := client.YouTubeTranscript(&YouTubeTranscriptParams{VideoId: "abc", Lang: "fr"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.YouTubeTranscript(params, WithForceRefresh()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("expected another lang and a forced refresh to be fetched, got %d requests", requests)
+	}
+}
+
+func TestFileTranscriptStore_NotFound(t *testing.T) {
+	store, err := NewFileTranscriptStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Get(TranscriptKey{VideoId: "abc"}); !errors.Is(err, ErrTranscriptNotFound) {
+		t.Errorf("expected ErrTranscriptNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package supadata
+
+import (
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// modulePath is the import path of this module, used to find its version in the build information
+const modulePath = "github.com/petros0/supadata-go"
+
+// sdkVersion returns the version of this module recorded in the build information of the running binary
+var sdkVersion = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return Version
+	}
+	return moduleVersion(info)
+})
+
+// moduleVersion returns the version of this module in info without its "v" prefix, or Version when the module is
+// not a versioned dependency, e.g. in tests or when built from a local checkout
+func moduleVersion(info *debug.BuildInfo) string {
+	mod := &info.Main
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			mod = dep
+			break
+		}
+	}
+	if mod.Path != modulePath {
+		return Version
+	}
+	if mod.Replace != nil {
+		mod = mod.Replace
+	}
+	if mod.Version == "" || mod.Version == "(devel)" {
+		return Version
+	}
+	return strings.TrimPrefix(mod.Version, "v")
+}
+
+// WithUserAgentSuffix appends suffix, typically "myapp/2.1", to the User-Agent sent with every request
+func WithUserAgentSuffix(suffix string) ConfigOption {
+	return func(config *Config) {
+		config.userAgentSuffix = suffix
+	}
+}
+
+// userAgent returns the User-Agent identifying this SDK and the application using it
+func (s *Supadata) userAgent() string {
+	ua := "supadata-go/" + sdkVersion()
+	if s.config.userAgentSuffix != "" {
+		ua += " " + s.config.userAgentSuffix
+	}
+	return ua
+}
@@ -0,0 +1,66 @@
+package supadata
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime/debug"
+	"testing"
+)
+
+func TestModuleVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		info *debug.BuildInfo
+		want string
+	}{
+		{
+			name: "dependency",
+			info: &debug.BuildInfo{
+				Main: debug.Module{Path: "example.com/app", Version: "v0.1.0"},
+				Deps: []*debug.Module{{Path: modulePath, Version: "v1.4.2"}},
+			},
+			want: "1.4.2",
+		},
+		{
+			name: "replaced dependency",
+			info: &debug.BuildInfo{
+				Deps: []*debug.Module{{Path: modulePath, Version: "v1.4.2", Replace: &debug.Module{Path: "../fork", Version: "v1.5.0"}}},
+			},
+			want: "1.5.0",
+		},
+		{
+			name: "local checkout",
+			info: &debug.BuildInfo{Main: debug.Module{Path: modulePath, Version: "(devel)"}},
+			want: Version,
+		},
+		{
+			name: "not a dependency",
+			info: &debug.BuildInfo{Main: debug.Module{Path: "example.com/app", Version: "v0.1.0"}},
+			want: Version,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := moduleVersion(tt.info); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithUserAgentSuffix(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := "supadata-go/" + sdkVersion() + " myapp/2.1"
+		if got := r.Header.Get("User-Agent"); got != want {
+			t.Errorf("expected User-Agent %q, got %q", want, got)
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"plan": "Free"})
+	}))
+	defer server.Close()
+
+	client := NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithUserAgentSuffix("myapp/2.1"))
+	if _, err := client.Me(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
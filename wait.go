@@ -0,0 +1,262 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	// DefaultPollInterval is the delay before the second poll of a job status
+	DefaultPollInterval = 2 * time.Second
+	// DefaultMaxPollInterval bounds the delay between two polls of a job status
+	DefaultMaxPollInterval = 30 * time.Second
+	// DefaultPollMultiplier is the factor applied to the delay between polls after every poll
+	DefaultPollMultiplier = 1.5
+	// DefaultPollJitter is the fraction by which every delay between polls is randomized
+	DefaultPollJitter = 0.1
+)
+
+// ErrMaxWaitExceeded is returned by the wait helpers when the job is still running after PollOptions.MaxWait
+var ErrMaxWaitExceeded = errors.New("maximum wait exceeded")
+
+// PollOptions controls how often the wait helpers poll a job status. The first delay is InitialInterval, and every
+// delay is Multiplier times the previous one, up to MaxInterval. Each delay is randomized by up to Jitter times its
+// value so that many waiters do not poll in lockstep. MaxWait, when positive, bounds the total time spent waiting.
+type PollOptions struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	Jitter          float64
+	MaxWait         time.Duration
+}
+
+// DefaultPollOptions returns the polling used by the wait helpers when no WaitOption changes it
+func DefaultPollOptions() PollOptions {
+	return PollOptions{
+		InitialInterval: DefaultPollInterval,
+		MaxInterval:     DefaultMaxPollInterval,
+		Multiplier:      DefaultPollMultiplier,
+		Jitter:          DefaultPollJitter,
+	}
+}
+
+// WaitOption customizes how a job is waited for
+type WaitOption func(*waitConfig)
+
+type waitConfig struct {
+	poll     PollOptions
+	progress any
+}
+
+func newWaitConfig(opts []WaitOption) *waitConfig {
+	wc := &waitConfig{poll: DefaultPollOptions()}
+	for _, opt := range opts {
+		opt(wc)
+	}
+	return wc
+}
+
+// WithPollOptions sets how the job status is polled. A zero InitialInterval, MaxInterval or Multiplier is replaced by
+// its default, while a zero Jitter disables the randomization.
+func WithPollOptions(poll PollOptions) WaitOption {
+	return func(wc *waitConfig) {
+		defaults := DefaultPollOptions()
+		if poll.InitialInterval <= 0 {
+			poll.InitialInterval = defaults.InitialInterval
+		}
+		if poll.MaxInterval <= 0 {
+			poll.MaxInterval = max(defaults.MaxInterval, poll.InitialInterval)
+		}
+		if poll.Multiplier <= 0 {
+			poll.Multiplier = defaults.Multiplier
+		}
+		wc.poll = poll
+	}
+}
+
+// WithPollInterval polls the job status at a fixed interval, keeping the maximum wait if any
+func WithPollInterval(interval time.Duration) WaitOption {
+	return func(wc *waitConfig) {
+		wc.poll = PollOptions{InitialInterval: interval, MaxInterval: interval, Multiplier: 1, MaxWait: wc.poll.MaxWait}
+	}
+}
+
+// pollUntil calls check until it reports the job as done, sleeping between calls as configured by wc. Transient
+// errors of check, e.g. a 5xx status or a connection error, are polled through; the job is checked a last time at
+// the maximum wait, if any.
+func pollUntil[T any](ctx context.Context, wc *waitConfig, check func() (T, bool, error)) (T, error) {
+	var deadline time.Time
+	if wc.poll.MaxWait > 0 {
+		deadline = time.Now().Add(wc.poll.MaxWait)
+	}
+	interval := wc.poll.InitialInterval
+	for {
+		result, done, err := check()
+		if done || (err != nil && !transientError(ctx, err)) {
+			return result, interrupted(ctx, err)
+		}
+
+		delay := jitter(interval, wc.poll.Jitter)
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				var zero T
+				if err != nil {
+					return zero, fmt.Errorf("%w: job still running after %s: %w", ErrMaxWaitExceeded, wc.poll.MaxWait, err)
+				}
+				return zero, fmt.Errorf("%w: job still running after %s", ErrMaxWaitExceeded, wc.poll.MaxWait)
+			}
+			delay = min(delay, remaining)
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			var zero T
+			return zero, interrupted(ctx, err)
+		}
+		interval = min(time.Duration(float64(interval)*wc.poll.Multiplier), wc.poll.MaxInterval)
+	}
+}
+
+// jitter randomizes d by up to fraction times its value in either direction
+func jitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return d
+	}
+	//nolint:gosec // spreading polls does not need a secure random source
+	return time.Duration(float64(d) * (1 + fraction*(2*rand.Float64()-1)))
+}
+
+// ErrProgressType is returned by the waiters given a WithProgress callback that does not accept their progress
+var ErrProgressType = errors.New("progress callback does not match the waiter")
+
+// WithProgress registers a callback invoked after every poll with the progress of the job.
+// The callback must accept the progress type of the waiter it is passed to, e.g. func(CrawlProgress) for
+// WaitForCrawl or func(YouTubeBatchStats) for WaitForYouTubeBatch; the waiter fails with ErrProgressType otherwise,
+// before polling or submitting anything.
+func WithProgress[P any](fn func(P)) WaitOption {
+	return func(wc *waitConfig) {
+		wc.progress = fn
+	}
+}
+
+// checkProgress returns an error wrapping ErrProgressType when wc has a progress callback not accepting progress of
+// type P
+func checkProgress[P any](wc *waitConfig) error {
+	if _, ok := wc.progress.(func(P)); ok || wc.progress == nil {
+		return nil
+	}
+	var zero P
+	return fmt.Errorf("%w: got %T, expected func(%T)", ErrProgressType, wc.progress, zero)
+}
+
+// checkNoProgress returns an error wrapping ErrProgressType when wc has a progress callback, for the waiters
+// reporting no progress
+func checkNoProgress(wc *waitConfig) error {
+	if wc.progress == nil {
+		return nil
+	}
+	return fmt.Errorf("%w: got %T, but the job reports no progress", ErrProgressType, wc.progress)
+}
+
+// reportProgress invokes the progress callback, checked beforehand with checkProgress
+func reportProgress[P any](wc *waitConfig, progress P) {
+	if fn, ok := wc.progress.(func(P)); ok {
+		fn(progress)
+	}
+}
+
+// sleepContext waits for d or until ctx is done, whichever happens first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// CrawlProgress describes the progress of a crawl job while it is being waited for. The API does not report the
+// total number of pages crawled, so the progress only covers the first page of results.
+type CrawlProgress struct {
+	Status CrawlStatus
+	// FirstPagePages is the number of crawled pages in the first page of results
+	FirstPagePages int
+	// MorePages reports whether the results span more than the first page
+	MorePages bool
+}
+
+// WaitForTranscript polls an asynchronous transcript job until it is no longer queued or active and returns its
+// result. The returned result may have a failed status. The job is then removed from the job store, if any.
+func (s *Supadata) WaitForTranscript(ctx context.Context, jobId string, opts ...WaitOption) (*TranscriptResult, error) {
+	wc := newWaitConfig(opts)
+	if err := checkNoProgress(wc); err != nil {
+		return nil, err
+	}
+	ctx, stop := s.bindClose(ensureLineage(ctx))
+	defer stop()
+	return pollUntil(ctx, wc, func() (*TranscriptResult, bool, error) {
+		result, err := s.TranscriptResult(jobId, WithContext(ctx))
+		if err != nil {
+			return nil, false, err
+		}
+		if result.Status == Queued || result.Status == Active {
+			return nil, false, nil
+		}
+		return result, true, s.forgetJob(jobId)
+	})
+}
+
+// WaitForCrawl polls a crawl job until it is no longer scraping and returns its first page of results.
+// The returned result may have a failed or cancelled status. The job is then removed from the job store, if any.
+func (s *Supadata) WaitForCrawl(ctx context.Context, jobId string, opts ...WaitOption) (*CrawlResult, error) {
+	wc := newWaitConfig(opts)
+	if err := checkProgress[CrawlProgress](wc); err != nil {
+		return nil, err
+	}
+	ctx, stop := s.bindClose(ensureLineage(ctx))
+	defer stop()
+	return pollUntil(ctx, wc, func() (*CrawlResult, bool, error) {
+		result, err := s.CrawlResult(jobId, 0, WithContext(ctx))
+		if err != nil {
+			return nil, false, err
+		}
+		reportProgress(wc, CrawlProgress{Status: result.Status, FirstPagePages: len(result.Pages), MorePages: result.Next != ""})
+		if result.Status == Scraping {
+			return nil, false, nil
+		}
+		return result, true, s.forgetJob(jobId)
+	})
+}
+
+// WaitForYouTubeBatch polls a YouTube batch job until it is no longer queued or active and returns its result.
+// The returned result may have a failed status. The job is then removed from the job store, if any.
+// The stats of the job are reported to a WithProgress callback on every poll returning them.
+func (s *Supadata) WaitForYouTubeBatch(ctx context.Context, jobId string, opts ...WaitOption) (*YouTubeBatchResult, error) {
+	return s.waitForBatchJob(ensureLineage(ctx), &YouTubeBatchJob{JobId: jobId}, newWaitConfig(opts))
+}
+
+// waitForBatchJob polls every job of a possibly split batch job until none of them is queued or active
+func (s *Supadata) waitForBatchJob(ctx context.Context, job *YouTubeBatchJob, wc *waitConfig) (*YouTubeBatchResult, error) {
+	if err := checkProgress[YouTubeBatchStats](wc); err != nil {
+		return nil, err
+	}
+	ctx, stop := s.bindClose(ctx)
+	defer stop()
+	return pollUntil(ctx, wc, func() (*YouTubeBatchResult, bool, error) {
+		result, err := s.YouTubeBatchJobResult(job, WithContext(ctx))
+		if err != nil {
+			return nil, false, err
+		}
+		if result.Stats != (YouTubeBatchStats{}) {
+			reportProgress(wc, result.Stats)
+		}
+		if result.Status == BatchQueued || result.Status == BatchActive {
+			return nil, false, nil
+		}
+		return result, true, s.forgetJob(job.JobId)
+	})
+}
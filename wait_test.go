@@ -0,0 +1,287 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWaitForCrawl_Progress(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 3 {
+			pages := make([]map[string]any, polls)
+			for i := range pages {
+				pages[i] = map[string]any{"url": "https://example.com"}
+			}
+			jsonResponse(w, http.StatusOK, map[string]any{"status": "scraping", "pages": pages, "next": "cursor"})
+			return
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"status": "completed",
+			"pages":  []map[string]any{{"url": "a"}, {"url": "b"}, {"url": "c"}},
+		})
+	}))
+	defer server.Close()
+
+	var progress []CrawlProgress
+	client := newTestClient(server)
+	result, err := client.WaitForCrawl(context.Background(), "job-1",
+		WithPollInterval(time.Millisecond),
+		WithProgress(func(p CrawlProgress) { progress = append(progress, p) }),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Status != CrawlCompleted {
+		t.Errorf("expected status %q, got %q", CrawlCompleted, result.Status)
+	}
+	expected := []CrawlProgress{{Scraping, 1, true}, {Scraping, 2, true}, {CrawlCompleted, 3, false}}
+	if len(progress) != len(expected) {
+		t.Fatalf("expected %d progress reports, got %v", len(expected), progress)
+	}
+	for i := range expected {
+		if progress[i] != expected[i] {
+			t.Errorf("expected progress %d to be %+v, got %+v", i, expected[i], progress[i])
+		}
+	}
+}
+
+func TestWaitForYouTubeBatch_Progress(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		switch polls {
+		case 1:
+			// Queued jobs do not report stats yet
+			jsonResponse(w, http.StatusOK, map[string]any{"status": "queued"})
+		case 2:
+			jsonResponse(w, http.StatusOK, map[string]any{"status": "active", "stats": map[string]any{"total": 3, "succeeded": 1}})
+		default:
+			jsonResponse(w, http.StatusOK, map[string]any{"status": "completed", "stats": map[string]any{"total": 3, "succeeded": 2, "failed": 1}})
+		}
+	}))
+	defer server.Close()
+
+	var progress []YouTubeBatchStats
+	client := newTestClient(server)
+	result, err := client.WaitForYouTubeBatch(context.Background(), "job-1",
+		WithPollInterval(time.Millisecond),
+		WithProgress(func(stats YouTubeBatchStats) { progress = append(progress, stats) }),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Status != BatchCompleted {
+		t.Errorf("expected status %q, got %q", BatchCompleted, result.Status)
+	}
+	expected := []YouTubeBatchStats{{Total: 3, Succeeded: 1}, {Total: 3, Succeeded: 2, Failed: 1}}
+	if len(progress) != len(expected) || progress[0] != expected[0] || progress[1] != expected[1] {
+		t.Errorf("expected progress %+v, got %+v", expected, progress)
+	}
+}
+
+func TestWaitForCrawl_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{"status": "scraping"})
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	client := newTestClient(server)
+	_, err := client.WaitForCrawl(ctx, "job-1", WithPollInterval(5*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestWithProgress_RejectsMismatchedCallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	crawlProgress := WithProgress(func(CrawlProgress) {})
+	if _, err := client.WaitForYouTubeBatch(context.Background(), "job-1", crawlProgress); !errors.Is(err, ErrProgressType) {
+		t.Errorf("expected ErrProgressType from WaitForYouTubeBatch, got %v", err)
+	}
+	if _, err := client.WaitForCrawl(context.Background(), "job-1", WithProgress(func(YouTubeBatchStats) {})); !errors.Is(err, ErrProgressType) {
+		t.Errorf("expected ErrProgressType from WaitForCrawl, got %v", err)
+	}
+	if _, err := client.WaitForTranscript(context.Background(), "job-1", crawlProgress); !errors.Is(err, ErrProgressType) {
+		t.Errorf("expected ErrProgressType from WaitForTranscript, got %v", err)
+	}
+	event := <-client.WatchJob(context.Background(), JobRecord{Id: "job-1", Kind: JobCrawl}, crawlProgress)
+	if !errors.Is(event.Err, ErrProgressType) {
+		t.Errorf("expected ErrProgressType from WatchJob, got %v", event.Err)
+	}
+}
+
+func TestWaitForYouTubeBatch(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		status := "active"
+		if polls == 3 {
+			status = "completed"
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"status": status})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	result, err := client.WaitForYouTubeBatch(context.Background(), "job-1", WithPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != BatchCompleted || polls != 3 {
+		t.Errorf("expected completed after 3 polls, got %s after %d", result.Status, polls)
+	}
+}
+
+func TestWaitForTranscript(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/transcript/job-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		polls++
+		if polls < 3 {
+			jsonResponse(w, http.StatusOK, map[string]any{"status": "active"})
+			return
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"status": "completed", "lang": "en"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	result, err := client.WaitForTranscript(context.Background(), "job-1", WithPollOptions(PollOptions{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     2 * time.Millisecond,
+		Multiplier:      2,
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != Completed || polls != 3 {
+		t.Errorf("expected completed after 3 polls, got %s after %d", result.Status, polls)
+	}
+}
+
+func TestWaitForYouTubeBatch_MaxWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{"status": "queued"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.WaitForYouTubeBatch(context.Background(), "job-1", WithPollOptions(PollOptions{
+		InitialInterval: 5 * time.Millisecond,
+		MaxWait:         20 * time.Millisecond,
+	}))
+	if !errors.Is(err, ErrMaxWaitExceeded) {
+		t.Fatalf("expected ErrMaxWaitExceeded, got %v", err)
+	}
+}
+
+func TestWaitForYouTubeBatch_ChecksAtMaxWait(t *testing.T) {
+	start := time.Now()
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		status := "queued"
+		if time.Since(start) >= 40*time.Millisecond {
+			status = "completed"
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"status": status})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	result, err := client.WaitForYouTubeBatch(context.Background(), "job-1", WithPollOptions(PollOptions{
+		InitialInterval: time.Hour,
+		MaxWait:         50 * time.Millisecond,
+	}))
+	if err != nil {
+		t.Fatalf("expected the job to be checked at the maximum wait, got %v", err)
+	}
+	if result.Status != BatchCompleted || polls != 2 {
+		t.Errorf("expected completed after 2 polls, got %s after %d", result.Status, polls)
+	}
+}
+
+func TestWaitForYouTubeBatch_PollsThroughTransientErrors(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		switch polls {
+		case 1:
+			errorResponse(w, http.StatusServiceUnavailable, InternalError, "Unavailable", "")
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			jsonResponse(w, http.StatusOK, map[string]any{"status": "completed"})
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	result, err := client.WaitForYouTubeBatch(context.Background(), "job-1", WithPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != BatchCompleted || polls != 3 {
+		t.Errorf("expected completed after 3 polls, got %s after %d", result.Status, polls)
+	}
+}
+
+func TestWaitForYouTubeBatch_AbortsOnPermanentError(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		errorResponse(w, http.StatusNotFound, NotFound, "Not found", "")
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.WaitForYouTubeBatch(context.Background(), "job-1", WithPollInterval(time.Millisecond))
+	if !IsNotFound(err) || polls != 1 {
+		t.Errorf("expected the wait to stop on the first not found error, got %v after %d polls", err, polls)
+	}
+}
+
+func TestWithPollOptions_Defaults(t *testing.T) {
+	wc := newWaitConfig([]WaitOption{WithPollOptions(PollOptions{MaxWait: time.Minute})})
+	expected := DefaultPollOptions()
+	expected.Jitter = 0
+	expected.MaxWait = time.Minute
+	if wc.poll != expected {
+		t.Errorf("expected %+v, got %+v", expected, wc.poll)
+	}
+
+	wc = newWaitConfig([]WaitOption{WithPollOptions(PollOptions{MaxWait: time.Minute}), WithPollInterval(time.Second)})
+	if wc.poll.InitialInterval != time.Second || wc.poll.MaxInterval != time.Second || wc.poll.MaxWait != time.Minute {
+		t.Errorf("expected a fixed interval keeping the maximum wait, got %+v", wc.poll)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	for range 100 {
+		d := jitter(time.Second, 0.1)
+		if d < 900*time.Millisecond || d > 1100*time.Millisecond {
+			t.Fatalf("expected a delay within 10%% of 1s, got %s", d)
+		}
+	}
+	if d := jitter(time.Second, 0); d != time.Second {
+		t.Errorf("expected no jitter, got %s", d)
+	}
+}
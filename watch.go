@@ -0,0 +1,122 @@
+package supadata
+
+import (
+	"context"
+	"fmt"
+)
+
+// JobEvent reports a status transition of a watched job. The last event of a job is either Done or carries the Err
+// that stopped the polling.
+type JobEvent struct {
+	JobId string
+	Kind  JobKind
+	// Status is the status reported by the API, e.g. "queued", "active", "scraping" or "completed"
+	Status string
+	// Done is set on the event reporting the final status, along with the result matching Kind
+	Done       bool
+	Transcript *TranscriptResult
+	Crawl      *CrawlResult
+	Batch      *YouTubeBatchResult
+	// Err is also set on the Done event when the finished job could not be removed from the job store
+	Err error
+}
+
+// WatchJob polls a transcript, crawl or batch job in the background and emits an event every time its status
+// changes, e.g. queued, active then completed. job identifies the job by its Id and Kind, so that records loaded
+// from a JobStore can be watched directly. The channel is closed after the final event, after an error event or
+// when ctx is done or the client closed. Once the job has finished it is removed from the job store, if any.
+func (s *Supadata) WatchJob(ctx context.Context, job JobRecord, opts ...WaitOption) <-chan JobEvent {
+	wc := newWaitConfig(opts)
+	if err := checkNoProgress(wc); err != nil {
+		return failedWatch(job, err)
+	}
+	if !s.shutdown.start() {
+		return failedWatch(job, ErrClientClosed)
+	}
+	ctx, stop := s.bindClose(ensureLineage(ctx))
+	events := make(chan JobEvent)
+	emit := func(event JobEvent) bool {
+		event.JobId, event.Kind = job.Id, job.Kind
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	go func() {
+		defer s.shutdown.wg.Done()
+		defer stop()
+		defer close(events)
+		last := ""
+		_, err := pollUntil(ctx, wc, func() (struct{}, bool, error) {
+			event, err := s.jobStatus(ctx, job)
+			if err != nil {
+				return struct{}{}, false, err
+			}
+			if event.Done {
+				event.Err = s.forgetJob(job.Id)
+			}
+			if event.Status != last || event.Done {
+				last = event.Status
+				if !emit(event) {
+					return struct{}{}, false, ctx.Err()
+				}
+			}
+			return struct{}{}, event.Done, nil
+		})
+		if err != nil && ctx.Err() == nil {
+			emit(JobEvent{Err: err})
+		}
+	}()
+	return events
+}
+
+// failedWatch returns a closed channel holding a single event reporting err for job
+func failedWatch(job JobRecord, err error) <-chan JobEvent {
+	events := make(chan JobEvent, 1)
+	events <- JobEvent{JobId: job.Id, Kind: job.Kind, Err: err}
+	close(events)
+	return events
+}
+
+// jobStatus fetches the current status of a job, along with its result when it has finished
+func (s *Supadata) jobStatus(ctx context.Context, job JobRecord) (JobEvent, error) {
+	switch job.Kind {
+	case JobTranscript:
+		result, err := s.TranscriptResult(job.Id, WithContext(ctx))
+		if err != nil {
+			return JobEvent{}, err
+		}
+		done := result.Status != Queued && result.Status != Active
+		event := JobEvent{Status: string(result.Status), Done: done}
+		if done {
+			event.Transcript = result
+		}
+		return event, nil
+	case JobCrawl:
+		result, err := s.CrawlResult(job.Id, 0, WithContext(ctx))
+		if err != nil {
+			return JobEvent{}, err
+		}
+		done := result.Status != Scraping
+		event := JobEvent{Status: string(result.Status), Done: done}
+		if done {
+			event.Crawl = result
+		}
+		return event, nil
+	case JobBatch:
+		result, err := s.YouTubeBatchJobResult(job.BatchJob(), WithContext(ctx))
+		if err != nil {
+			return JobEvent{}, err
+		}
+		done := result.Status != BatchQueued && result.Status != BatchActive
+		event := JobEvent{Status: string(result.Status), Done: done}
+		if done {
+			event.Batch = result
+		}
+		return event, nil
+	}
+	return JobEvent{}, fmt.Errorf("cannot watch job %s of kind %q", job.Id, job.Kind)
+}
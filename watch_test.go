@@ -0,0 +1,70 @@
+package supadata
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWatchJob(t *testing.T) {
+	statuses := []string{"queued", "queued", "active", "completed"}
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/transcript/job-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		status := statuses[min(polls, len(statuses)-1)]
+		polls++
+		jsonResponse(w, http.StatusOK, map[string]any{"status": status})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	var seen []string
+	var last JobEvent
+	for event := range client.WatchJob(context.Background(), JobRecord{Id: "job-1", Kind: JobTranscript}, WithPollInterval(time.Millisecond)) {
+		if event.Err != nil {
+			t.Fatalf("unexpected error: %v", event.Err)
+		}
+		seen = append(seen, event.Status)
+		last = event
+	}
+
+	if len(seen) != 3 || seen[0] != "queued" || seen[1] != "active" || seen[2] != "completed" {
+		t.Errorf("expected queued, active, completed, got %v", seen)
+	}
+	if !last.Done || last.Transcript == nil || last.JobId != "job-1" || last.Kind != JobTranscript {
+		t.Errorf("unexpected final event %+v", last)
+	}
+}
+
+func TestWatchJob_UnknownKind(t *testing.T) {
+	client := NewSupadata(WithAPIKey("test-api-key"))
+	var events []JobEvent
+	for event := range client.WatchJob(context.Background(), JobRecord{Id: "job-1", Kind: "unknown"}) {
+		events = append(events, event)
+	}
+	if len(events) != 1 || events[0].Err == nil {
+		t.Errorf("expected a single error event, got %+v", events)
+	}
+}
+
+func TestWatchJob_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, map[string]any{"status": "scraping"})
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := newTestClient(server)
+	events := client.WatchJob(ctx, JobRecord{Id: "job-1", Kind: JobCrawl}, WithPollInterval(time.Millisecond))
+	if event := <-events; event.Status != "scraping" {
+		t.Fatalf("expected scraping, got %+v", event)
+	}
+	cancel()
+	for event := range events {
+		t.Errorf("expected no event after cancellation, got %+v", event)
+	}
+}
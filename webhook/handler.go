@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// MaxBodySize is the maximum size of a webhook body accepted by the handler
+const MaxBodySize = 32 << 20
+
+// Callbacks are invoked by the handler for each kind of event. Events without a callback are acknowledged
+// and dropped. A callback error makes the handler answer with a server error so the delivery can be retried.
+type Callbacks struct {
+	OnTranscript func(ctx context.Context, event *TranscriptEvent) error
+	OnCrawl      func(ctx context.Context, event *CrawlEvent) error
+	OnBatch      func(ctx context.Context, event *BatchEvent) error
+}
+
+type handler struct {
+	secret    string
+	callbacks Callbacks
+}
+
+// NewHandler returns an http.Handler that verifies, parses and dispatches Supadata webhook events to callbacks.
+// An empty secret is rejected with ErrEmptySecret.
+func NewHandler(secret string, callbacks Callbacks) (http.Handler, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+	return &handler{secret: secret, callbacks: callbacks}, nil
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if err := VerifySignature(h.secret, r.Header.Get(SignatureHeader), body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	event, err := Parse(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	handled, err := h.dispatch(r.Context(), event)
+	switch {
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	case !handled:
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// dispatch invokes the callback registered for the event type and reports whether there was one
+func (h *handler) dispatch(ctx context.Context, event *Event) (bool, error) {
+	switch event.Type {
+	case TranscriptEventType:
+		if h.callbacks.OnTranscript == nil {
+			return false, nil
+		}
+		e, err := event.Transcript()
+		if err != nil {
+			return true, err
+		}
+		return true, h.callbacks.OnTranscript(ctx, e)
+	case CrawlEventType:
+		if h.callbacks.OnCrawl == nil {
+			return false, nil
+		}
+		e, err := event.Crawl()
+		if err != nil {
+			return true, err
+		}
+		return true, h.callbacks.OnCrawl(ctx, e)
+	case BatchEventType:
+		if h.callbacks.OnBatch == nil {
+			return false, nil
+		}
+		e, err := event.Batch()
+		if err != nil {
+			return true, err
+		}
+		return true, h.callbacks.OnBatch(ctx, e)
+	}
+	return false, nil
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newSignedRequest(t *testing.T, secret, body string) *http.Request {
+	t.Helper()
+	signature, err := Sign(secret, []byte(body))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/supadata", strings.NewReader(body))
+	req.Header.Set(SignatureHeader, signature)
+	return req
+}
+
+func TestHandler_DispatchesEvents(t *testing.T) {
+	var crawlJob string
+	handler, err := NewHandler("secret", Callbacks{
+		OnCrawl: func(ctx context.Context, event *CrawlEvent) error {
+			crawlJob = event.JobId
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newSignedRequest(t, "secret", `{"type":"crawl","jobId":"job-1","data":{"status":"completed"}}`))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+	if crawlJob != "job-1" {
+		t.Errorf("expected crawl callback for job-1, got %q", crawlJob)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newSignedRequest(t, "secret", `{"type":"batch","jobId":"job-2","data":{}}`))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("expected status 204 for unhandled event, got %d", rec.Code)
+	}
+}
+
+func TestHandler_Rejections(t *testing.T) {
+	handler, err := NewHandler("secret", Callbacks{
+		OnTranscript: func(ctx context.Context, event *TranscriptEvent) error {
+			return errors.New("storage unavailable")
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		req      *http.Request
+		expected int
+	}{
+		{"wrong method", httptest.NewRequest(http.MethodGet, "/", nil), http.StatusMethodNotAllowed},
+		{"missing signature", httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`)), http.StatusUnauthorized},
+		{"wrong secret", newSignedRequest(t, "other", `{"type":"crawl"}`), http.StatusUnauthorized},
+		{"invalid payload", newSignedRequest(t, "secret", `not json`), http.StatusBadRequest},
+		{"callback error", newSignedRequest(t, "secret", `{"type":"transcript","jobId":"job-1","data":{}}`), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, tt.req)
+			if rec.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
+
+func TestNewHandler_EmptySecret(t *testing.T) {
+	if _, err := NewHandler("", Callbacks{}); !errors.Is(err, ErrEmptySecret) {
+		t.Errorf("expected ErrEmptySecret, got %v", err)
+	}
+}
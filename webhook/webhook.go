@@ -0,0 +1,143 @@
+// Package webhook parses and verifies the webhook events Supadata sends when transcript, crawl and batch jobs
+// finish.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/petros0/supadata-go"
+)
+
+// SignatureHeader is the HTTP header carrying the signature of a webhook body
+const SignatureHeader = "X-Supadata-Signature"
+
+var (
+	ErrMissingSignature = errors.New("webhook: missing signature")
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	ErrEmptySecret      = errors.New("webhook: empty secret")
+)
+
+// EventType identifies the kind of job a webhook event reports on
+type EventType string
+
+const (
+	TranscriptEventType EventType = "transcript"
+	CrawlEventType      EventType = "crawl"
+	BatchEventType      EventType = "batch"
+)
+
+// Event is the envelope common to every webhook payload
+type Event struct {
+	Type  EventType       `json:"type"`
+	JobId string          `json:"jobId"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// TranscriptEvent reports the result of an async transcript job
+type TranscriptEvent struct {
+	JobId  string
+	Result *supadata.TranscriptResult
+}
+
+// CrawlEvent reports the result of a crawl job
+type CrawlEvent struct {
+	JobId  string
+	Result *supadata.CrawlResult
+}
+
+// BatchEvent reports the result of a YouTube batch job
+type BatchEvent struct {
+	JobId  string
+	Result *supadata.YouTubeBatchResult
+}
+
+// Parse decodes the envelope of a webhook payload
+func Parse(body []byte) (*Event, error) {
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("webhook: decode event: %w", err)
+	}
+	if event.Type == "" {
+		return nil, errors.New("webhook: event has no type")
+	}
+	return &event, nil
+}
+
+// Transcript decodes the payload of a transcript event
+func (e *Event) Transcript() (*TranscriptEvent, error) {
+	result, err := decodeData[supadata.TranscriptResult](e, TranscriptEventType)
+	if err != nil {
+		return nil, err
+	}
+	return &TranscriptEvent{JobId: e.JobId, Result: result}, nil
+}
+
+// Crawl decodes the payload of a crawl event
+func (e *Event) Crawl() (*CrawlEvent, error) {
+	result, err := decodeData[supadata.CrawlResult](e, CrawlEventType)
+	if err != nil {
+		return nil, err
+	}
+	return &CrawlEvent{JobId: e.JobId, Result: result}, nil
+}
+
+// Batch decodes the payload of a batch event
+func (e *Event) Batch() (*BatchEvent, error) {
+	result, err := decodeData[supadata.YouTubeBatchResult](e, BatchEventType)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchEvent{JobId: e.JobId, Result: result}, nil
+}
+
+func decodeData[T any](e *Event, expected EventType) (*T, error) {
+	if e.Type != expected {
+		return nil, fmt.Errorf("webhook: expected %s event, got %s", expected, e.Type)
+	}
+	var result T
+	if err := json.Unmarshal(e.Data, &result); err != nil {
+		return nil, fmt.Errorf("webhook: decode %s event: %w", e.Type, err)
+	}
+	return &result, nil
+}
+
+// Sign returns the signature of body for secret, in the format sent in SignatureHeader. An empty secret is rejected
+// with ErrEmptySecret, since anyone could sign with it.
+func Sign(secret string, body []byte) (string, error) {
+	if secret == "" {
+		return "", ErrEmptySecret
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// VerifySignature checks that header holds the HMAC-SHA256 signature of body for secret.
+// The signature may be given as "sha256=<hex>" or as the bare hex digest. An empty secret is rejected with
+// ErrEmptySecret, e.g. when the variable holding it is unset, rather than accepting payloads anyone could sign.
+func VerifySignature(secret, header string, body []byte) error {
+	if secret == "" {
+		return ErrEmptySecret
+	}
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return ErrMissingSignature
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(header, "sha256="))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
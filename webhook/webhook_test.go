@@ -0,0 +1,108 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/petros0/supadata-go"
+)
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"type":"crawl","jobId":"job-1"}`)
+	signature, err := Sign("secret", body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := VerifySignature("secret", signature, body); err != nil {
+		t.Errorf("expected valid signature, got %v", err)
+	}
+	if err := VerifySignature("secret", strings.TrimPrefix(signature, "sha256="), body); err != nil {
+		t.Errorf("expected bare hex signature to be valid, got %v", err)
+	}
+	if err := VerifySignature("other", signature, body); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for wrong secret, got %v", err)
+	}
+	if err := VerifySignature("secret", signature, []byte(`{}`)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for tampered body, got %v", err)
+	}
+	if err := VerifySignature("secret", "sha256=not-hex", body); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for malformed signature, got %v", err)
+	}
+	if err := VerifySignature("secret", "", body); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("expected ErrMissingSignature, got %v", err)
+	}
+}
+
+func TestVerifySignature_EmptySecret(t *testing.T) {
+	body := []byte(`{"type":"crawl","jobId":"job-1"}`)
+	if _, err := Sign("", body); !errors.Is(err, ErrEmptySecret) {
+		t.Errorf("expected ErrEmptySecret from Sign, got %v", err)
+	}
+
+	mac := hmac.New(sha256.New, nil)
+	mac.Write(body)
+	forged := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if err := VerifySignature("", forged, body); !errors.Is(err, ErrEmptySecret) {
+		t.Errorf("expected ErrEmptySecret, got %v", err)
+	}
+}
+
+func TestParse_Transcript(t *testing.T) {
+	event, err := Parse([]byte(`{
+		"type": "transcript",
+		"jobId": "job-1",
+		"data": {"status": "completed", "content": [{"text": "Hello"}], "lang": "en"}
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	transcript, err := event.Transcript()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transcript.JobId != "job-1" || transcript.Result.Status != supadata.Completed {
+		t.Errorf("unexpected event %+v", transcript)
+	}
+	if transcript.Result.Content[0].Text != "Hello" {
+		t.Errorf("unexpected content %+v", transcript.Result.Content)
+	}
+
+	if _, err := event.Crawl(); err == nil {
+		t.Error("expected error decoding transcript event as crawl")
+	}
+}
+
+func TestParse_CrawlAndBatch(t *testing.T) {
+	event, err := Parse([]byte(`{"type":"crawl","jobId":"job-2","data":{"status":"completed","pages":[{"url":"https://example.com"}]}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	crawl, err := event.Crawl()
+	if err != nil || crawl.Result.Pages[0].Url != "https://example.com" {
+		t.Errorf("unexpected crawl event %+v (err=%v)", crawl, err)
+	}
+
+	event, err = Parse([]byte(`{"type":"batch","jobId":"job-3","data":{"status":"completed","stats":{"total":2,"succeeded":1,"failed":1}}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	batch, err := event.Batch()
+	if err != nil || batch.Result.Stats.Total != 2 {
+		t.Errorf("unexpected batch event %+v (err=%v)", batch, err)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	if _, err := Parse([]byte(`not json`)); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+	if _, err := Parse([]byte(`{"jobId":"job-1"}`)); err == nil {
+		t.Error("expected error for missing type")
+	}
+}
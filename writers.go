@@ -0,0 +1,238 @@
+package supadata
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// csvRecord is implemented by the types that can be written with WriteCSV
+type csvRecord interface {
+	csvHeader() []string
+	csvRecord() []string
+}
+
+// WriteJSONL writes items to w as JSON Lines, one item per line
+func WriteJSONL[T any](w io.Writer, items []T) error {
+	enc := json.NewEncoder(w)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCSV writes items to w as CSV with a header row.
+// Supported item types are CrawlPage, YouTubeSearchResultItem and YouTubeBatchResultItem.
+func WriteCSV[T csvRecord](w io.Writer, items []T) error {
+	cw := csv.NewWriter(w)
+	var zero T
+	if err := cw.Write(zero.csvHeader()); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := cw.Write(item.csvRecord()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (p CrawlPage) csvHeader() []string {
+	return []string{"url", "name", "description", "ogUrl", "countCharacters", "content"}
+}
+
+func (p CrawlPage) csvRecord() []string {
+	return []string{p.Url, p.Name, p.Description, p.OgUrl, strconv.Itoa(p.CountCharacters), p.Content}
+}
+
+func (i YouTubeSearchResultItem) csvHeader() []string {
+	return searchHeader(searchColumns)
+}
+
+func (i YouTubeSearchResultItem) csvRecord() []string {
+	return searchRecord(&i, searchColumns)
+}
+
+// SearchColumn is a field of the YouTube search results written by WriteSearchCSV and WriteSearchJSON
+type SearchColumn string
+
+const (
+	SearchColumnType            SearchColumn = "type"
+	SearchColumnId              SearchColumn = "id"
+	SearchColumnTitle           SearchColumn = "title"
+	SearchColumnDescription     SearchColumn = "description"
+	SearchColumnThumbnail       SearchColumn = "thumbnail"
+	SearchColumnDuration        SearchColumn = "duration"
+	SearchColumnViews           SearchColumn = "viewCount"
+	SearchColumnUploadDate      SearchColumn = "uploadDate"
+	SearchColumnChannelId       SearchColumn = "channelId"
+	SearchColumnChannel         SearchColumn = "channelName"
+	SearchColumnSubscriberCount SearchColumn = "subscriberCount"
+	SearchColumnVideoCount      SearchColumn = "videoCount"
+)
+
+// searchColumns are the columns written by default, in order
+var searchColumns = []SearchColumn{
+	SearchColumnType, SearchColumnId, SearchColumnTitle, SearchColumnDescription, SearchColumnThumbnail,
+	SearchColumnDuration, SearchColumnViews, SearchColumnUploadDate, SearchColumnChannelId, SearchColumnChannel,
+	SearchColumnSubscriberCount, SearchColumnVideoCount,
+}
+
+// value returns the value of the column for item: a string, an int, or an *int left nil when the API omitted it
+func (c SearchColumn) value(item *YouTubeSearchResultItem) any {
+	switch c {
+	case SearchColumnType:
+		return item.Type
+	case SearchColumnId:
+		return item.Id
+	case SearchColumnTitle:
+		return item.Title
+	case SearchColumnDescription:
+		return item.Description
+	case SearchColumnThumbnail:
+		return item.Thumbnails.Best().Url
+	case SearchColumnDuration:
+		return item.Duration
+	case SearchColumnViews:
+		return item.ViewCount
+	case SearchColumnUploadDate:
+		return item.UploadDate
+	case SearchColumnChannelId:
+		return item.ChannelId
+	case SearchColumnChannel:
+		return item.ChannelName
+	case SearchColumnSubscriberCount:
+		return item.SubscriberCount
+	case SearchColumnVideoCount:
+		return item.VideoCount
+	}
+	return nil
+}
+
+// selectSearchColumns returns columns, or every column when there are none, failing on unknown columns
+func selectSearchColumns(columns []SearchColumn) ([]SearchColumn, error) {
+	if len(columns) == 0 {
+		return searchColumns, nil
+	}
+	for _, c := range columns {
+		if !slices.Contains(searchColumns, c) {
+			return nil, invalidParams("unknown search column %q", c)
+		}
+	}
+	return columns, nil
+}
+
+func searchHeader(columns []SearchColumn) []string {
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = string(c)
+	}
+	return header
+}
+
+func searchRecord(item *YouTubeSearchResultItem, columns []SearchColumn) []string {
+	record := make([]string, len(columns))
+	for i, c := range columns {
+		switch v := c.value(item).(type) {
+		case string:
+			record[i] = v
+		case int:
+			record[i] = strconv.Itoa(v)
+		case *int:
+			record[i] = formatOptionalInt(v)
+		}
+	}
+	return record
+}
+
+// WriteSearchCSV writes YouTube search results to w as CSV with a header row, e.g. to open them in a spreadsheet.
+// Only the given columns are written, in their order, every column being written when none is given.
+func WriteSearchCSV(w io.Writer, items []YouTubeSearchResultItem, columns ...SearchColumn) error {
+	columns, err := selectSearchColumns(columns)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(searchHeader(columns)); err != nil {
+		return err
+	}
+	for i := range items {
+		if err := cw.Write(searchRecord(&items[i], columns)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteSearchJSON writes YouTube search results to w as a JSON array of objects keyed by column. Only the given
+// columns are written, in their order, every column being written when none is given. Counts the API omitted are
+// written as null.
+func WriteSearchJSON(w io.Writer, items []YouTubeSearchResultItem, columns ...SearchColumn) error {
+	columns, err := selectSearchColumns(columns)
+	if err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i := range items {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('{')
+		for j, c := range columns {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			value, err := json.Marshal(c.value(&items[i]))
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&b, "%q:%s", c, value)
+		}
+		b.WriteByte('}')
+	}
+	b.WriteString("]\n")
+	_, err = w.Write(b.Bytes())
+	return err
+}
+
+func (i YouTubeBatchResultItem) csvHeader() []string {
+	return []string{"videoId", "errorCode", "title", "lang", "transcript"}
+}
+
+func (i YouTubeBatchResultItem) csvRecord() []string {
+	var title, lang, transcript string
+	if i.Video != nil {
+		title = i.Video.Title
+	}
+	if i.Transcript != nil {
+		lang = i.Transcript.Lang
+		transcript = joinTranscript(i.Transcript.Content)
+	}
+	return []string{i.VideoId, i.ErrorCode, title, lang, transcript}
+}
+
+func formatOptionalInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+// joinTranscript concatenates the text of transcript segments separated by spaces
+func joinTranscript(content []TranscriptContent) string {
+	texts := make([]string, len(content))
+	for i, c := range content {
+		texts[i] = c.Text
+	}
+	return strings.Join(texts, " ")
+}
@@ -0,0 +1,143 @@
+package supadata
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestWriteJSONL(t *testing.T) {
+	pages := []CrawlPage{
+		{Url: "https://example.com/a", Name: "A"},
+		{Url: "https://example.com/b", Name: "B"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSONL(&buf, pages); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{"url":"https://example.com/a","content":"","name":"A","description":"","ogUrl":"","countCharacters":0}
+{"url":"https://example.com/b","content":"","name":"B","description":"","ogUrl":"","countCharacters":0}
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestWriteCSV_CrawlPages(t *testing.T) {
+	pages := []CrawlPage{
+		{Url: "https://example.com/a", Name: "A, the page", CountCharacters: 12, Content: "line1\nline2"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, pages); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "url,name,description,ogUrl,countCharacters,content\n" +
+		"https://example.com/a,\"A, the page\",,,12,\"line1\nline2\"\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestWriteCSV_SearchResults(t *testing.T) {
+	views := 1500
+	items := []YouTubeSearchResultItem{
+		{Type: "video", Id: "abc", Title: "Video", Duration: 60, ViewCount: &views, ChannelName: "Channel"},
+		{Type: "channel", Id: "UC1", Title: "Channel"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, items); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "type,id,title,description,thumbnail,duration,viewCount,uploadDate,channelId,channelName,subscriberCount,videoCount\n" +
+		"video,abc,Video,,,60,1500,,,Channel,,\n" +
+		"channel,UC1,Channel,,,0,,,,,,\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestWriteCSV_BatchResults(t *testing.T) {
+	items := []YouTubeBatchResultItem{
+		{VideoId: "abc", Transcript: &YouTubeTranscriptResult{
+			Lang:    "en",
+			Content: []TranscriptContent{{Text: "Hello"}, {Text: "world"}},
+		}},
+		{VideoId: "def", ErrorCode: "transcript-unavailable"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, items); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "videoId,errorCode,title,lang,transcript\n" +
+		"abc,,,en,Hello world\n" +
+		"def,transcript-unavailable,,,\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestWriteSearchCSV_Columns(t *testing.T) {
+	views := 1500
+	items := []YouTubeSearchResultItem{
+		{Type: "video", Id: "abc", Title: "Video, part 1", ViewCount: &views, ChannelName: "Channel", UploadDate: "2024-01-02"},
+		{Type: "channel", Id: "UC1", Title: "Channel"},
+	}
+
+	var buf bytes.Buffer
+	err := WriteSearchCSV(&buf, items, SearchColumnId, SearchColumnTitle, SearchColumnViews, SearchColumnChannel, SearchColumnUploadDate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "id,title,viewCount,channelName,uploadDate\n" +
+		"abc,\"Video, part 1\",1500,Channel,2024-01-02\n" +
+		"UC1,Channel,,,\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+
+	// Without columns, the output matches WriteCSV
+	var all, generic bytes.Buffer
+	if err := WriteSearchCSV(&all, items); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := WriteCSV(&generic, items); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if all.String() != generic.String() {
+		t.Errorf("expected every column by default, got:\n%s", all.String())
+	}
+
+	if err := WriteSearchCSV(&buf, items, "likes"); !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("expected ErrInvalidParams for an unknown column, got %v", err)
+	}
+}
+
+func TestWriteSearchJSON_Columns(t *testing.T) {
+	views := 1500
+	items := []YouTubeSearchResultItem{
+		{Type: "video", Id: "abc", Title: "Video", Duration: 60, ViewCount: &views},
+		{Type: "channel", Id: "UC1", Title: "Channel"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSearchJSON(&buf, items, SearchColumnTitle, SearchColumnId, SearchColumnViews, SearchColumnDuration); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `[{"title":"Video","id":"abc","viewCount":1500,"duration":60},{"title":"Channel","id":"UC1","viewCount":null,"duration":0}]` + "\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteSearchJSON(&buf, nil); err != nil || buf.String() != "[]\n" {
+		t.Errorf("expected an empty array, got %q (%v)", buf.String(), err)
+	}
+}
@@ -0,0 +1,96 @@
+package supadata
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strings"
+)
+
+// FeaturesEncoding is how YouTubeSearch encodes the features filter in the query
+type FeaturesEncoding int
+
+const (
+	// FeaturesCommaSeparated sends the features in a single comma-separated parameter, features=hd,4k, as the API
+	// expects
+	FeaturesCommaSeparated FeaturesEncoding = iota
+	// FeaturesRepeated sends a parameter per feature, features=hd&features=4k, as earlier versions of the client did
+	FeaturesRepeated
+)
+
+// WithFeaturesEncoding sets how YouTubeSearch encodes the features filter, FeaturesCommaSeparated by default
+func WithFeaturesEncoding(encoding FeaturesEncoding) ConfigOption {
+	return func(config *Config) {
+		config.featuresEncoding = encoding
+	}
+}
+
+// setFeatures sets the features filter in q with the configured encoding, sending every feature once
+func (s *Supadata) setFeatures(q url.Values, features []YouTubeSearchFeature) error {
+	var values []string
+	seen := make(map[YouTubeSearchFeature]bool, len(features))
+	for _, f := range features {
+		if f == "" || strings.Contains(string(f), ",") {
+			return invalidParams("invalid search feature %q", f)
+		}
+		if !seen[f] {
+			seen[f] = true
+			values = append(values, string(f))
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	if s.config.featuresEncoding == FeaturesRepeated {
+		q["features"] = values
+	} else {
+		q.Set("features", strings.Join(values, ","))
+	}
+	return nil
+}
+
+// YouTubeSearchAll iterates over the results of a YouTube search, following NextPageToken until maxResults items
+// were yielded or there are no more pages. A maxResults of zero or less yields every result.
+// Results repeated on later pages, as the API returns when the ranking shifts between requests, are yielded once.
+// Iteration stops after the first error.
+func (s *Supadata) YouTubeSearchAll(ctx context.Context, params *YouTubeSearchParams, maxResults int) iter.Seq2[YouTubeSearchResultItem, error] {
+	return func(yield func(YouTubeSearchResultItem, error) bool) {
+		yielded := 0
+		seen := make(map[string]bool)
+		for item, err := range pageItems(ctx, s, func(ctx context.Context) (*Page[YouTubeSearchResultItem], error) {
+			return s.YouTubeSearchPage(ctx, params)
+		}) {
+			if err == nil && item.Id != "" {
+				key := searchResultKey(&item)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+			}
+			if !yield(item, err) || err != nil {
+				return
+			}
+			yielded++
+			if maxResults > 0 && yielded >= maxResults {
+				return
+			}
+		}
+	}
+}
+
+// AllPlaylistVideoIds iterates over the IDs of every video of a YouTube playlist, following NextPageToken until
+// the playlist is exhausted. Iteration stops after the first error.
+func (s *Supadata) AllPlaylistVideoIds(ctx context.Context, id string) iter.Seq2[string, error] {
+	return pageItems(ctx, s, func(ctx context.Context) (*Page[string], error) {
+		return s.YouTubePlaylistVideosPage(ctx, &YouTubePlaylistVideosParams{Id: id})
+	})
+}
+
+// AllChannelVideoIds iterates over the IDs of every video, short and live stream of a YouTube channel,
+// following NextPageToken until the channel is exhausted. Iteration stops after the first error.
+func (s *Supadata) AllChannelVideoIds(ctx context.Context, id string) iter.Seq2[string, error] {
+	return pageItems(ctx, s, func(ctx context.Context) (*Page[string], error) {
+		return s.YouTubeChannelVideosPage(ctx, &YouTubeChannelVideosParams{Id: id})
+	})
+}
@@ -0,0 +1,232 @@
+package supadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// searchServer serves total search results in pages of pageSize, using the result offset as page token
+func searchServer(t *testing.T, total, pageSize int) (*httptest.Server, *int) {
+	t.Helper()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := r.URL.Query().Get("query"); got != "golang" {
+			t.Errorf("expected query %q, got %q", "golang", got)
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("nextPageToken"))
+
+		var results []map[string]any
+		for i := offset; i < total && i < offset+pageSize; i++ {
+			results = append(results, map[string]any{"type": "video", "id": fmt.Sprintf("video-%d", i)})
+		}
+		resp := map[string]any{"query": "golang", "results": results, "totalResults": total}
+		if offset+pageSize < total {
+			resp["nextPageToken"] = strconv.Itoa(offset + pageSize)
+		}
+		jsonResponse(w, http.StatusOK, resp)
+	}))
+	return server, &requests
+}
+
+func TestYouTubeSearchAll_AllPages(t *testing.T) {
+	server, requests := searchServer(t, 7, 3)
+	defer server.Close()
+
+	client := newTestClient(server)
+	var ids []string
+	for item, err := range client.YouTubeSearchAll(context.Background(), &YouTubeSearchParams{Query: "golang"}, 0) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, item.Id)
+	}
+
+	if len(ids) != 7 || ids[6] != "video-6" {
+		t.Errorf("expected 7 results, got %v", ids)
+	}
+	if *requests != 3 {
+		t.Errorf("expected 3 requests, got %d", *requests)
+	}
+}
+
+func TestYouTubeSearchAll_MaxResults(t *testing.T) {
+	server, requests := searchServer(t, 100, 10)
+	defer server.Close()
+
+	client := newTestClient(server)
+	count := 0
+	for _, err := range client.YouTubeSearchAll(context.Background(), &YouTubeSearchParams{Query: "golang"}, 15) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+	}
+
+	if count != 15 {
+		t.Errorf("expected 15 results, got %d", count)
+	}
+	if *requests != 2 {
+		t.Errorf("expected 2 requests, got %d", *requests)
+	}
+}
+
+func TestAllChannelVideoIds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/youtube/channel/videos" {
+			t.Errorf("expected path /youtube/channel/videos, got %s", r.URL.Path)
+		}
+		switch token := r.URL.Query().Get("nextPageToken"); token {
+		case "":
+			jsonResponse(w, http.StatusOK, map[string]any{
+				"videoIds":      []string{"v1", "v2"},
+				"shortIds":      []string{"s1"},
+				"liveIds":       []string{},
+				"nextPageToken": "page-2",
+			})
+		case "page-2":
+			jsonResponse(w, http.StatusOK, map[string]any{
+				"videoIds": []string{"v3"},
+				"shortIds": []string{},
+				"liveIds":  []string{"l1"},
+			})
+		default:
+			t.Errorf("unexpected nextPageToken %q", token)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	var ids []string
+	for id, err := range client.AllChannelVideoIds(context.Background(), "@GoogleDevelopers") {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	expected := []string{"v1", "v2", "s1", "v3", "l1"}
+	if fmt.Sprint(ids) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
+
+func TestAllPlaylistVideoIds(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/youtube/playlist/videos" {
+			t.Errorf("expected path /youtube/playlist/videos, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("nextPageToken") == "" {
+			jsonResponse(w, http.StatusOK, map[string]any{
+				"videoIds":      []string{"v1", "v2"},
+				"nextPageToken": "page-2",
+			})
+			return
+		}
+		jsonResponse(w, http.StatusOK, map[string]any{"videoIds": []string{"v3"}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	var ids []string
+	for id, err := range client.AllPlaylistVideoIds(context.Background(), "PLxyz123") {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, id)
+		if len(ids) == 2 {
+			break
+		}
+	}
+
+	if fmt.Sprint(ids) != "[v1 v2]" {
+		t.Errorf("expected [v1 v2], got %v", ids)
+	}
+	if requests != 1 {
+		t.Errorf("expected iteration to stop after the first page, got %d requests", requests)
+	}
+}
+
+func TestYouTubeSearchAll_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorResponse(w, http.StatusTooManyRequests, LimitExceeded, "Too many requests", "")
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	for _, err := range client.YouTubeSearchAll(context.Background(), &YouTubeSearchParams{Query: "golang"}, 0) {
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	}
+}
+
+func TestYouTubeSearch_FeaturesEncoding(t *testing.T) {
+	var rawQueries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawQueries = append(rawQueries, r.URL.Query().Encode())
+		jsonResponse(w, http.StatusOK, map[string]any{"results": []any{}})
+	}))
+	defer server.Close()
+
+	params := &YouTubeSearchParams{Query: "go", Features: []YouTubeSearchFeature{FeatureHD, Feature4K, FeatureHD, FeatureSubtitles}}
+	for _, client := range []*Supadata{
+		newTestClient(server),
+		NewSupadata(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithFeaturesEncoding(FeaturesRepeated)),
+	} {
+		if _, err := client.YouTubeSearch(params); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	expected := []string{
+		"features=hd%2C4k%2Csubtitles&query=go",
+		"features=hd&features=4k&features=subtitles&query=go",
+	}
+	for i := range expected {
+		if rawQueries[i] != expected[i] {
+			t.Errorf("expected query %q, got %q", expected[i], rawQueries[i])
+		}
+	}
+
+	_, err := newTestClient(server).YouTubeSearch(&YouTubeSearchParams{Query: "go", Features: []YouTubeSearchFeature{"hd,4k"}})
+	if !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("expected ErrInvalidParams for a feature containing a comma, got %v", err)
+	}
+	if len(rawQueries) != 2 {
+		t.Errorf("expected the invalid search not to be sent, got %d requests", len(rawQueries))
+	}
+}
+
+func TestYouTubeSearchAll_DeduplicatesAcrossPages(t *testing.T) {
+	pages := map[string]map[string]any{
+		"": {"results": []map[string]any{{"type": "video", "id": "a"}, {"type": "video", "id": "b"}}, "nextPageToken": "2"},
+		// The ranking shifted: b is returned again, and a channel shares the ID of a video
+		"2": {"results": []map[string]any{{"type": "video", "id": "b"}, {"type": "channel", "id": "a"}, {"type": "video", "id": "c"}}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, http.StatusOK, pages[r.URL.Query().Get("nextPageToken")])
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	var keys []string
+	for item, err := range client.YouTubeSearchAll(context.Background(), &YouTubeSearchParams{Query: "golang"}, 3) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		keys = append(keys, item.Type+":"+item.Id)
+	}
+
+	expected := fmt.Sprint([]string{"video:a", "video:b", "channel:a"})
+	if fmt.Sprint(keys) != expected {
+		t.Errorf("expected %s, got %v", expected, keys)
+	}
+}
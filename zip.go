@@ -0,0 +1,91 @@
+package supadata
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ZipSink writes every record to its own entry of a zip archive streamed to a writer, with the layout of DirSink:
+// pages as markdown with front-matter, transcripts as plain text and videos as JSON. It is safe for concurrent use.
+type ZipSink struct {
+	mu   sync.Mutex
+	zw   *zip.Writer
+	used map[string]bool
+}
+
+// NewZipSink creates a sink writing a zip archive to w, e.g. an http.ResponseWriter to stream a download.
+// The archive is complete once the sink is closed.
+func NewZipSink(w io.Writer) *ZipSink {
+	return &ZipSink{zw: zip.NewWriter(w), used: make(map[string]bool)}
+}
+
+func (z *ZipSink) write(name, ext string, data []byte) error {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	entry, err := z.zw.CreateHeader(&zip.FileHeader{
+		Name:     uniqueFilename(z.used, name, ext),
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = entry.Write(data)
+	return err
+}
+
+func (z *ZipSink) WriteTranscript(videoId string, transcript *YouTubeTranscriptResult) error {
+	return z.write(transcriptFilename(videoId, transcript), ".txt", []byte(joinTranscript(transcript.Content)+"\n"))
+}
+
+func (z *ZipSink) WritePage(page *CrawlPage) error {
+	return z.write(pageFilename(page.Url), ".md", pageMarkdown(page))
+}
+
+func (z *ZipSink) WriteVideo(video *YouTubeVideo) error {
+	data, err := json.MarshalIndent(video, "", "  ")
+	if err != nil {
+		return err
+	}
+	return z.write(sanitizeFilename(video.Id), ".json", append(data, '\n'))
+}
+
+// Close writes the central directory of the archive; it does not close the underlying writer
+func (z *ZipSink) Close() error {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	return z.zw.Close()
+}
+
+// ExportToZip writes crawl pages, or transcripts keyed by video ID as returned by PlaylistTranscripts and
+// ChannelTranscripts, to w as a zip archive laid out like ZipSink. Transcripts are written in the order of their
+// video IDs. It does not close w.
+func ExportToZip[T []CrawlPage | map[string]*YouTubeTranscriptResult](w io.Writer, items T) error {
+	sink := NewZipSink(w)
+	var err error
+	switch items := any(items).(type) {
+	case []CrawlPage:
+		for i := range items {
+			if err = sink.WritePage(&items[i]); err != nil {
+				break
+			}
+		}
+	case map[string]*YouTubeTranscriptResult:
+		videoIds := make([]string, 0, len(items))
+		for videoId := range items {
+			videoIds = append(videoIds, videoId)
+		}
+		sort.Strings(videoIds)
+		for _, videoId := range videoIds {
+			if err = sink.WriteTranscript(videoId, items[videoId]); err != nil {
+				break
+			}
+		}
+	}
+	return errors.Join(err, sink.Close())
+}
@@ -0,0 +1,69 @@
+package supadata
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+)
+
+// readZip returns the content of every entry of a zip archive by name
+func readZip(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("failed to open archive: %v", err)
+	}
+	entries := make(map[string]string, len(r.File))
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", f.Name, err)
+		}
+		entries[f.Name] = string(content)
+	}
+	return entries
+}
+
+func TestExportToZip_Pages(t *testing.T) {
+	var buf bytes.Buffer
+	err := ExportToZip(&buf, []CrawlPage{
+		{Url: "https://example.com/docs/intro", Name: "Intro", Content: "# Intro"},
+		{Url: "https://example.com/docs/intro/", Name: "Intro again", Content: "dup"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries := readZip(t, buf.Bytes())
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %v", entries)
+	}
+	if content := entries["example.com_docs_intro.md"]; content == "" || !bytes.Contains([]byte(content), []byte("# Intro")) {
+		t.Errorf("unexpected page entry %q", content)
+	}
+	if _, ok := entries["example.com_docs_intro-2.md"]; !ok {
+		t.Errorf("expected duplicate names to get a suffix, got %v", entries)
+	}
+}
+
+func TestExportToZip_Transcripts(t *testing.T) {
+	var buf bytes.Buffer
+	err := ExportToZip(&buf, map[string]*YouTubeTranscriptResult{
+		"dQw4w9WgXcQ": {Lang: "en", Content: []TranscriptContent{{Text: "Never gonna"}, {Text: "give you up"}}},
+		"jNQXAC9IVRw": {Content: []TranscriptContent{{Text: "Me at the zoo"}}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries := readZip(t, buf.Bytes())
+	if entries["dQw4w9WgXcQ.en.txt"] != "Never gonna give you up\n" || entries["jNQXAC9IVRw.txt"] != "Me at the zoo\n" {
+		t.Errorf("unexpected entries %v", entries)
+	}
+}